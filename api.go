@@ -16,6 +16,7 @@ package zoekt
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -84,6 +85,19 @@ type FileMatch struct {
 	// RepositoryID is a Sourcegraph extension. This is the ID of Repository in
 	// Sourcegraph.
 	RepositoryID uint32 `json:",omitempty"`
+
+	// DocID is the document's position within its shard. It is only set when
+	// paginating with SearchOptions.Cursor.
+	DocID uint32 `json:"-"`
+
+	// CommitDate is the date of the last commit that touched the file. It is
+	// zero if the indexer did not record it.
+	CommitDate time.Time
+
+	// CommitHash and CommitAuthor identify the last commit that touched the
+	// file. They are empty if the indexer did not record it.
+	CommitHash   string `json:",omitempty"`
+	CommitAuthor string `json:",omitempty"`
 }
 
 func (m *FileMatch) sizeBytes() (sz uint64) {
@@ -98,6 +112,8 @@ func (m *FileMatch) sizeBytes() (sz uint64) {
 		m.SubRepositoryName,
 		m.SubRepositoryPath,
 		m.Version,
+		m.CommitHash,
+		m.CommitAuthor,
 	} {
 		sz += stringHeaderBytes + uint64(len(s))
 	}
@@ -123,6 +139,12 @@ func (m *FileMatch) sizeBytes() (sz uint64) {
 	// RepositoryID
 	sz += 4
 
+	// DocID
+	sz += 4
+
+	// CommitDate
+	sz += 24
+
 	// RepositoryPriority
 	sz += 8
 
@@ -135,6 +157,86 @@ func (m *FileMatch) sizeBytes() (sz uint64) {
 	return
 }
 
+// CommitMatch is a commit matching a type:commit query.
+type CommitMatch struct {
+	// Repository is the globally unique name of the repo of the
+	// match
+	Repository string
+
+	// RepositoryID is a Sourcegraph extension. This is the ID of Repository in
+	// Sourcegraph.
+	RepositoryID uint32 `json:",omitempty"`
+
+	// Hash is the SHA1 (hex) of the commit.
+	Hash   string
+	Author string
+	Date   time.Time
+
+	// Message is the full commit message.
+	Message string
+
+	// Paths holds the paths changed by the commit.
+	Paths []string `json:",omitempty"`
+
+	// Branches holds the indexed branches containing the commit.
+	Branches []string `json:",omitempty"`
+
+	// One of LineMatches or ChunkMatches will be returned depending on whether
+	// the SearchOptions.ChunkMatches is set. Matches are relative to the
+	// commit message followed by the changed paths, one per line.
+	LineMatches  []LineMatch  `json:",omitempty"`
+	ChunkMatches []ChunkMatch `json:",omitempty"`
+
+	// Ranking; the higher, the better.
+	Score float64 `json:",omitempty"`
+}
+
+func (m *CommitMatch) sizeBytes() (sz uint64) {
+	for _, s := range []string{
+		m.Repository,
+		m.Hash,
+		m.Author,
+		m.Message,
+	} {
+		sz += stringHeaderBytes + uint64(len(s))
+	}
+
+	// RepositoryID
+	sz += 4
+
+	// Date
+	sz += 24
+
+	// Paths
+	sz += sliceHeaderBytes
+	for _, s := range m.Paths {
+		sz += stringHeaderBytes + uint64(len(s))
+	}
+
+	// Branches
+	sz += sliceHeaderBytes
+	for _, s := range m.Branches {
+		sz += stringHeaderBytes + uint64(len(s))
+	}
+
+	// LineMatches
+	sz += sliceHeaderBytes
+	for _, lm := range m.LineMatches {
+		sz += lm.sizeBytes()
+	}
+
+	// ChunkMatches
+	sz += sliceHeaderBytes
+	for _, cm := range m.ChunkMatches {
+		sz += cm.sizeBytes()
+	}
+
+	// Score
+	sz += 8
+
+	return
+}
+
 // AddScore increments the score of the FileMatch by the computed score. If
 // debugScore is true, it also adds a debug string to the FileMatch. If raw is
 // -1, it is ignored. Otherwise, it is added to the debug string.
@@ -368,6 +470,67 @@ func (fr FlushReason) String() string {
 	return "none"
 }
 
+// SortBy is the order in which search results are returned.
+type SortBy uint8
+
+const (
+	// SortByScore returns the best ranked files first. This is the default.
+	SortByScore SortBy = iota
+	// SortByPath sorts files alphabetically by path, then repository.
+	SortByPath
+	// SortByRepo sorts files alphabetically by repository, then path.
+	SortByRepo
+	// SortByCommitDate returns the most recently changed files first. Files
+	// without a commit date come last.
+	SortByCommitDate
+)
+
+var SortByStrings = map[SortBy]string{
+	SortByScore:      "score",
+	SortByPath:       "path",
+	SortByRepo:       "repo",
+	SortByCommitDate: "commitdate",
+}
+
+func (s SortBy) String() string {
+	if v, ok := SortByStrings[s]; ok {
+		return v
+	}
+
+	return "unknown"
+}
+
+// ParseSortBy returns the SortBy named s. The empty string is SortByScore.
+func ParseSortBy(s string) (SortBy, error) {
+	if s == "" {
+		return SortByScore, nil
+	}
+	for sortBy, name := range SortByStrings {
+		if name == s {
+			return sortBy, nil
+		}
+	}
+	return SortByScore, fmt.Errorf("unknown sort order %q", s)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s SortBy) MarshalText() ([]byte, error) {
+	if _, ok := SortByStrings[s]; !ok {
+		return nil, fmt.Errorf("unknown sort order %d", s)
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *SortBy) UnmarshalText(text []byte) error {
+	sortBy, err := ParseSortBy(string(text))
+	if err != nil {
+		return err
+	}
+	*s = sortBy
+	return nil
+}
+
 // Stats contains interesting numbers on the search
 type Stats struct {
 	// Amount of I/O for reading contents.
@@ -524,12 +687,28 @@ type SearchResult struct {
 
 	Files []FileMatch
 
+	// Commits holds the matching commits of type:commit queries.
+	Commits []CommitMatch `json:",omitempty"`
+
 	// RepoURLs holds a repo => template string map.
 	RepoURLs map[string]string
 
 	// FragmentNames holds a repo => template string map, for
 	// the line number fragment.
 	LineFragments map[string]string
+
+	// Facets holds the number of matching files per facet value for each
+	// facet requested in SearchOptions.Facets.
+	Facets Facets `json:",omitempty"`
+
+	// NextCursor resumes the search after the files in this result. It is
+	// only set when paginating with SearchOptions.Cursor, and is nil once all
+	// results have been returned.
+	NextCursor *Cursor `json:",omitempty"`
+
+	// Explain describes how each searched shard evaluated the query. It is
+	// only set if SearchOptions.Explain is set.
+	Explain []ShardExplain `json:",omitempty"`
 }
 
 // SizeBytes is a best-effort estimate of the size of SearchResult in memory.
@@ -545,6 +724,12 @@ func (sr *SearchResult) SizeBytes() (sz uint64) {
 		sz += f.sizeBytes()
 	}
 
+	// Commits
+	sz += sliceHeaderBytes
+	for _, c := range sr.Commits {
+		sz += c.sizeBytes()
+	}
+
 	// RepoURLs
 	sz += mapHeaderBytes
 	for k, v := range sr.RepoURLs {
@@ -559,9 +744,223 @@ func (sr *SearchResult) SizeBytes() (sz uint64) {
 		sz += stringHeaderBytes + uint64(len(v))
 	}
 
+	// Facets
+	sz += mapHeaderBytes
+	for k, v := range sr.Facets {
+		sz += stringHeaderBytes + uint64(len(k))
+		sz += mapHeaderBytes
+		for value := range v {
+			sz += stringHeaderBytes + uint64(len(value))
+			sz += 8 // int
+		}
+	}
+
+	// NextCursor
+	sz += pointerSize
+	if sr.NextCursor != nil {
+		sz += stringHeaderBytes + uint64(len(sr.NextCursor.Shard)) + 4 + 8
+	}
+
+	// Explain
+	sz += sliceHeaderBytes
+	for _, e := range sr.Explain {
+		sz += e.sizeBytes()
+	}
+
 	return
 }
 
+// ShardExplain describes how a shard evaluated a query, for finding out why
+// a query is slow.
+type ShardExplain struct {
+	// Shard is the file name of the shard.
+	Shard string
+
+	// Query is the query after it was simplified for the shard. A shard
+	// which can't match the query simplifies it to false.
+	Query string
+
+	// MatchTree is the pruned matchTree which evaluated the query. It is empty
+	// if the shard was skipped without evaluating the query.
+	MatchTree string `json:",omitempty"`
+
+	// Ngrams lists the ngrams chosen to find the candidate matches of each
+	// substring in MatchTree.
+	Ngrams []NgramExplain `json:",omitempty"`
+
+	// BruteForce is set if a part of MatchTree fell back to visiting every
+	// document of the shard, eg. a regexp without literals to look up in the
+	// ngram index.
+	BruteForce bool `json:",omitempty"`
+
+	// MatchTreeConstruction is the wall time spent building and pruning
+	// MatchTree.
+	MatchTreeConstruction time.Duration
+
+	// MatchTreeSearch is the wall time spent evaluating MatchTree on the
+	// documents of the shard.
+	MatchTreeSearch time.Duration
+
+	// FilesConsidered is the number of documents MatchTree was evaluated
+	// on, and FileCount the number of those which matched.
+	FilesConsidered int
+	FileCount       int
+}
+
+// NgramExplain describes the ngrams chosen to look up the candidate matches
+// of a substring.
+type NgramExplain struct {
+	// Pattern is the substring.
+	Pattern  string
+	FileName bool `json:",omitempty"`
+
+	// Ngrams are the chosen ngrams of Pattern, and Frequencies their number
+	// of occurrences in the shard. There are two ngrams unless Pattern has
+	// only one, or one ngram does not occur at all.
+	Ngrams      []string
+	Frequencies []uint32
+}
+
+func (e *ShardExplain) sizeBytes() (sz uint64) {
+	sz += stringHeaderBytes + uint64(len(e.Shard))
+	sz += stringHeaderBytes + uint64(len(e.Query))
+	sz += stringHeaderBytes + uint64(len(e.MatchTree))
+	sz += sliceHeaderBytes
+	for _, n := range e.Ngrams {
+		sz += stringHeaderBytes + uint64(len(n.Pattern)) + 1
+		sz += sliceHeaderBytes
+		for _, ng := range n.Ngrams {
+			sz += stringHeaderBytes + uint64(len(ng))
+		}
+		sz += sliceHeaderBytes + 4*uint64(len(n.Frequencies))
+	}
+	sz += 1     // BruteForce
+	sz += 2 * 8 // MatchTreeConstruction, MatchTreeSearch
+	sz += 2 * 8 // FilesConsidered, FileCount
+	return
+}
+
+// Names of the facets which can be requested in SearchOptions.Facets.
+const (
+	// FacetRepository counts matching files by repository name.
+	FacetRepository = "repo"
+
+	// FacetLanguage counts matching files by language.
+	FacetLanguage = "lang"
+
+	// FacetCategory counts matching files by file category, eg. "test" or
+	// "vendored".
+	FacetCategory = "category"
+
+	// FacetDirectory counts matching files by top-level directory, eg.
+	// "cmd/". Files in the root directory are counted as "/".
+	FacetDirectory = "dir"
+)
+
+// Facets maps a facet name, eg. FacetLanguage, to the number of matching files
+// for each value of the facet.
+type Facets map[string]map[string]int
+
+// Add adds the counts in o to f. Like Stats, facets of different search
+// results can be added together.
+func (f *Facets) Add(o Facets) {
+	if len(o) == 0 {
+		return
+	}
+	if *f == nil {
+		*f = make(Facets, len(o))
+	}
+	for name, counts := range o {
+		dst := (*f)[name]
+		if dst == nil {
+			dst = make(map[string]int, len(counts))
+			(*f)[name] = dst
+		}
+		for value, n := range counts {
+			dst[value] += n
+		}
+	}
+}
+
+// Inc increments the count of value for the facet name.
+func (f *Facets) Inc(name, value string) {
+	if *f == nil {
+		*f = Facets{}
+	}
+	counts := (*f)[name]
+	if counts == nil {
+		counts = map[string]int{}
+		(*f)[name] = counts
+	}
+	counts[value]++
+}
+
+// Cursor is a resume token for paginating through search results. Results
+// are walked in a fixed order: shards in the order they are ranked by the
+// searcher, and documents within a shard by document ID. A Cursor marks the
+// position in that walk where the next page starts.
+//
+// The zero Cursor starts at the first page.
+type Cursor struct {
+	// Shard identifies the shard to resume in. Empty means the first shard.
+	Shard string
+
+	// Doc is the first document in Shard that has not been returned yet.
+	Doc uint32
+
+	// Score is the score of the last file returned before the cursor.
+	Score float64
+}
+
+// String returns the opaque encoding of c, suitable for URL parameters.
+func (c *Cursor) String() string {
+	b, _ := c.MarshalText()
+	return string(b)
+}
+
+// MarshalText implements encoding.TextMarshaler. The encoding is opaque to
+// clients.
+func (c *Cursor) MarshalText() ([]byte, error) {
+	b, err := json.Marshal(cursorJSON{S: c.Shard, D: c.Doc, Sc: c.Score})
+	if err != nil {
+		return nil, err
+	}
+	return base64.RawURLEncoding.AppendEncode(nil, b), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *Cursor) UnmarshalText(text []byte) error {
+	cur, err := ParseCursor(string(text))
+	if err != nil {
+		return err
+	}
+	*c = *cur
+	return nil
+}
+
+type cursorJSON struct {
+	S  string  `json:"s,omitempty"`
+	D  uint32  `json:"d,omitempty"`
+	Sc float64 `json:"sc,omitempty"`
+}
+
+// ParseCursor parses a cursor previously returned by Cursor.String. The empty
+// string parses to the zero Cursor.
+func ParseCursor(s string) (*Cursor, error) {
+	if s == "" {
+		return &Cursor{}, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor %q: %w", s, err)
+	}
+	var cj cursorJSON
+	if err := json.Unmarshal(b, &cj); err != nil {
+		return nil, fmt.Errorf("invalid cursor %q: %w", s, err)
+	}
+	return &Cursor{Shard: cj.S, Doc: cj.D, Score: cj.Sc}, nil
+}
+
 // RepositoryBranch describes an indexed branch, which is a name
 // combined with a version.
 type RepositoryBranch struct {
@@ -782,6 +1181,14 @@ type IndexMetadata struct {
 	LanguageMap           map[string]uint16
 	ZoektVersion          string
 	ID                    string
+
+	// ContentEncoding is how document contents are stored in the shard. It
+	// is empty for raw contents.
+	ContentEncoding string `json:",omitempty"`
+
+	// ContentBlockDocs is the number of documents per compressed block of
+	// contents.
+	ContentBlockDocs int `json:",omitempty"`
 }
 
 // Statistics of a (collection of) repositories.
@@ -1018,6 +1425,27 @@ type SearchOptions struct {
 
 	// SpanContext is the opentracing span context, if it exists, from the zoekt client
 	SpanContext map[string]string
+
+	// Facets lists the facets, eg. FacetLanguage, for which
+	// SearchResult.Facets should count the matching files. Unknown names are
+	// ignored.
+	Facets []string
+
+	// Cursor enables pagination if non-nil. The search resumes at the cursor
+	// and returns at most MaxDocDisplayCount files, in the order described by
+	// Cursor. SearchResult.NextCursor fetches the following page. Stats and
+	// Facets describe the files searched to fill the page, which can include
+	// files beyond it.
+	Cursor *Cursor
+
+	// SortBy is the order of the returned files. Orders other than SortByScore
+	// need all results before any can be returned, so streamed results are
+	// only flushed once the search is done.
+	SortBy SortBy
+
+	// If set, SearchResult.Explain describes how each shard evaluated the
+	// query.
+	Explain bool
 }
 
 func (o *SearchOptions) SetDefaults() {
@@ -1082,6 +1510,17 @@ func (s *SearchOptions) String() string {
 	addBool("UseBM25Scoring", s.UseBM25Scoring)
 	addBool("Trace", s.Trace)
 	addBool("DebugScore", s.DebugScore)
+	addBool("Explain", s.Explain)
+
+	if len(s.Facets) > 0 {
+		add("Facets", strings.Join(s.Facets, ","))
+	}
+	if s.Cursor != nil {
+		add("Cursor", s.Cursor.String())
+	}
+	if s.SortBy != SortByScore {
+		add("SortBy", s.SortBy.String())
+	}
 
 	for k, v := range s.SpanContext {
 		add("SpanContext."+k, strconv.Quote(v))
@@ -17,6 +17,7 @@ package zoekt // import "github.com/sourcegraph/zoekt"
 import (
 	"math/rand"
 	"reflect"
+	"time"
 
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -35,6 +36,11 @@ func FileMatchFromProto(p *webserverv1.FileMatch) FileMatch {
 		chunkMatches[i] = ChunkMatchFromProto(chunkMatch)
 	}
 
+	var commitDate time.Time
+	if p.GetCommitDate() != nil {
+		commitDate = p.GetCommitDate().AsTime()
+	}
+
 	return FileMatch{
 		Score:              p.GetScore(),
 		Debug:              p.GetDebug(),
@@ -51,6 +57,10 @@ func FileMatchFromProto(p *webserverv1.FileMatch) FileMatch {
 		SubRepositoryName:  p.GetSubRepositoryName(),
 		SubRepositoryPath:  p.GetSubRepositoryPath(),
 		Version:            p.GetVersion(),
+		DocID:              p.GetDocId(),
+		CommitDate:         commitDate,
+		CommitHash:         p.GetCommitHash(),
+		CommitAuthor:       p.GetCommitAuthor(),
 	}
 }
 
@@ -65,6 +75,11 @@ func (m *FileMatch) ToProto() *webserverv1.FileMatch {
 		chunkMatches[i] = cm.ToProto()
 	}
 
+	var commitDate *timestamppb.Timestamp
+	if !m.CommitDate.IsZero() {
+		commitDate = timestamppb.New(m.CommitDate)
+	}
+
 	return &webserverv1.FileMatch{
 		Score:              m.Score,
 		Debug:              m.Debug,
@@ -81,6 +96,72 @@ func (m *FileMatch) ToProto() *webserverv1.FileMatch {
 		SubRepositoryName:  m.SubRepositoryName,
 		SubRepositoryPath:  m.SubRepositoryPath,
 		Version:            m.Version,
+		DocId:              m.DocID,
+		CommitDate:         commitDate,
+		CommitHash:         m.CommitHash,
+		CommitAuthor:       m.CommitAuthor,
+	}
+}
+
+func CommitMatchFromProto(p *webserverv1.CommitMatch) CommitMatch {
+	lineMatches := make([]LineMatch, len(p.GetLineMatches()))
+	for i, lineMatch := range p.GetLineMatches() {
+		lineMatches[i] = LineMatchFromProto(lineMatch)
+	}
+
+	chunkMatches := make([]ChunkMatch, len(p.GetChunkMatches()))
+	for i, chunkMatch := range p.GetChunkMatches() {
+		chunkMatches[i] = ChunkMatchFromProto(chunkMatch)
+	}
+
+	var date time.Time
+	if p.GetDate() != nil {
+		date = p.GetDate().AsTime()
+	}
+
+	return CommitMatch{
+		Repository:   p.GetRepository(),
+		RepositoryID: p.GetRepositoryId(),
+		Hash:         p.GetHash(),
+		Author:       p.GetAuthor(),
+		Date:         date,
+		Message:      p.GetMessage(),
+		Paths:        p.GetPaths(),
+		Branches:     p.GetBranches(),
+		LineMatches:  lineMatches,
+		ChunkMatches: chunkMatches,
+		Score:        p.GetScore(),
+	}
+}
+
+func (m *CommitMatch) ToProto() *webserverv1.CommitMatch {
+	lineMatches := make([]*webserverv1.LineMatch, len(m.LineMatches))
+	for i, lm := range m.LineMatches {
+		lineMatches[i] = lm.ToProto()
+	}
+
+	chunkMatches := make([]*webserverv1.ChunkMatch, len(m.ChunkMatches))
+	for i, cm := range m.ChunkMatches {
+		chunkMatches[i] = cm.ToProto()
+	}
+
+	var date *timestamppb.Timestamp
+	if !m.Date.IsZero() {
+		date = timestamppb.New(m.Date)
+	}
+
+	return &webserverv1.CommitMatch{
+		Repository:   m.Repository,
+		RepositoryId: m.RepositoryID,
+		Hash:         m.Hash,
+		Author:       m.Author,
+		Date:         date,
+		Message:      m.Message,
+		Paths:        m.Paths,
+		Branches:     m.Branches,
+		LineMatches:  lineMatches,
+		ChunkMatches: chunkMatches,
+		Score:        m.Score,
 	}
 }
 
@@ -270,6 +351,32 @@ func (fr FlushReason) ToProto() webserverv1.FlushReason {
 	}
 }
 
+func SortByFromProto(p webserverv1.SortBy) SortBy {
+	switch p {
+	case webserverv1.SortBy_SORT_BY_PATH:
+		return SortByPath
+	case webserverv1.SortBy_SORT_BY_REPO:
+		return SortByRepo
+	case webserverv1.SortBy_SORT_BY_COMMIT_DATE:
+		return SortByCommitDate
+	default:
+		return SortByScore
+	}
+}
+
+func (s SortBy) ToProto() webserverv1.SortBy {
+	switch s {
+	case SortByPath:
+		return webserverv1.SortBy_SORT_BY_PATH
+	case SortByRepo:
+		return webserverv1.SortBy_SORT_BY_REPO
+	case SortByCommitDate:
+		return webserverv1.SortBy_SORT_BY_COMMIT_DATE
+	default:
+		return webserverv1.SortBy_SORT_BY_SCORE_UNSPECIFIED
+	}
+}
+
 // Generate valid reasons for quickchecks
 func (fr FlushReason) Generate(rand *rand.Rand, size int) reflect.Value {
 	switch rand.Int() % 4 {
@@ -366,14 +473,31 @@ func SearchResultFromProto(p *webserverv1.SearchResponse, repoURLs, lineFragment
 		files[i] = FileMatchFromProto(file)
 	}
 
+	commits := make([]CommitMatch, len(p.GetCommits()))
+	for i, commit := range p.GetCommits() {
+		commits[i] = CommitMatchFromProto(commit)
+	}
+
+	explain := make([]ShardExplain, len(p.GetExplain()))
+	for i, e := range p.GetExplain() {
+		explain[i] = ShardExplainFromProto(e)
+	}
+
 	return &SearchResult{
 		Stats:    StatsFromProto(p.GetStats()),
 		Progress: ProgressFromProto(p.GetProgress()),
 
-		Files: files,
+		Files:   files,
+		Commits: commits,
 
 		RepoURLs:      repoURLs,
 		LineFragments: lineFragments,
+
+		Facets: FacetsFromProto(p.GetFacets()),
+
+		NextCursor: CursorFromProto(p.GetNextCursor()),
+
+		Explain: explain,
 	}
 }
 
@@ -387,12 +511,133 @@ func (sr *SearchResult) ToProto() *webserverv1.SearchResponse {
 		files[i] = file.ToProto()
 	}
 
+	commits := make([]*webserverv1.CommitMatch, len(sr.Commits))
+	for i, commit := range sr.Commits {
+		commits[i] = commit.ToProto()
+	}
+
+	explain := make([]*webserverv1.ShardExplain, len(sr.Explain))
+	for i, e := range sr.Explain {
+		explain[i] = e.ToProto()
+	}
+
 	return &webserverv1.SearchResponse{
 		Stats:    sr.Stats.ToProto(),
 		Progress: sr.Progress.ToProto(),
 
-		Files: files,
+		Files:   files,
+		Commits: commits,
+
+		Facets: sr.Facets.ToProto(),
+
+		NextCursor: sr.NextCursor.ToProto(),
+
+		Explain: explain,
+	}
+}
+
+func ShardExplainFromProto(p *webserverv1.ShardExplain) ShardExplain {
+	ngrams := make([]NgramExplain, len(p.GetNgrams()))
+	for i, n := range p.GetNgrams() {
+		ngrams[i] = NgramExplain{
+			Pattern:     n.GetPattern(),
+			FileName:    n.GetFileName(),
+			Ngrams:      append([]string{}, n.GetNgrams()...),
+			Frequencies: append([]uint32{}, n.GetFrequencies()...),
+		}
+	}
+
+	return ShardExplain{
+		Shard:                 p.GetShard(),
+		Query:                 p.GetQuery(),
+		MatchTree:             p.GetMatchTree(),
+		Ngrams:                ngrams,
+		BruteForce:            p.GetBruteForce(),
+		MatchTreeConstruction: p.GetMatchTreeConstruction().AsDuration(),
+		MatchTreeSearch:       p.GetMatchTreeSearch().AsDuration(),
+		FilesConsidered:       int(p.GetFilesConsidered()),
+		FileCount:             int(p.GetFileCount()),
+	}
+}
+
+func (e *ShardExplain) ToProto() *webserverv1.ShardExplain {
+	ngrams := make([]*webserverv1.NgramExplain, len(e.Ngrams))
+	for i, n := range e.Ngrams {
+		ngrams[i] = &webserverv1.NgramExplain{
+			Pattern:     n.Pattern,
+			FileName:    n.FileName,
+			Ngrams:      n.Ngrams,
+			Frequencies: n.Frequencies,
+		}
+	}
+
+	return &webserverv1.ShardExplain{
+		Shard:                 e.Shard,
+		Query:                 e.Query,
+		MatchTree:             e.MatchTree,
+		Ngrams:                ngrams,
+		BruteForce:            e.BruteForce,
+		MatchTreeConstruction: durationpb.New(e.MatchTreeConstruction),
+		MatchTreeSearch:       durationpb.New(e.MatchTreeSearch),
+		FilesConsidered:       int64(e.FilesConsidered),
+		FileCount:             int64(e.FileCount),
+	}
+}
+
+func CursorFromProto(p *webserverv1.Cursor) *Cursor {
+	if p == nil {
+		return nil
+	}
+
+	return &Cursor{
+		Shard: p.GetShard(),
+		Doc:   p.GetDoc(),
+		Score: p.GetScore(),
+	}
+}
+
+func (c *Cursor) ToProto() *webserverv1.Cursor {
+	if c == nil {
+		return nil
+	}
+
+	return &webserverv1.Cursor{
+		Shard: c.Shard,
+		Doc:   c.Doc,
+		Score: c.Score,
+	}
+}
+
+func FacetsFromProto(p map[string]*webserverv1.FacetCounts) Facets {
+	if len(p) == 0 {
+		return nil
+	}
+
+	facets := make(Facets, len(p))
+	for name, fc := range p {
+		counts := make(map[string]int, len(fc.GetCounts()))
+		for value, n := range fc.GetCounts() {
+			counts[value] = int(n)
+		}
+		facets[name] = counts
+	}
+	return facets
+}
+
+func (f Facets) ToProto() map[string]*webserverv1.FacetCounts {
+	if len(f) == 0 {
+		return nil
+	}
+
+	p := make(map[string]*webserverv1.FacetCounts, len(f))
+	for name, counts := range f {
+		fc := &webserverv1.FacetCounts{Counts: make(map[string]int64, len(counts))}
+		for value, n := range counts {
+			fc.Counts[value] = int64(n)
+		}
+		p[name] = fc
 	}
+	return p
 }
 
 func (sr *SearchResult) ToStreamProto() *webserverv1.StreamSearchResponse {
@@ -513,6 +758,8 @@ func IndexMetadataFromProto(p *webserverv1.IndexMetadata) IndexMetadata {
 		LanguageMap:           languageMap,
 		ZoektVersion:          p.GetZoektVersion(),
 		ID:                    p.GetId(),
+		ContentEncoding:       p.GetContentEncoding(),
+		ContentBlockDocs:      int(p.GetContentBlockDocs()),
 	}
 }
 
@@ -535,6 +782,8 @@ func (m *IndexMetadata) ToProto() *webserverv1.IndexMetadata {
 		LanguageMap:           languageMap,
 		ZoektVersion:          m.ZoektVersion,
 		Id:                    m.ID,
+		ContentEncoding:       m.ContentEncoding,
+		ContentBlockDocs:      int64(m.ContentBlockDocs),
 	}
 }
 
@@ -704,6 +953,10 @@ func SearchOptionsFromProto(p *webserverv1.SearchOptions) *SearchOptions {
 		Trace:                  p.GetTrace(),
 		DebugScore:             p.GetDebugScore(),
 		UseBM25Scoring:         p.GetUseBm25Scoring(),
+		Facets:                 p.GetFacets(),
+		Cursor:                 CursorFromProto(p.GetCursor()),
+		SortBy:                 SortByFromProto(p.GetSortBy()),
+		Explain:                p.GetExplain(),
 	}
 }
 
@@ -727,5 +980,9 @@ func (s *SearchOptions) ToProto() *webserverv1.SearchOptions {
 		Trace:                  s.Trace,
 		DebugScore:             s.DebugScore,
 		UseBm25Scoring:         s.UseBM25Scoring,
+		Facets:                 s.Facets,
+		Cursor:                 s.Cursor.ToProto(),
+		SortBy:                 s.SortBy.ToProto(),
+		Explain:                s.Explain,
 	}
 }
@@ -45,6 +45,17 @@ func TestProtoRoundtrip(t *testing.T) {
 		}
 	})
 
+	t.Run("CommitMatch", func(t *testing.T) {
+		f := func(f1 CommitMatch) bool {
+			p1 := f1.ToProto()
+			f2 := CommitMatchFromProto(p1)
+			return reflect.DeepEqual(f1, f2)
+		}
+		if err := quick.Check(f, nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+
 	t.Run("ChunkMatch", func(t *testing.T) {
 		f := func(f1 ChunkMatch) bool {
 			p1 := f1.ToProto()
@@ -362,9 +373,59 @@ func (*IndexMetadata) Generate(r *rand.Rand, _ int) reflect.Value {
 	i.LanguageMap = gen(i.LanguageMap, r)
 	i.ZoektVersion = gen(i.ZoektVersion, r)
 	i.ID = gen(i.ID, r)
+	i.ContentEncoding = gen(i.ContentEncoding, r)
+	i.ContentBlockDocs = gen(i.ContentBlockDocs, r)
 	return reflect.ValueOf(&i)
 }
 
+func (FileMatch) Generate(rng *rand.Rand, _ int) reflect.Value {
+	var f FileMatch
+	v := FileMatch{
+		FileName:           gen(f.FileName, rng),
+		Repository:         gen(f.Repository, rng),
+		SubRepositoryName:  gen(f.SubRepositoryName, rng),
+		SubRepositoryPath:  gen(f.SubRepositoryPath, rng),
+		Version:            gen(f.Version, rng),
+		Language:           gen(f.Language, rng),
+		Debug:              gen(f.Debug, rng),
+		Branches:           gen(f.Branches, rng),
+		LineMatches:        gen(f.LineMatches, rng),
+		ChunkMatches:       gen(f.ChunkMatches, rng),
+		Content:            gen(f.Content, rng),
+		Checksum:           gen(f.Checksum, rng),
+		Score:              gen(f.Score, rng),
+		RepositoryPriority: gen(f.RepositoryPriority, rng),
+		RepositoryID:       gen(f.RepositoryID, rng),
+		DocID:              gen(f.DocID, rng),
+		CommitHash:         gen(f.CommitHash, rng),
+		CommitAuthor:       gen(f.CommitAuthor, rng),
+	}
+	if rng.Intn(2) == 0 {
+		v.CommitDate = time.Unix(rng.Int63n(1<<32), 0).UTC()
+	}
+	return reflect.ValueOf(v)
+}
+
+func (CommitMatch) Generate(rng *rand.Rand, _ int) reflect.Value {
+	var c CommitMatch
+	v := CommitMatch{
+		Repository:   gen(c.Repository, rng),
+		RepositoryID: gen(c.RepositoryID, rng),
+		Hash:         gen(c.Hash, rng),
+		Author:       gen(c.Author, rng),
+		Message:      gen(c.Message, rng),
+		Paths:        gen(c.Paths, rng),
+		Branches:     gen(c.Branches, rng),
+		LineMatches:  gen(c.LineMatches, rng),
+		ChunkMatches: gen(c.ChunkMatches, rng),
+		Score:        gen(c.Score, rng),
+	}
+	if rng.Intn(2) == 0 {
+		v.Date = time.Unix(rng.Int63n(1<<32), 0).UTC()
+	}
+	return reflect.ValueOf(v)
+}
+
 func (*Repository) Generate(rng *rand.Rand, _ int) reflect.Value {
 	latestCommitDate := time.Now().Add(time.Duration(rng.Int63n(1000)) * time.Hour)
 	var r Repository
@@ -390,6 +451,10 @@ func (*Repository) Generate(rng *rand.Rand, _ int) reflect.Value {
 	return reflect.ValueOf(v)
 }
 
+func (SortBy) Generate(rng *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(SortBy(rng.Intn(len(SortByStrings))))
+}
+
 func (RepoListField) Generate(rng *rand.Rand, _ int) reflect.Value {
 	if rng.Intn(2) == 0 {
 		return reflect.ValueOf(RepoListField(RepoListFieldRepos))
@@ -503,3 +568,13 @@ func Fuzz_RepoList_ProtoRoundTrip(f *testing.F) {
 		}
 	})
 }
+
+func (Facets) Generate(rng *rand.Rand, _ int) reflect.Value {
+	var f map[string]map[string]int
+	f = gen(f, rng)
+	if len(f) == 0 {
+		// The proto representation does not distinguish empty and nil facets.
+		return reflect.ValueOf(Facets(nil))
+	}
+	return reflect.ValueOf(Facets(f))
+}
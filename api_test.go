@@ -17,6 +17,7 @@ package zoekt // import "github.com/sourcegraph/zoekt"
 import (
 	"bytes"
 	"encoding/gob"
+	"encoding/json"
 	"reflect"
 	"strings"
 	"testing"
@@ -88,7 +89,7 @@ func TestSizeBytesSearchResult(t *testing.T) {
 	sr := SearchResult{
 		Stats:    Stats{},    // 129 bytes
 		Progress: Progress{}, // 16 bytes
-		Files: []FileMatch{{ // 24 bytes + 460 bytes
+		Files: []FileMatch{{ // 24 bytes + 520 bytes
 			Score:       0,   // 8 bytes
 			Debug:       "",  // 16 bytes
 			FileName:    "",  // 16 bytes
@@ -104,20 +105,40 @@ func TestSizeBytesSearchResult(t *testing.T) {
 				Score:        0,
 				DebugScore:   "",
 			}},
-			RepositoryID:       0,   // 4 bytes
-			RepositoryPriority: 0,   // 8 bytes
-			Content:            nil, // 24 bytes
-			Checksum:           nil, // 24 bytes
-			Language:           "",  // 16 bytes
-			SubRepositoryName:  "",  // 16 bytes
-			SubRepositoryPath:  "",  // 16 bytes
-			Version:            "",  // 16 bytes
+			RepositoryID:       0,           // 4 bytes
+			DocID:              0,           // 4 bytes
+			CommitDate:         time.Time{}, // 24 bytes
+			RepositoryPriority: 0,           // 8 bytes
+			Content:            nil,         // 24 bytes
+			Checksum:           nil,         // 24 bytes
+			Language:           "",          // 16 bytes
+			SubRepositoryName:  "",          // 16 bytes
+			SubRepositoryPath:  "",          // 16 bytes
+			Version:            "",          // 16 bytes
+			CommitHash:         "",          // 16 bytes
+			CommitAuthor:       "",          // 16 bytes
+		}},
+		Commits: []CommitMatch{{ // 24 bytes + 199 bytes
+			Repository:   "",          // 16 bytes
+			RepositoryID: 0,           // 4 bytes
+			Hash:         "abc",       // 19 bytes
+			Author:       "",          // 16 bytes
+			Date:         time.Time{}, // 24 bytes
+			Message:      "",          // 16 bytes
+			Paths:        nil,         // 24 bytes
+			Branches:     nil,         // 24 bytes
+			LineMatches:  nil,         // 24 bytes
+			ChunkMatches: nil,         // 24 bytes
+			Score:        0,           // 8 bytes
 		}},
 		RepoURLs:      nil, // 48 bytes
 		LineFragments: nil, // 48 bytes
+		Facets:        nil, // 48 bytes
+		NextCursor:    nil, // 8 bytes
+		Explain:       nil, // 24 bytes
 	}
 
-	var wantBytes uint64 = 725
+	var wantBytes uint64 = 1088
 	if sr.SizeBytes() != wantBytes {
 		t.Fatalf("want %d, got %d", wantBytes, sr.SizeBytes())
 	}
@@ -146,7 +167,7 @@ func TestMatchSize(t *testing.T) {
 		size int
 	}{{
 		v:    FileMatch{},
-		size: 256,
+		size: 312,
 	}, {
 		v:    ChunkMatch{},
 		size: 120,
@@ -180,11 +201,20 @@ func TestSearchOptions_String(t *testing.T) {
 			f.SetInt(1)
 		case reflect.Int64:
 			f.SetInt(1)
+		case reflect.Uint8:
+			// Only uint8 is SortBy
+			f.SetUint(uint64(SortByPath))
 		case reflect.Float64:
 			f.SetFloat(1)
 		case reflect.Map:
 			// Only map is SpanContext
 			f.Set(reflect.ValueOf(map[string]string{"key": "value"}))
+		case reflect.Slice:
+			// Only slice is Facets
+			f.Set(reflect.ValueOf([]string{FacetLanguage}))
+		case reflect.Pointer:
+			// Only pointer is Cursor
+			f.Set(reflect.ValueOf(&Cursor{Shard: "shard", Doc: 1}))
 		default:
 			t.Fatalf("add support for %s field (%s)", f.Kind(), name)
 		}
@@ -388,3 +418,35 @@ func TestMonthsSince1970(t *testing.T) {
 		})
 	}
 }
+
+func TestCursor(t *testing.T) {
+	for _, c := range []Cursor{
+		{},
+		{Shard: "/data/index/github.com%2Fsourcegraph%2Fzoekt_v16.00000.zoekt", Doc: 42, Score: 1234.5},
+	} {
+		got, err := ParseCursor(c.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *got != c {
+			t.Errorf("got %+v, want %+v", *got, c)
+		}
+
+		// Cursors are opaque strings in JSON.
+		b, err := json.Marshal(SearchResult{NextCursor: &c})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var sr SearchResult
+		if err := json.Unmarshal(b, &sr); err != nil {
+			t.Fatal(err)
+		}
+		if sr.NextCursor == nil || *sr.NextCursor != c {
+			t.Errorf("JSON roundtrip: got %+v, want %+v", sr.NextCursor, c)
+		}
+	}
+
+	if _, err := ParseCursor("not a cursor"); err == nil {
+		t.Error("expected an error for an invalid cursor")
+	}
+}
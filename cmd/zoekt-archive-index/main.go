@@ -20,6 +20,7 @@ import (
 func main() {
 	var (
 		incremental = flag.Bool("incremental", true, "only index changed repositories")
+		delta       = flag.Bool("delta", false, "only index files which changed since the last run of a repository")
 
 		name   = flag.String("name", "", "The repository name for the archive")
 		urlRaw = flag.String("url", "", "The repository URL for the archive")
@@ -43,6 +44,7 @@ func main() {
 	bopts := cmd.OptionsFromFlags()
 	opts := archive.Options{
 		Incremental: *incremental,
+		Delta:       *delta,
 
 		Archive: archiveURL,
 		Name:    *name,
@@ -46,6 +46,8 @@ func run() int {
 	isDelta := flag.Bool("delta", false, "whether we should use delta build")
 	deltaShardNumberFallbackThreshold := flag.Uint64("delta_threshold", 0, "upper limit on the number of preexisting shards that can exist before attempting a delta build (0 to disable fallback behavior)")
 	languageMap := flag.String("language_map", "", "a mapping between a language and its ctags processor (a:0,b:3).")
+	fileCommits := flag.Bool("file_commits", false, "record the last commit of each file. This walks the history of the indexed branches.")
+	commits := flag.Bool("commits", false, "index the commit history of the indexed branches, searchable with type:commit. Disables delta builds.")
 
 	cpuProfile := flag.String("cpu_profile", "", "write cpu profile to `file`")
 
@@ -132,6 +134,8 @@ func run() int {
 			Branches:                          branches,
 			RepoDir:                           dir,
 			DeltaShardNumberFallbackThreshold: *deltaShardNumberFallbackThreshold,
+			FileCommits:                       *fileCommits,
+			Commits:                           *commits,
 		}
 
 		if _, err := gitindex.IndexGitRepo(gitOpts); err != nil {
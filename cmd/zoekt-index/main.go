@@ -64,6 +64,7 @@ func main() {
 	cpuProfile := flag.String("cpu_profile", "", "write cpu profile to file")
 	ignoreDirs := flag.String("ignore_dirs", ".git,.hg,.svn", "comma separated list of directories to ignore.")
 	metaFile := flag.String("meta", "", "path to .meta JSON file with repository description")
+	isDelta := flag.Bool("delta", false, "only index files whose path or content changed since the last run, and tombstone the others in the existing shards. Falls back to a full build if there are no usable shards.")
 	flag.Parse()
 
 	if flag.NArg() == 0 {
@@ -77,6 +78,8 @@ func main() {
 	_, _ = maxprocs.Set()
 
 	opts := cmd.OptionsFromFlags()
+	opts.IsDelta = *isDelta
+	opts.DeltaByChecksum = *isDelta
 	if *cpuProfile != "" {
 		f, err := os.Create(*cpuProfile)
 		if err != nil {
@@ -61,6 +61,7 @@ type Options struct {
 	mirrorConfigFile string
 	maxLogAge        time.Duration
 	indexTimeout     time.Duration
+	compaction       index.CompactionThresholds
 }
 
 func (o *Options) validate() {
@@ -84,6 +85,8 @@ func (o *Options) defineFlags() {
 	flag.DurationVar(&o.mirrorInterval, "mirror_duration", 24*time.Hour, "find and clone new repos at this frequency.")
 	flag.Float64Var(&o.cpuFraction, "cpu_fraction", 0.25,
 		"use this fraction of the cores for indexing.")
+	flag.IntVar(&o.compaction.MaxDeltaShards, "compact_delta_shards", 16, "compact the shards of a repository once it has this many delta shards; 0 disables the check")
+	flag.Int64Var(&o.compaction.MaxDeltaBytes, "compact_delta_bytes", 0, "compact the shards of a repository once its delta shards have this many bytes; 0 disables the check")
 	flag.StringVar(&o.indexFlagsStr, "git_index_flags", "", "space separated list of flags passed through to zoekt-git-index (e.g. -git_index_flags='-symbols=false -submodules=false'")
 }
 
@@ -140,6 +143,7 @@ func fetchGitRepo(dir string) bool {
 func indexPendingRepos(indexDir, repoDir string, opts *Options, repos <-chan string) {
 	for dir := range repos {
 		indexPendingRepo(dir, indexDir, repoDir, opts)
+		compactDeltaShards(indexDir, opts.compaction)
 
 		// Failures (eg. timeout) will leave temp files
 		// around. We have to clean them, or they will fill up the indexing volume.
@@ -169,6 +173,38 @@ func indexPendingRepo(dir, indexDir, repoDir string, opts *Options) {
 	loggedRun(cmd)
 }
 
+// compactDeltaShards compacts the shards of the repositories in indexDir
+// whose delta shards exceed t. It runs between index jobs, so that it never
+// races with a delta build of the same repository.
+func compactDeltaShards(indexDir string, t index.CompactionThresholds) {
+	// Only repositories with more than one shard can have delta shards.
+	second, err := filepath.Glob(filepath.Join(indexDir, "*.00001.zoekt"))
+	if err != nil {
+		log.Printf("Glob: %v", err)
+		return
+	}
+	for _, fn := range second {
+		first := strings.TrimSuffix(fn, ".00001.zoekt") + ".00000.zoekt"
+		repos, _, err := index.ReadMetadataPath(first)
+		if err != nil || len(repos) != 1 {
+			continue
+		}
+		opts := index.Options{
+			IndexDir:              indexDir,
+			RepositoryDescription: *repos[0],
+		}
+		if ok, err := opts.NeedsCompaction(t); err != nil {
+			log.Printf("NeedsCompaction(%s): %v", repos[0].Name, err)
+			continue
+		} else if !ok {
+			continue
+		}
+		if err := index.CompactDeltaShards(opts); err != nil {
+			log.Printf("CompactDeltaShards(%s): %v", repos[0].Name, err)
+		}
+	}
+}
+
 // deleteLogs deletes old logs.
 func deleteLogs(logDir string, maxAge time.Duration) {
 	fs, err := filepath.Glob(filepath.Join(logDir, "*"))
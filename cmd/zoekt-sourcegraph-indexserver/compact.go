@@ -0,0 +1,32 @@
+package main
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sourcegraph/zoekt/index"
+)
+
+var metricDeltaCompactionDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "index_delta_compaction_duration_seconds",
+	Help:    "The duration of compacting the delta shards of 1 repository.",
+	Buckets: prometheus.ExponentialBuckets(1, 2, 12),
+}, []string{"error"})
+
+// compactDeltaShards folds the delta shards of the repository in bo into a
+// normal shard if they exceed t. It reports whether it compacted. The caller
+// must hold the lock of the repository in muIndexDir, so that no delta build
+// adds shards concurrently.
+func compactDeltaShards(bo *index.Options, t index.CompactionThresholds) (bool, error) {
+	if ok, err := bo.NeedsCompaction(t); err != nil || !ok {
+		return false, err
+	}
+
+	start := time.Now()
+	err := index.CompactDeltaShards(*bo)
+	metricDeltaCompactionDuration.WithLabelValues(strconv.FormatBool(err != nil)).Observe(time.Since(start).Seconds())
+	return err == nil, err
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/zoekt"
+	"github.com/sourcegraph/zoekt/index"
+)
+
+func TestCompactDeltaShards(t *testing.T) {
+	bo := &index.Options{
+		IndexDir:              t.TempDir(),
+		RepositoryDescription: zoekt.Repository{Name: "repo", ID: 1},
+		DisableCTags:          true,
+		IsDelta:               true,
+		DeltaByChecksum:       true,
+	}
+	for _, content := range []string{"package a", "package a2", "package a3"} {
+		b, err := index.NewBuilder(*bo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := b.AddFile("a.go", []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.Finish(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ok, err := compactDeltaShards(bo, index.CompactionThresholds{MaxDeltaShards: 3})
+	if err != nil || ok {
+		t.Fatalf("got %v, %v below the threshold, want false", ok, err)
+	}
+	if got := len(bo.FindAllShards()); got != 3 {
+		t.Fatalf("got %d shards, want 3", got)
+	}
+
+	ok, err = compactDeltaShards(bo, index.CompactionThresholds{MaxDeltaShards: 2})
+	if err != nil || !ok {
+		t.Fatalf("got %v, %v at the threshold, want true", ok, err)
+	}
+	if got := len(bo.FindAllShards()); got != 1 {
+		t.Fatalf("got %d shards after compaction, want 1", got)
+	}
+}
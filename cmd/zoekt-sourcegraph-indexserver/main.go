@@ -231,6 +231,10 @@ type Server struct {
 	// before attempting a delta build.
 	deltaShardNumberFallbackThreshold uint64
 
+	// deltaCompaction defines when the delta shards of a repository are
+	// compacted after a delta build.
+	deltaCompaction index.CompactionThresholds
+
 	// repositoriesSkipSymbolsCalculationAllowList is an allowlist for repositories that
 	// we skip calculating symbols metadata for during builds
 	repositoriesSkipSymbolsCalculationAllowList map[string]struct{}
@@ -683,6 +687,17 @@ func (s *Server) index(ctx context.Context, args *indexArgs) (state indexState,
 		return indexStateFail, err
 	}
 
+	// Callers hold the lock of the repository, so compaction doesn't race
+	// with the next delta build.
+	if args.UseDelta {
+		if ok, err := compactDeltaShards(args.BuildOptions(), s.deltaCompaction); err != nil {
+			errorLog.Printf("error compacting delta shards of %s: %s", args.String(), err)
+		} else if ok {
+			tr.LazyPrintf("compacted delta shards")
+			infoLog.Printf("compacted delta shards of %s", args.String())
+		}
+	}
+
 	if err := updateIndexStatusOnSourcegraph(c, args, s.Sourcegraph); err != nil {
 		s.logger.Error("failed to update index status",
 			sglog.String("repo", args.Name),
@@ -1749,6 +1764,12 @@ func newServer(conf rootConfig) (*Server, error) {
 		debugLog.Printf("disabling delta build fallback behavior - delta builds will be performed regardless of the number of preexisting shards")
 	}
 
+	deltaCompaction := index.CompactionThresholds{
+		MaxDeltaShards: getEnvWithDefaultInt("DELTA_COMPACTION_MAX_SHARDS", 32),
+		MaxDeltaBytes:  getEnvWithDefaultInt64("DELTA_COMPACTION_MAX_SIZE", 0) * 1024 * 1024,
+	}
+	debugLog.Printf("compacting delta shards at %d shard(s) or %d bytes", deltaCompaction.MaxDeltaShards, deltaCompaction.MaxDeltaBytes)
+
 	reposShouldSkipSymbolsCalculation := getEnvWithDefaultEmptySet("SKIP_SYMBOLS_REPOS_ALLOWLIST")
 	if len(reposShouldSkipSymbolsCalculation) > 0 {
 		debugLog.Printf("skipping generating symbols metadata for: %s", joinStringSet(reposShouldSkipSymbolsCalculation, ", "))
@@ -1810,6 +1831,7 @@ func newServer(conf rootConfig) (*Server, error) {
 		shardMerging:                      !conf.disableShardMerging,
 		deltaBuildRepositoriesAllowList:   deltaBuildRepositoriesAllowList,
 		deltaShardNumberFallbackThreshold: deltaShardNumberFallbackThreshold,
+		deltaCompaction:                   deltaCompaction,
 		repositoriesSkipSymbolsCalculationAllowList: reposShouldSkipSymbolsCalculation,
 		hostname: conf.hostname,
 		mergeOpts: mergeOpts{
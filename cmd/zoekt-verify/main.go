@@ -0,0 +1,115 @@
+// Command zoekt-verify fully validates index shards. It recomputes the
+// content checksums and checks the newline, rune offset and posting list
+// tables against the document contents.
+//
+// It exits with status 1 if a shard is corrupt. With -quarantine, corrupt
+// shards are moved into the .corrupt directory next to them.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/sourcegraph/zoekt/index"
+)
+
+// verify checks the shard at path and prints its problems. It returns
+// whether the shard is corrupt.
+func verify(path string, maxProblems int) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	iFile, err := index.NewIndexFile(f)
+	if err != nil {
+		return false, err
+	}
+	defer iFile.Close()
+
+	problems, err := index.VerifyShard(iFile)
+	if err != nil {
+		fmt.Printf("%s: CORRUPT: %v\n", path, err)
+		return true, nil
+	}
+	if len(problems) == 0 {
+		fmt.Printf("%s: OK\n", path)
+		return false, nil
+	}
+
+	fmt.Printf("%s: CORRUPT: %d problems\n", path, len(problems))
+	for i, p := range problems {
+		if maxProblems > 0 && i == maxProblems {
+			fmt.Printf("  ... %d more\n", len(problems)-i)
+			break
+		}
+		fmt.Printf("  %s\n", p)
+	}
+	return true, nil
+}
+
+// shardPaths expands directories in args to the shards they contain.
+func shardPaths(args []string) ([]string, error) {
+	var paths []string
+	for _, arg := range args {
+		fi, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !fi.IsDir() {
+			paths = append(paths, arg)
+			continue
+		}
+		shards, err := filepath.Glob(filepath.Join(arg, "*.zoekt"))
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, shards...)
+	}
+	return paths, nil
+}
+
+func main() {
+	quarantine := flag.Bool("quarantine", false, "move corrupt shards and their .meta files into the "+index.CorruptDir+" directory next to them")
+	maxProblems := flag.Int("max_problems", 20, "print at most this many problems per shard; 0 prints all")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage:\n\n  %s [option] SHARD_OR_DIR...\n\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	paths, err := shardPaths(flag.Args())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	corrupt := 0
+	for _, path := range paths {
+		bad, err := verify(path, *maxProblems)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if !bad {
+			continue
+		}
+		corrupt++
+		if *quarantine {
+			dst, err := index.QuarantineShard(path)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("%s: moved to %s\n", path, dst)
+		}
+	}
+
+	if corrupt > 0 {
+		fmt.Printf("%d of %d shards are corrupt\n", corrupt, len(paths))
+		os.Exit(1)
+	}
+}
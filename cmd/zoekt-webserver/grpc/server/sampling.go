@@ -20,15 +20,20 @@ type samplingSender struct {
 
 func (s *samplingSender) Send(event *zoekt.SearchResult) {
 	// We don't want to send events over the wire if they don't contain file
-	// matches. Hence, in case we didn't find any results, we aggregate the stats
-	// and send them out in regular intervals.
-	if len(event.Files) == 0 {
+	// or commit matches. Hence, in case we didn't find any results, we
+	// aggregate the stats and send them out in regular intervals.
+	if len(event.Files) == 0 && len(event.Commits) == 0 {
 		s.aggCount++
 
 		s.agg.Stats.Add(event.Stats)
+		s.agg.Facets.Add(event.Facets)
+		s.agg.Explain = append(s.agg.Explain, event.Explain...)
 		s.agg.Progress = event.Progress
+		if event.NextCursor != nil {
+			s.agg.NextCursor = event.NextCursor
+		}
 
-		if s.aggCount%100 == 0 && !s.agg.Stats.Zero() {
+		if s.aggCount%100 == 0 && (!s.agg.Stats.Zero() || len(s.agg.Explain) > 0) {
 			s.next.Send(&s.agg)
 			s.agg = zoekt.SearchResult{}
 		}
@@ -39,19 +44,29 @@ func (s *samplingSender) Send(event *zoekt.SearchResult) {
 	// If we have aggregate stats, we merge them with the new event before sending
 	// it. We drop agg.Progress, because we assume that event.Progress reflects the
 	// latest status.
-	if !s.agg.Stats.Zero() {
+	if !s.agg.Stats.Zero() || len(s.agg.Explain) > 0 {
 		event.Stats.Add(s.agg.Stats)
+		event.Facets.Add(s.agg.Facets)
+		event.Explain = append(event.Explain, s.agg.Explain...)
 		s.agg = zoekt.SearchResult{}
 	}
+	if s.agg.NextCursor != nil {
+		event.NextCursor = s.agg.NextCursor
+		s.agg.NextCursor = nil
+	}
 
 	s.next.Send(event)
 }
 
-// Flush sends any aggregated stats that we haven't sent yet
+// Flush sends any aggregated stats, explanations and the cursor for the next
+// page that we haven't sent yet
 func (s *samplingSender) Flush() {
-	if !s.agg.Stats.Zero() {
+	if !s.agg.Stats.Zero() || s.agg.NextCursor != nil || len(s.agg.Explain) > 0 {
 		s.next.Send(&zoekt.SearchResult{
-			Stats: s.agg.Stats,
+			Stats:      s.agg.Stats,
+			Facets:     s.agg.Facets,
+			NextCursor: s.agg.NextCursor,
+			Explain:    s.agg.Explain,
 			Progress: zoekt.Progress{
 				Priority:           math.Inf(-1),
 				MaxPendingPriority: math.Inf(-1),
@@ -75,7 +75,7 @@ func gRPCChunkSender(ss webserverv1.WebserverService_StreamSearchServer) zoekt.S
 	f := func(r *zoekt.SearchResult) {
 		result := r.ToStreamProto().GetResponseChunk()
 
-		if len(result.GetFiles()) == 0 { // stats-only result, send it immediately
+		if len(result.GetFiles()) == 0 { // stats-only or commits-only result, send it immediately
 			_ = ss.Send(&webserverv1.StreamSearchResponse{
 				ResponseChunk: result,
 			})
@@ -91,9 +91,17 @@ func gRPCChunkSender(ss webserverv1.WebserverService_StreamSearchServer) zoekt.S
 			numFilesSent += len(filesChunk)
 
 			var stats *webserverv1.Stats
-			if !statsSent { // We only send stats back on the first chunk
+			var facets map[string]*webserverv1.FacetCounts
+			var nextCursor *webserverv1.Cursor
+			var commits []*webserverv1.CommitMatch
+			var explain []*webserverv1.ShardExplain
+			if !statsSent { // We only send stats, facets, the cursor, commits and explanations back on the first chunk
 				statsSent = true
 				stats = result.GetStats()
+				facets = result.GetFacets()
+				nextCursor = result.GetNextCursor()
+				commits = result.GetCommits()
+				explain = result.GetExplain()
 			}
 
 			progress := result.GetProgress()
@@ -113,10 +121,14 @@ func gRPCChunkSender(ss webserverv1.WebserverService_StreamSearchServer) zoekt.S
 
 			return ss.Send(&webserverv1.StreamSearchResponse{
 				ResponseChunk: &webserverv1.SearchResponse{
-					Files: filesChunk,
-
-					Stats:    stats,
-					Progress: progress,
+					Files:   filesChunk,
+					Commits: commits,
+
+					Stats:      stats,
+					Progress:   progress,
+					Facets:     facets,
+					NextCursor: nextCursor,
+					Explain:    explain,
 				},
 			})
 		}
@@ -5,10 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/http/httptest"
 	"net/url"
+	"reflect"
 	"testing"
 	"testing/quick"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -148,7 +151,9 @@ func TestFuzzGRPCChunkSender(t *testing.T) {
 				protocmp.IgnoreFields(&webserverv1.SearchResponse{},
 					"progress", // progress is tested above
 					"stats",    // aggregated stats are tested below
+					"facets",   // aggregated facets are tested below
 					"files",    // files are tested separately
+					"commits",  // commits are tested separately
 				),
 			}
 
@@ -158,11 +163,15 @@ func TestFuzzGRPCChunkSender(t *testing.T) {
 		}
 
 		receivedStats := &zoekt.Stats{}
+		var receivedFacets zoekt.Facets
 
 		var receivedFileMatches []*webserverv1.FileMatch
+		var receivedCommitMatches []*webserverv1.CommitMatch
 		for _, r := range allResponses {
 			receivedStats.Add(zoekt.StatsFromProto(r.GetStats()))
+			receivedFacets.Add(zoekt.FacetsFromProto(r.GetFacets()))
 			receivedFileMatches = append(receivedFileMatches, r.GetFiles()...)
+			receivedCommitMatches = append(receivedCommitMatches, r.GetCommits()...)
 		}
 
 		// Check to make sure that we get one set of stats back
@@ -175,18 +184,30 @@ func TestFuzzGRPCChunkSender(t *testing.T) {
 			return fmt.Errorf("unexpected difference in stats (-want +got):\n%s", diff)
 		}
 
+		// Check to make sure that we get one set of facets back
+		if diff := cmp.Diff(expectedResult.GetFacets(), receivedFacets.ToProto(),
+			protocmp.Transform(), cmpopts.EquateEmpty()); diff != "" {
+			return fmt.Errorf("unexpected difference in facets (-want +got):\n%s", diff)
+		}
+
 		// Check to make sure that we get the same set of file matches back
 		if diff := cmp.Diff(expectedResult.GetFiles(), receivedFileMatches,
 			protocmp.Transform(), cmpopts.EquateEmpty()); diff != "" {
 			return fmt.Errorf("unexpected difference in file matches (-want +got):\n%s", diff)
 		}
 
+		// Check to make sure that we get the same set of commit matches back
+		if diff := cmp.Diff(expectedResult.GetCommits(), receivedCommitMatches,
+			protocmp.Transform(), cmpopts.EquateEmpty()); diff != "" {
+			return fmt.Errorf("unexpected difference in commit matches (-want +got):\n%s", diff)
+		}
+
 		return nil
 	}
 
 	var lastErr error
-	if err := quick.Check(func(r zoekt.SearchResult) bool {
-		lastErr = validateResult(r)
+	if err := quick.Check(func(r fuzzSearchResult) bool {
+		lastErr = validateResult(zoekt.SearchResult(r))
 
 		return lastErr == nil
 	}, nil); err != nil {
@@ -194,6 +215,74 @@ func TestFuzzGRPCChunkSender(t *testing.T) {
 	}
 }
 
+// fuzzSearchResult is a zoekt.SearchResult which quick.Check can generate.
+// quick can't generate the time.Time in zoekt.FileMatch and
+// zoekt.CommitMatch, so we generate a copy of the struct types without it
+// instead.
+type fuzzSearchResult zoekt.SearchResult
+
+func (fuzzSearchResult) Generate(rng *rand.Rand, _ int) reflect.Value {
+	fileType := structWithout(reflect.TypeOf(zoekt.FileMatch{}), "CommitDate", nil)
+	commitType := structWithout(reflect.TypeOf(zoekt.CommitMatch{}), "Date", nil)
+	resultType := structWithout(reflect.TypeOf(zoekt.SearchResult{}), "Files", reflect.SliceOf(fileType))
+	resultType = structWithout(resultType, "Commits", reflect.SliceOf(commitType))
+
+	gen, ok := quick.Value(resultType, rng)
+	if !ok {
+		panic("can't generate zoekt.SearchResult")
+	}
+
+	var r zoekt.SearchResult
+	copyFields(reflect.ValueOf(&r).Elem(), gen)
+	genFiles := gen.FieldByName("Files")
+	r.Files = make([]zoekt.FileMatch, genFiles.Len())
+	for i := range r.Files {
+		copyFields(reflect.ValueOf(&r.Files[i]).Elem(), genFiles.Index(i))
+		if rng.Intn(2) == 0 {
+			r.Files[i].CommitDate = time.Unix(rng.Int63n(1<<32), 0).UTC()
+		}
+	}
+	genCommits := gen.FieldByName("Commits")
+	r.Commits = make([]zoekt.CommitMatch, genCommits.Len())
+	for i := range r.Commits {
+		copyFields(reflect.ValueOf(&r.Commits[i]).Elem(), genCommits.Index(i))
+		if rng.Intn(2) == 0 {
+			r.Commits[i].Date = time.Unix(rng.Int63n(1<<32), 0).UTC()
+		}
+	}
+
+	return reflect.ValueOf(fuzzSearchResult(r))
+}
+
+// structWithout returns a struct type with the fields of t, except that the
+// field name has type replacement. If replacement is nil, the field is
+// dropped.
+func structWithout(t reflect.Type, name string, replacement reflect.Type) reflect.Type {
+	var fields []reflect.StructField
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if f.Name == name {
+			if replacement == nil {
+				continue
+			}
+			f.Type = replacement
+		}
+		fields = append(fields, f)
+	}
+	return reflect.StructOf(fields)
+}
+
+// copyFields copies the fields of src to the fields of dst with the same name
+// and type.
+func copyFields(dst, src reflect.Value) {
+	for i := range src.NumField() {
+		f := dst.FieldByName(src.Type().Field(i).Name)
+		if f.Type() == src.Field(i).Type() {
+			f.Set(src.Field(i))
+		}
+	}
+}
+
 // newPairedSearchStream returns a pair of client and server search streams that are connected to each other.
 func newPairedSearchStream(t *testing.T) (webserverv1.WebserverService_StreamSearchClient, webserverv1.WebserverService_StreamSearchServer) {
 	client := &mockSearchStreamClient{t: t}
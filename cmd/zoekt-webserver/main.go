@@ -54,6 +54,7 @@ import (
 	"github.com/sourcegraph/zoekt/grpc/grpcutil"
 	webserverv1 "github.com/sourcegraph/zoekt/grpc/protos/zoekt/webserver/v1"
 	"github.com/sourcegraph/zoekt/index"
+	"github.com/sourcegraph/zoekt/internal/auth"
 	"github.com/sourcegraph/zoekt/internal/debugserver"
 	"github.com/sourcegraph/zoekt/internal/profiler"
 	"github.com/sourcegraph/zoekt/internal/trace"
@@ -62,6 +63,8 @@ import (
 	"github.com/sourcegraph/zoekt/search"
 	"github.com/sourcegraph/zoekt/web"
 	"github.com/sourcegraph/zoekt/contrib"
+	"github.com/sourcegraph/zoekt/contrib/keyval"
+	"github.com/sourcegraph/zoekt/contrib/savedsearch"
 	"github.com/sourcegraph/zoekt/contrib/analysis"
 )
 
@@ -134,12 +137,15 @@ func main() {
 
 	listen := flag.String("listen", ":6070", "listen on this address.")
 	indexDir := flag.String("index", index.DefaultDir, "set index directory to use")
+	verifyShards := flag.Bool("verify_shards", false, "fully validate shards before loading them, and move corrupt shards to the "+index.CorruptDir+" directory of the index")
 	html := flag.Bool("html", true, "enable HTML interface")
 	enableRPC := flag.Bool("rpc", false, "enable go/net RPC")
 	enableIndexserverProxy := flag.Bool("indexserver_proxy", false, "proxy requests with URLs matching the path /indexserver/ to <index>/indexserver.sock")
 	print := flag.Bool("print", false, "enable local result URLs")
 	fsbase := flag.String("fs_base_dir", "", "enable api to fetch file/directory contents (filepath)")
 	basicauth := flag.String("basic_auth", "", "enable basic auth in api invocation (filepath)")
+	usersFile := flag.String("users", "", "authenticate all requests against this users file of bcrypt hashes, repository patterns and groups, superseding -basic_auth (filepath)")
+	webhookHosts := flag.String("saved_search_webhook_hosts", "", "comma separated hosts saved search webhooks may post to, eg. hooks.example.com,*.internal. By default, webhooks may post to public addresses only.")
 	enablePprof := flag.Bool("pprof", false, "set to enable remote profiling.")
 	sslCert := flag.String("ssl_cert", "", "set path to SSL .pem holding certificate.")
 	sslKey := flag.String("ssl_key", "", "set path to SSL .pem holding key.")
@@ -173,7 +179,7 @@ func main() {
 		fmt.Println("ZOETK_P4_BIN:", analysis.P4_BIN)
 	}
 	if analysis.GIT_BIN == "" {
-		fmt.Println("[!] No git support (ZOETK_GIT_BIN)")
+		fmt.Println("[!] No git binary (ZOETK_GIT_BIN); using go-git")
 	} else {
 		fmt.Println("ZOETK_GIT_BIN:", analysis.GIT_BIN)
 	}
@@ -229,10 +235,41 @@ func main() {
 
 	prometheus.DefaultRegisterer.MustRegister(c)
 
+	var users *auth.Users
+	if *usersFile != "" {
+		var err error
+		if users, err = auth.LoadUsers(*usersFile); err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	// Do not block on loading shards so we can become partially available
 	// sooner. Otherwise on large instances zoekt can be unavailable on the
 	// order of minutes.
-	searcher, err := search.NewDirectorySearcherFast(*indexDir)
+	// Saved searches are kept in the keyval storage and run again whenever
+	// new shards are loaded.
+	var savedSearches *savedsearch.Manager
+	var searcherOpts []search.DirectorySearcherOption
+	if keyval.IsKeyvalFSEnabled() {
+		storage, err := keyval.DefaultStorage()
+		if err != nil {
+			log.Fatalf("keyval storage: %v", err)
+		}
+		var savedOpts []savedsearch.Option
+		if *webhookHosts != "" {
+			savedOpts = append(savedOpts, savedsearch.WithWebhookHosts(strings.Split(*webhookHosts, ",")...))
+		}
+		if users != nil {
+			savedOpts = append(savedOpts, savedsearch.WithOwners(users))
+		}
+		savedSearches = savedsearch.NewManager(storage, savedOpts...)
+		searcherOpts = append(searcherOpts, search.WithLoadHook(savedSearches.ShardsLoaded))
+	}
+	if *verifyShards {
+		searcherOpts = append(searcherOpts, search.WithShardVerification())
+	}
+
+	searcher, err := search.NewDirectorySearcherFast(*indexDir, searcherOpts...)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -243,9 +280,19 @@ func main() {
 	}
 
 	s := &web.Server{
-		Searcher: searcher,
-		Top:      web.Top,
-		Version:  index.Version,
+		Searcher:      searcher,
+		Top:           web.Top,
+		Version:       index.Version,
+		SavedSearches: savedSearches,
+	}
+	if users != nil {
+		s.Searcher = auth.NewSearcher(searcher)
+		s.Users = users
+	}
+	if savedSearches != nil {
+		// Saved searches run as their owners with the same searcher as
+		// the user.
+		go savedSearches.Run(context.Background(), s.Searcher)
 	}
 
 	if *templateDir != "" {
@@ -320,7 +367,14 @@ func main() {
 	logger := sglog.Scoped("ZoektWebserverGRPCServer")
 
 	streamer := web.NewTraceAwareSearcher(s.Searcher)
-	grpcServer := newGRPCServer(logger, streamer)
+	var grpcOpts []grpc.ServerOption
+	if users != nil {
+		grpcOpts = append(grpcOpts,
+			grpc.ChainUnaryInterceptor(users.UnaryServerInterceptor),
+			grpc.ChainStreamInterceptor(users.StreamServerInterceptor),
+		)
+	}
+	grpcServer := newGRPCServer(logger, streamer, grpcOpts...)
 
 	handler = grpcutil.MultiplexGRPC(grpcServer, handler)
 
@@ -356,6 +410,16 @@ func main() {
 			log.Fatalf("http.Server.Shutdown: %v", err)
 		}
 	}
+
+	if keyval.IsKeyvalFSEnabled() {
+		// Indexes the last writes, so the keyval index needn't be rebuilt
+		// on the next start.
+		if storage, err := keyval.DefaultStorage(); err == nil {
+			if err := storage.Close(); err != nil {
+				log.Printf("closing keyval storage: %v", err)
+			}
+		}
+	}
 }
 
 // addProxyHandler adds a handler to "mux" that proxies all requests with base
@@ -32,6 +32,7 @@ import (
 
 	"github.com/sourcegraph/zoekt"
 	"github.com/sourcegraph/zoekt/index"
+	"github.com/sourcegraph/zoekt/internal/auth"
 	"github.com/sourcegraph/zoekt/query"
 	"github.com/sourcegraph/zoekt/search"
 )
@@ -215,7 +216,9 @@ func main() {
 	sOpts := zoekt.SearchOptions{
 		DebugScore: *debug,
 	}
-	sres, err := searcher.Search(context.Background(), q, &sOpts)
+	// Whoever can read the shards may see all of their documents.
+	ctx := auth.WithUnrestrictedContext(context.Background())
+	sres, err := searcher.Search(ctx, q, &sOpts)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -223,10 +226,10 @@ func main() {
 	// If profiling, do it another time so we measure with
 	// warm caches.
 	for run := startCPUProfile(*cpuProfile, *profileTime); run(); {
-		sres, _ = searcher.Search(context.Background(), q, &sOpts)
+		sres, _ = searcher.Search(ctx, q, &sOpts)
 	}
 	for run := startFullProfile(*fullProfile, *profileTime); run(); {
-		sres, _ = searcher.Search(context.Background(), q, &sOpts)
+		sres, _ = searcher.Search(ctx, q, &sOpts)
 	}
 
 	displayMatches(sres.Files, pat, *withRepo, *list)
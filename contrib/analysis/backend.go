@@ -0,0 +1,129 @@
+package analysis
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Backend opens projects of one source control system.
+//
+// Backends register themselves by name with RegisterBackend. NewProject asks
+// each backend in registration order whether it recognizes a project
+// directory, and opens the project with the first one that does.
+type Backend interface {
+	// Detect reports whether baseDir is a project of this backend.
+	Detect(baseDir string) bool
+	// Open returns the project in baseDir; baseDir is an absolute path.
+	Open(projectName, baseDir string) (IProject, error)
+}
+
+var backends = struct {
+	sync.Mutex
+	names  []string
+	byName map[string]Backend
+}{byName: make(map[string]Backend)}
+
+// RegisterBackend makes a backend available by name. It panics if the name
+// is registered twice or the backend is nil.
+func RegisterBackend(name string, b Backend) {
+	backends.Lock()
+	defer backends.Unlock()
+	if b == nil {
+		log.Panicf("[E] ! backend %q is nil", name)
+	}
+	if _, ok := backends.byName[name]; ok {
+		log.Panicf("[E] ! backend %q registered twice", name)
+	}
+	backends.names = append(backends.names, name)
+	backends.byName[name] = b
+}
+
+// GetBackend returns the backend registered under name, or nil.
+func GetBackend(name string) Backend {
+	backends.Lock()
+	defer backends.Unlock()
+	return backends.byName[name]
+}
+
+// Backends returns the names of the registered backends in the order they
+// are tried by NewProject.
+func Backends() []string {
+	backends.Lock()
+	defer backends.Unlock()
+	return append([]string(nil), backends.names...)
+}
+
+// NewProject opens the project in baseDir with the first registered backend
+// which detects it. It returns nil if no backend supports the directory.
+func NewProject(projectName string, baseDir string) IProject {
+	baseDir, err := filepath.Abs(baseDir)
+	if err != nil {
+		return nil
+	}
+	if !isDir(baseDir) {
+		return nil
+	}
+	for _, name := range Backends() {
+		b := GetBackend(name)
+		if !b.Detect(baseDir) {
+			continue
+		}
+		p, err := b.Open(projectName, baseDir)
+		if err != nil {
+			log.Printf("P/%s: [E] cannot open %s project: %v\n", projectName, name, err)
+			return nil
+		}
+		return p
+	}
+	// not support yet
+	return nil
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func init() {
+	// a project with both .git and .p4 has always been treated as git
+	RegisterBackend("git", gitBackend{})
+	RegisterBackend("gogit", goGitBackend{})
+	RegisterBackend("p4", p4Backend{})
+}
+
+// gitBackend runs the git binary in ZOEKT_GIT_BIN.
+type gitBackend struct{}
+
+func (gitBackend) Detect(baseDir string) bool {
+	return GIT_BIN != "" && isDir(filepath.Join(baseDir, ".git"))
+}
+
+func (gitBackend) Open(projectName, baseDir string) (IProject, error) {
+	options := make(map[string]string)
+	getGitProjectOptions(baseDir, &options)
+	p := NewGitProject(projectName, baseDir, options)
+	if p == nil {
+		return nil, fmt.Errorf("invalid git project")
+	}
+	return p, nil
+}
+
+// p4Backend runs the p4 binary in ZOEKT_P4_BIN.
+type p4Backend struct{}
+
+func (p4Backend) Detect(baseDir string) bool {
+	return P4_BIN != "" && isDir(filepath.Join(baseDir, ".p4"))
+}
+
+func (p4Backend) Open(projectName, baseDir string) (IProject, error) {
+	options := make(map[string]string)
+	getP4ProjectOptions(baseDir, &options)
+	p := NewP4Project(projectName, baseDir, options)
+	if p == nil {
+		return nil, fmt.Errorf("invalid p4 project")
+	}
+	return p, nil
+}
@@ -0,0 +1,196 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/zoekt"
+)
+
+// StubBackend serves projects from memory. It is meant for tests, which
+// register it with RegisterBackend under a name of their choice.
+type StubBackend struct {
+	// Projects maps project directory names to projects.
+	Projects map[string]*StubProject
+}
+
+func (b *StubBackend) Detect(baseDir string) bool {
+	_, ok := b.Projects[filepath.Base(baseDir)]
+	return ok
+}
+
+// Open returns a copy of the stub project of baseDir, so that concurrent
+// requests don't share it. The copy shares the stub data, which must not be
+// modified once the backend is in use.
+func (b *StubBackend) Open(projectName, baseDir string) (IProject, error) {
+	p, ok := b.Projects[filepath.Base(baseDir)]
+	if !ok {
+		return nil, fmt.Errorf("no stub project in %s", baseDir)
+	}
+	cp := *p
+	cp.Name = projectName
+	cp.BaseDir = baseDir
+	return &cp, nil
+}
+
+var _ IProject = &StubProject{}
+
+// StubProject is an in-memory project with a single revision. Operations
+// without stub data return an error.
+type StubProject struct {
+	Name    string
+	BaseDir string
+	// Files maps paths like /README.md to file contents.
+	Files map[string][]byte
+	// Blame maps paths to the blame of each line.
+	Blame map[string][]*BlameDetails
+	// Commits maps commit ids to commit details.
+	Commits map[string]*CommitDetails
+	// History lists commit ids, newest first.
+	History []string
+}
+
+func (p *StubProject) GetName() string {
+	return p.Name
+}
+
+func (p *StubProject) GetBaseDir() string {
+	return p.BaseDir
+}
+
+func (p *StubProject) GetMetadataDir() string {
+	return filepath.Join(p.BaseDir, ".stub")
+}
+
+func (p *StubProject) Sync() (map[string]string, error) {
+	return make(map[string]string), nil
+}
+
+func (p *StubProject) Compile() error {
+	return nil
+}
+
+func (p *StubProject) GetProjectType() string {
+	return "stub"
+}
+
+func (p *StubProject) GetFileTextContents(path, revision string) (string, error) {
+	B, err := p.GetFileBinaryContents(path, revision)
+	if err != nil {
+		return "", err
+	}
+	return string(B), nil
+}
+
+func (p *StubProject) GetFileBinaryContents(path, revision string) ([]byte, error) {
+	B, ok := p.Files[path]
+	if !ok {
+		return nil, fmt.Errorf("%s: not found", path)
+	}
+	return B, nil
+}
+
+func (p *StubProject) GetFileLength(path, revision string) (int64, error) {
+	B, err := p.GetFileBinaryContents(path, revision)
+	if err != nil {
+		return -1, err
+	}
+	return int64(len(B)), nil
+}
+
+func (p *StubProject) GetFileHash(path, revision string) (string, error) {
+	if _, ok := p.Files[path]; !ok {
+		return "", fmt.Errorf("%s: not found", path)
+	}
+	return "stub:" + path, nil
+}
+
+func (p *StubProject) GetFileBlameInfo(path, revision string, startLine, endLine int) ([]*BlameDetails, error) {
+	blames, ok := p.Blame[path]
+	if !ok {
+		return nil, fmt.Errorf("%s: no blame", path)
+	}
+	if startLine <= 0 {
+		startLine = 1
+	}
+	if endLine <= 0 || endLine > len(blames) {
+		endLine = len(blames)
+	}
+	if startLine > endLine {
+		return []*BlameDetails{}, nil
+	}
+	return blames[startLine-1 : endLine], nil
+}
+
+func (p *StubProject) GetFileCommitInfo(path string, offset, N int) ([]string, error) {
+	commits := make([]string, 0)
+	for _, id := range p.History {
+		if N == 0 {
+			break
+		}
+		c, ok := p.Commits[id]
+		if !ok {
+			continue
+		}
+		touched := false
+		for _, f := range c.CommitFiles {
+			touched = touched || "/"+strings.TrimPrefix(f.Path, "/") == path
+		}
+		if !touched {
+			continue
+		}
+		if offset > 0 {
+			offset--
+			continue
+		}
+		commits = append(commits, id)
+		N--
+	}
+	return commits, nil
+}
+
+func (p *StubProject) GetDirContents(path, revision string) ([]string, error) {
+	if !strings.HasSuffix(path, "/") {
+		path += "/"
+	}
+	seen := make(map[string]bool)
+	for name := range p.Files {
+		rest, ok := strings.CutPrefix(name, path)
+		if !ok {
+			continue
+		}
+		if dir, _, ok := strings.Cut(rest, "/"); ok {
+			rest = dir + "/"
+		}
+		seen[rest] = true
+	}
+	list := make([]string, 0, len(seen))
+	for name := range seen {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list, nil
+}
+
+func (p *StubProject) GetCommitDetails(commitId string) (*CommitDetails, error) {
+	c, ok := p.Commits[commitId]
+	if !ok {
+		return nil, fmt.Errorf("%s: unknown commit", commitId)
+	}
+	return c, nil
+}
+
+func (p *StubProject) SearchCommits(ctx context.Context, query string, num int) (*zoekt.SearchResult, error) {
+	return nil, fmt.Errorf("not supported")
+}
+
+func (p *StubProject) GetOccurrenceReport(name string) (*OccurrenceReport, error) {
+	return getOccurrenceReport(p, name)
+}
+
+func (p *StubProject) GenOccurrenceReport(ctx context.Context, name string, items []string, opts OccurrenceReportOptions) error {
+	return genOccurrenceReport(ctx, p, name, items, opts)
+}
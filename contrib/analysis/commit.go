@@ -10,6 +10,8 @@ import (
 
 	"github.com/sourcegraph/zoekt"
 	"github.com/sourcegraph/zoekt/contrib"
+	"github.com/sourcegraph/zoekt/index"
+	"github.com/sourcegraph/zoekt/internal/gitindex"
 )
 
 type CommitFileInfo struct {
@@ -279,15 +281,41 @@ func (p *GitProject) GetCommitDetails (commitId string) (*CommitDetails, error)
 
 // ref: contrib/analysis/metadata.go
 // define folder structure; /path/to/repo/.<type>/.zoekt/index
-// TODO: add "r:commit"
 
 func (p *P4Project) SearchCommits(ctx context.Context, query string, num int) (*zoekt.SearchResult, error) {
 	path := filepath.Join(p.BaseDir, ".p4", ".zoekt", "index")
 	return contrib.Search(path, ctx, query, num)
 }
 
+// commit index is built by Compile; results come back in SearchResult.Commits
 func (p *GitProject) SearchCommits(ctx context.Context, query string, num int) (*zoekt.SearchResult, error) {
-	path := filepath.Join(p.BaseDir, ".git", ".zoekt", "index")
-	return contrib.Search(path, ctx, query, num)
+	return contrib.Search(p.getCommitIndexDir(), ctx, "type:commit " + query, num)
+}
+
+func (p *GitProject) getCommitIndexDir() string {
+	return filepath.Join(p.BaseDir, ".git", ".zoekt", "index")
+}
+
+// index commit message, author, date and changed paths of the project branch
+func (p *GitProject) indexCommits() error {
+	branch := p.Branch
+	if branch == "" {
+		branch = "HEAD"
+	}
+	indexDir := p.getCommitIndexDir()
+	if err := contrib.PrepareDirectory(indexDir); err != nil {
+		return err
+	}
+	opts := gitindex.Options{
+		RepoDir:     filepath.Join(p.BaseDir, ".git"),
+		Incremental: true,
+		Branches:    []string{branch},
+		BuildOptions: index.Options{
+			IndexDir: indexDir,
+			RepositoryDescription: zoekt.Repository{Name: p.Name},
+		},
+	}
+	_, err := gitindex.IndexGitCommits(opts)
+	return err
 }
 
@@ -0,0 +1,368 @@
+package analysis
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+
+	"github.com/sourcegraph/zoekt/contrib"
+)
+
+// goGitBackend reads git repositories with go-git, so no git binary is
+// needed.
+type goGitBackend struct{}
+
+func (goGitBackend) Detect(baseDir string) bool {
+	return isDir(filepath.Join(baseDir, ".git"))
+}
+
+func (goGitBackend) Open(projectName, baseDir string) (IProject, error) {
+	return NewGoGitProject(projectName, baseDir)
+}
+
+var _ IProject = &GoGitProject{}
+
+// GoGitProject is a git project read with go-git instead of the git binary.
+// Commit search shares the commit index of GitProject.
+type GoGitProject struct {
+	*GitProject
+	repo *git.Repository
+}
+
+// NewGoGitProject opens the git repository in baseDir. Url and Branch are
+// taken from the origin remote and the checked out branch.
+func NewGoGitProject(projectName string, baseDir string) (*GoGitProject, error) {
+	repo, err := git.PlainOpen(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	p := &GoGitProject{
+		GitProject: &GitProject{Name: projectName, BaseDir: baseDir},
+		repo:       repo,
+	}
+	if remote, err := repo.Remote(git.DefaultRemoteName); err == nil && len(remote.Config().URLs) > 0 {
+		p.Url = remote.Config().URLs[0]
+	}
+	if head, err := repo.Head(); err == nil && head.Name().IsBranch() {
+		p.Branch = head.Name().Short()
+	}
+	return p, nil
+}
+
+// gitPath converts a project path like /README.md to a git tree path.
+func gitPath(path string) string {
+	return strings.Trim(filepath.ToSlash(path), "/")
+}
+
+// commit resolves revision, or the checked out commit if revision is empty.
+func (p *GoGitProject) commit(revision string) (*object.Commit, error) {
+	if revision == "" {
+		revision = "HEAD"
+	}
+	hash, err := p.repo.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", revision, err)
+	}
+	return p.repo.CommitObject(*hash)
+}
+
+func (p *GoGitProject) file(path, revision string) (*object.File, error) {
+	c, err := p.commit(revision)
+	if err != nil {
+		return nil, err
+	}
+	return c.File(gitPath(path))
+}
+
+func (p *GoGitProject) Sync() (map[string]string, error) {
+	updatedList := make(map[string]string)
+	fileinfo, err := os.Stat(p.BaseDir)
+	if os.IsNotExist(err) {
+		err = p.clone(&updatedList)
+		return updatedList, err
+	}
+	if err != nil {
+		return updatedList, err
+	}
+	if !fileinfo.IsDir() {
+		return updatedList, fmt.Errorf("P/%s: [E] cannot clone repo since \"%s\" is not a directory", p.Name, p.BaseDir)
+	}
+	err = p.sync(&updatedList)
+	return updatedList, err
+}
+
+func (p *GoGitProject) clone(updatedList *map[string]string) error {
+	opts := &git.CloneOptions{URL: p.Url}
+	if p.Branch != "" {
+		opts.ReferenceName = plumbing.NewBranchReferenceName(p.Branch)
+		opts.SingleBranch = true
+	}
+	repo, err := git.PlainClone(p.BaseDir, false, opts)
+	if err != nil {
+		return err
+	}
+	p.repo = repo
+	if head, err := repo.Head(); err == nil && head.Name().IsBranch() {
+		p.Branch = head.Name().Short()
+	}
+	doWalk(p.BaseDir, ".git", updatedList)
+	return nil
+}
+
+func (p *GoGitProject) sync(updatedList *map[string]string) error {
+	err := p.repo.Fetch(&git.FetchOptions{RemoteName: git.DefaultRemoteName})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return err
+	}
+	if p.Branch == "" {
+		return fmt.Errorf("P/%s: [E] no branch checked out", p.Name)
+	}
+
+	head, err := p.commit("HEAD")
+	if err != nil {
+		return err
+	}
+	remote, err := p.commit("origin/" + p.Branch)
+	if err != nil {
+		return err
+	}
+	headTree, err := head.Tree()
+	if err != nil {
+		return err
+	}
+	remoteTree, err := remote.Tree()
+	if err != nil {
+		return err
+	}
+	changes, err := object.DiffTree(headTree, remoteTree)
+	if err != nil {
+		return err
+	}
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return err
+		}
+		switch action {
+		case merkletrie.Insert:
+			(*updatedList)["/"+change.To.Name] = "added"
+		case merkletrie.Delete:
+			(*updatedList)["/"+change.From.Name] = "deleted"
+		case merkletrie.Modify:
+			(*updatedList)["/"+change.To.Name] = "modified"
+		}
+	}
+
+	wt, err := p.repo.Worktree()
+	if err != nil {
+		return err
+	}
+	return wt.Reset(&git.ResetOptions{Commit: remote.Hash, Mode: git.HardReset})
+}
+
+func (p *GoGitProject) GetProjectType() string {
+	return "git"
+}
+
+func (p *GoGitProject) GetFileTextContents(path, revision string) (string, error) {
+	B, err := p.GetFileBinaryContents(path, revision)
+	if err != nil {
+		return "", err
+	}
+	T := string(B)
+	if strings.Contains(T, "\x00") {
+		return "", fmt.Errorf("binary")
+	}
+	return T, nil
+}
+
+func (p *GoGitProject) GetFileBinaryContents(path, revision string) ([]byte, error) {
+	f, err := p.file(path, revision)
+	if err != nil {
+		return nil, err
+	}
+	if f.Size > 1024*1024*10 {
+		// max reading size 10 MB
+		return nil, fmt.Errorf("larger than 10 MB")
+	}
+	r, err := f.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func (p *GoGitProject) GetFileHash(path, revision string) (string, error) {
+	if revision == "" {
+		return contrib.FileHash(filepath.Join(p.BaseDir, path))
+	}
+	f, err := p.file(path, revision)
+	if err != nil {
+		return "", err
+	}
+	r, err := f.Reader()
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+	return contrib.IoHash(r)
+}
+
+func (p *GoGitProject) GetFileLength(path, revision string) (int64, error) {
+	if revision == "" {
+		return contrib.FileLen(filepath.Join(p.BaseDir, path))
+	}
+	f, err := p.file(path, revision)
+	if err != nil {
+		return -1, err
+	}
+	return f.Size, nil
+}
+
+func (p *GoGitProject) GetFileBlameInfo(path, revision string, startLine, endLine int) ([]*BlameDetails, error) {
+	c, err := p.commit(revision)
+	if err != nil {
+		return nil, err
+	}
+	result, err := git.Blame(c, gitPath(path))
+	if err != nil {
+		return nil, err
+	}
+	if startLine <= 0 {
+		startLine = 1
+	}
+	if endLine <= 0 || endLine > len(result.Lines) {
+		endLine = len(result.Lines)
+	}
+	blames := make([]*BlameDetails, 0)
+	lastCommit := ""
+	for i := startLine - 1; i < endLine; i++ {
+		line := result.Lines[i]
+		details := &BlameDetails{line.Author, line.Hash.String(), line.Date.Unix()}
+		if details.Commit == lastCommit {
+			// to shorten blame emails for lines
+			details = &BlameDetails{"^", "^", 0}
+		} else {
+			lastCommit = details.Commit
+		}
+		blames = append(blames, details)
+	}
+	return blames, nil
+}
+
+func (p *GoGitProject) GetFileCommitInfo(path string, offset, N int) ([]string, error) {
+	head, err := p.commit("HEAD")
+	if err != nil {
+		return nil, err
+	}
+	name := gitPath(path)
+	iter, err := p.repo.Log(&git.LogOptions{From: head.Hash, FileName: &name})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	commits := make([]string, 0)
+	err = iter.ForEach(func(c *object.Commit) error {
+		if offset > 0 {
+			offset--
+			return nil
+		}
+		if N == 0 {
+			// if N = -1, dump all commit hashes
+			return storer.ErrStop
+		}
+		commits = append(commits, c.Hash.String())
+		N--
+		return nil
+	})
+	return commits, err
+}
+
+func (p *GoGitProject) GetDirContents(path, revision string) ([]string, error) {
+	if revision == "" {
+		revision = p.Branch
+	}
+	c, err := p.commit(revision)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := c.Tree()
+	if err != nil {
+		return nil, err
+	}
+	if dir := gitPath(path); dir != "" {
+		if tree, err = tree.Tree(dir); err != nil {
+			return nil, err
+		}
+	}
+	list := make([]string, 0, len(tree.Entries))
+	for _, entry := range tree.Entries {
+		if entry.Mode == filemode.Dir {
+			list = append(list, entry.Name+"/")
+		} else {
+			list = append(list, entry.Name)
+		}
+	}
+	return list, nil
+}
+
+func (p *GoGitProject) GetCommitDetails(commitId string) (*CommitDetails, error) {
+	c, err := p.commit(commitId)
+	if err != nil {
+		return nil, err
+	}
+	title, desc, _ := strings.Cut(strings.TrimSpace(c.Message), "\n")
+	details := &CommitDetails{
+		Id:          c.Hash.String(),
+		Timestamp:   c.Author.When.Unix(),
+		Author:      c.Author.Email,
+		Title:       title,
+		Description: strings.TrimLeft(desc, "\n"),
+		CommitFiles: make([]*CommitFileInfo, 0),
+	}
+
+	tree, err := c.Tree()
+	if err != nil {
+		return nil, err
+	}
+	var parentTree *object.Tree
+	if c.NumParents() > 0 {
+		parent, err := c.Parent(0)
+		if err != nil {
+			return nil, err
+		}
+		if parentTree, err = parent.Tree(); err != nil {
+			return nil, err
+		}
+	}
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return nil, err
+	}
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case merkletrie.Insert:
+			details.CommitFiles = append(details.CommitFiles, &CommitFileInfo{change.To.Name, "A", ""})
+		case merkletrie.Delete:
+			details.CommitFiles = append(details.CommitFiles, &CommitFileInfo{change.From.Name, "D", ""})
+		case merkletrie.Modify:
+			details.CommitFiles = append(details.CommitFiles, &CommitFileInfo{change.To.Name, "M", ""})
+		}
+	}
+	return details, nil
+}
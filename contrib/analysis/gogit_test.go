@@ -0,0 +1,165 @@
+package analysis
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/google/go-cmp/cmp"
+)
+
+// commitFiles writes files into the worktree of repo, deleting those with
+// empty contents, and commits them.
+func commitFiles(t *testing.T, repo *git.Repository, author, msg string, when time.Time, files map[string]string) string {
+	t.Helper()
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		path := filepath.Join(wt.Filesystem.Root(), name)
+		if content == "" {
+			if _, err := wt.Remove(name); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := wt.Add(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hash, err := wt.Commit(msg, &git.CommitOptions{
+		Author: &object.Signature{Name: author, Email: author + "@example.com", When: when},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hash.String()
+}
+
+func TestGoGitProject(t *testing.T) {
+	originDir := t.TempDir()
+	origin, err := git.PlainInit(originDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	first := commitFiles(t, origin, "alice", "add readme\n\nfirst commit", t0, map[string]string{
+		"README.md":   "hello\nworld\n",
+		"src/main.go": "package main\n",
+	})
+
+	baseDir := filepath.Join(t.TempDir(), "clone")
+	p := &GoGitProject{GitProject: &GitProject{Name: "test", BaseDir: baseDir, Url: originDir}}
+	updated, err := p.Sync()
+	if err != nil {
+		t.Fatalf("clone: %v", err)
+	}
+	if p.Branch != "master" {
+		t.Errorf("got branch %q after clone, want master", p.Branch)
+	}
+	if len(updated) != 2 {
+		t.Errorf("clone updated %v, want 2 files", updated)
+	}
+
+	// The backend detects the clone.
+	if !(goGitBackend{}).Detect(baseDir) {
+		t.Fatalf("%s not detected as a git project", baseDir)
+	}
+	if _, err := NewGoGitProject("test", baseDir); err != nil {
+		t.Fatal(err)
+	}
+
+	second := commitFiles(t, origin, "bob", "update readme", t0.Add(time.Hour), map[string]string{
+		"README.md":   "hello\nthere\n",
+		"src/main.go": "",
+		"doc/a.md":    "a\n",
+	})
+	updated, err = p.Sync()
+	if err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	wantUpdated := map[string]string{
+		"/README.md":   "modified",
+		"/src/main.go": "deleted",
+		"/doc/a.md":    "added",
+	}
+	if d := cmp.Diff(wantUpdated, updated); d != "" {
+		t.Errorf("sync updated (-want +got):\n%s", d)
+	}
+
+	if got, err := p.GetFileTextContents("/README.md", ""); err != nil || got != "hello\nthere\n" {
+		t.Errorf("README.md at HEAD: %q, %v", got, err)
+	}
+	if got, err := p.GetFileTextContents("/README.md", first); err != nil || got != "hello\nworld\n" {
+		t.Errorf("README.md at %s: %q, %v", first, got, err)
+	}
+	if n, err := p.GetFileLength("/src/main.go", first); err != nil || n != int64(len("package main\n")) {
+		t.Errorf("length of src/main.go at %s: %d, %v", first, n, err)
+	}
+	if _, err := p.GetFileBinaryContents("/src/main.go", ""); err == nil {
+		t.Error("got deleted src/main.go at HEAD")
+	}
+
+	blame, err := p.GetFileBlameInfo("/README.md", "", 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantBlame := []*BlameDetails{
+		{Author: "alice@example.com", Commit: first, Timestamp: t0.Unix()},
+		{Author: "bob@example.com", Commit: second, Timestamp: t0.Add(time.Hour).Unix()},
+	}
+	if d := cmp.Diff(wantBlame, blame); d != "" {
+		t.Errorf("blame (-want +got):\n%s", d)
+	}
+
+	commits, err := p.GetFileCommitInfo("/README.md", 0, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff([]string{second, first}, commits); d != "" {
+		t.Errorf("log of README.md (-want +got):\n%s", d)
+	}
+	if commits, err = p.GetFileCommitInfo("/README.md", 1, 1); err != nil || len(commits) != 1 || commits[0] != first {
+		t.Errorf("log of README.md with offset 1: %v, %v", commits, err)
+	}
+
+	dir, err := p.GetDirContents("/", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff([]string{"README.md", "doc/"}, dir); d != "" {
+		t.Errorf("dir contents (-want +got):\n%s", d)
+	}
+	if dir, err = p.GetDirContents("/src", first); err != nil || len(dir) != 1 || dir[0] != "main.go" {
+		t.Errorf("dir contents of /src at %s: %v, %v", first, dir, err)
+	}
+
+	details, err := p.GetCommitDetails(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantDetails := &CommitDetails{
+		Id:          first,
+		Timestamp:   t0.Unix(),
+		Author:      "alice@example.com",
+		Title:       "add readme",
+		Description: "first commit",
+		CommitFiles: []*CommitFileInfo{
+			{Path: "README.md", Actions: "A"},
+			{Path: "src/main.go", Actions: "A"},
+		},
+	}
+	if d := cmp.Diff(wantDetails, details); d != "" {
+		t.Errorf("commit details (-want +got):\n%s", d)
+	}
+}
@@ -51,7 +51,7 @@ type IProject interface {
 
 	// report_occurrence
 	GetOccurrenceReport(name string) (*OccurrenceReport, error)
-	GenOccurrenceReport(name string, items []string) error
+	GenOccurrenceReport(ctx context.Context, name string, items []string, opts OccurrenceReportOptions) error
 }
 
 type BlameDetails struct {
@@ -81,42 +81,6 @@ func ListProjects (baseDir string) ([]string, error) {
 	return list, nil
 }
 
-func NewProject (projectName string, baseDir string) IProject {
-	baseDir, err := filepath.Abs(baseDir)
-	if err != nil {
-		return nil
-	}
-	info, err := os.Stat(baseDir)
-	if err != nil {
-		return nil
-	}
-	options := make(map[string]string)
-	// git project:
-	// - .git
-	gitGuess := filepath.Join(baseDir, ".git")
-	info, err = os.Stat(gitGuess)
-	if err == nil {
-		if !info.IsDir() {
-			return nil
-		}
-		getGitProjectOptions(baseDir, &options)
-		return NewGitProject(projectName, baseDir, options)
-	}
-	// p4 project:
-	// - .p4
-	p4Guess := filepath.Join(baseDir, ".p4")
-	info, err = os.Stat(p4Guess)
-	if err == nil {
-		if !info.IsDir() {
-			return nil
-		}
-		getP4ProjectOptions(baseDir, &options)
-		return NewP4Project(projectName, baseDir, options)
-	}
-	// not support yet
-	return nil
-}
-
 var gitRemoteMatcher = regexp.MustCompile(`^origin\s+(.*)\s+\([a-z]+\)$`)
 
 func getGitProjectOptions(baseDir string, options *map[string]string) {
@@ -381,7 +345,7 @@ func (p *P4Project) Sync () (map[string]string, error) {
 		return updatedList, err
 	}
 	if !fileinfo.IsDir() {
-		return updatedList, fmt.Errorf("P/%s: [E] cannot clone repo since \"%s\" is not a directory", p.Name)
+		return updatedList, fmt.Errorf("P/%s: [E] cannot clone repo since \"%s\" is not a directory", p.Name, p.BaseDir)
 	}
 	err = p.sync(&updatedList)
 	return updatedList, err
@@ -911,14 +875,14 @@ func (p *GitProject) Sync () (map[string]string, error) {
 		return updatedList, err
 	}
 	if !fileinfo.IsDir() {
-		return updatedList, fmt.Errorf("P/%s: [E] cannot clone repo since \"%s\" is not a directory", p.Name)
+		return updatedList, fmt.Errorf("P/%s: [E] cannot clone repo since \"%s\" is not a directory", p.Name, p.BaseDir)
 	}
 	err = p.sync(&updatedList)
 	return updatedList, err
 }
 
 func (p *GitProject) Compile () error {
-	return nil
+	return p.indexCommits()
 }
 
 func (p *GitProject) GetProjectType () string {
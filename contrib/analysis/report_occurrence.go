@@ -1,22 +1,61 @@
 package analysis
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"time"
-	"regexp"
+	"regexp/syntax"
+	"slices"
+	"sort"
 	"strings"
-	"io/ioutil"
 	"path/filepath"
 	"encoding/json"
-	"github.com/sourcegraph/zoekt/contrib"
+	"github.com/grafana/regexp"
+	"github.com/sourcegraph/zoekt"
+	"github.com/sourcegraph/zoekt/contrib/keyval"
+	"github.com/sourcegraph/zoekt/query"
 )
 
 type OccurrenceReport struct {
 	GenTime int64             `json:"gentime"`
-	// 0 = ready, 1 = generating
-	Status  int               `json:"status"`
+	Status  OccurrenceStatus   `json:"status"`
 	Groups  []*OccurrenceGroup `json:"groups"`
+	// index state of each repository when the report was generated;
+	// the report is reused as long as the shards stay the same
+	Shards map[string]*OccurrenceShards `json:"shards,omitempty"`
+}
+
+// OccurrenceStatus tells the progress of report generation
+type OccurrenceStatus struct {
+	Generating bool `json:"generating"`
+	// number of groups searched out of all groups
+	Done  int `json:"done"`
+	Total int `json:"total"`
+	// reason why the last generation failed
+	Error string `json:"error,omitempty"`
+}
+
+// UnmarshalJSON also accepts the 0/1 status of reports from older versions
+func (s *OccurrenceStatus) UnmarshalJSON(b []byte) error {
+	var legacy int
+	if json.Unmarshal(b, &legacy) == nil {
+		*s = OccurrenceStatus{Generating: legacy != 0}
+		return nil
+	}
+	type plain OccurrenceStatus
+	return json.Unmarshal(b, (*plain)(s))
+}
+
+// OccurrenceShards identifies the index of a repository
+type OccurrenceShards struct {
+	ID       string                   `json:"id"`
+	Branches []zoekt.RepositoryBranch `json:"branches"`
+}
+
+func (s *OccurrenceShards) equal(o *OccurrenceShards) bool {
+	return s != nil && o != nil && s.ID == o.ID && slices.Equal(s.Branches, o.Branches)
 }
 
 type OccurrenceGroup struct {
@@ -33,18 +72,56 @@ type OccurrenceGroup struct {
 	*/
 }
 
-func getOccurrenceReport(p IProject, name string) (*OccurrenceReport, error) {
+// OccurrenceReportOptions configures GenOccurrenceReport
+type OccurrenceReportOptions struct {
+	// searcher over the index built for the project, see contrib.Index;
+	// required
+	Searcher zoekt.Searcher
+	// optional; called whenever the generation makes progress
+	Progress func(status OccurrenceStatus)
+}
+
+// occurrence reports are stored in the storage of the project, see
+// MetadataStorage, at report://occurrence/<name>. Older versions kept them in
+// <metadata dir>/.zoekt/report/occurrence/<name>.json.
+
+func getOccurrenceReportKey(s keyval.Storage, name string) string {
+	return s.WithSubkey(s.UrlToKey("report://occurrence"), name)
+}
+
+func getLegacyOccurrenceReportPath(p IProject, name string) string {
 	metaBaseDir := p.GetMetadataDir()
 	reportBaseDir := filepath.Join(metaBaseDir, ".zoekt", "report", "occurrence")
-	reportPath := filepath.Join(reportBaseDir, fmt.Sprintf("%s.json", name))
-	_, err := os.Stat(reportPath)
-	if err != nil { return nil, err }
-	b, err := ioutil.ReadFile(reportPath)
-	if err != nil { return nil, err }
+	return filepath.Join(reportBaseDir, fmt.Sprintf("%s.json", name))
+}
+
+// loadOccurrenceReport returns the stored report called name and its
+// version, which is keyval.NoVersion for missing reports and reports of
+// older versions.
+func loadOccurrenceReport(p IProject, name string) (*OccurrenceReport, keyval.Version, error) {
+	s, err := MetadataStorage(p)
+	if err != nil {
+		return nil, keyval.NoVersion, err
+	}
+	b, v, err := s.Get(getOccurrenceReportKey(s, name))
+	if errors.Is(err, keyval.ErrNotFound) {
+		b, err = os.ReadFile(getLegacyOccurrenceReportPath(p, name))
+	}
+	if err != nil {
+		return nil, keyval.NoVersion, err
+	}
 	report := &OccurrenceReport{}
 	err = json.Unmarshal(b, &report)
-	if err != nil { return nil, err }
-	return report, nil
+	if err != nil {
+		// keep the version, so that a broken report is replaced
+		return nil, v, err
+	}
+	return report, v, nil
+}
+
+func getOccurrenceReport(p IProject, name string) (*OccurrenceReport, error) {
+	report, _, err := loadOccurrenceReport(p, name)
+	return report, err
 }
 
 func (p *P4Project) GetOccurrenceReport(name string) (*OccurrenceReport, error) {
@@ -68,38 +145,69 @@ func getScopeGroupName(scope string) string {
 	return parts[n - 1]
 }
 
-var stops = regexp.MustCompile("[~!@#$%^&*()+\\-={}|:\"<>?\\[\\]\\\\;',./ \\t\\r\\n\\`]+")
+// occurrenceQuery matches the whole word of a report group in repo
+func occurrenceQuery(repo, word string) (query.Q, error) {
+	re, err := syntax.Parse(`\b`+regexp.QuoteMeta(word)+`\b`, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	return query.NewAnd(
+		&query.Repo{Regexp: regexp.MustCompile("^" + regexp.QuoteMeta(repo) + "$")},
+		&query.Regexp{Regexp: re, Content: true, CaseSensitive: true},
+	), nil
+}
 
-func processOneFileOccurrence(path, baseDir string, groupMap map[string]*OccurrenceGroup) error {
-	if yes, _ := contrib.IsBinaryFile(path); yes {
-		// skip binary file
-		return nil
+func searchOccurrenceGroup(ctx context.Context, searcher zoekt.Searcher, repo string, group *OccurrenceGroup) error {
+	q, err := occurrenceQuery(repo, group.Name)
+	if err != nil {
+		return err
+	}
+	sres, err := searcher.Search(ctx, q, &zoekt.SearchOptions{})
+	if err != nil {
+		return err
 	}
-	lineNo := 1
-	contrib.File2Lines(path, func (line string) {
-		wordMap := make(map[string]bool)
-		for _, word := range stops.Split(line, -1) {
-			if word == "" { continue }
-			group, ok := groupMap[word]
-			if !ok { continue }
-			group.Count ++
-			if _, ok = wordMap[word]; ok { continue }
-			wordMap[word] = true
-			relativePath := strings.TrimPrefix(path, baseDir)
-			group.Cases = append(group.Cases, fmt.Sprintf("%s#L%d", relativePath, lineNo))
+	files := sres.Files
+	sort.Slice(files, func(i, j int) bool { return files[i].FileName < files[j].FileName })
+	for _, f := range files {
+		lines := f.LineMatches
+		sort.Slice(lines, func(i, j int) bool { return lines[i].LineNumber < lines[j].LineNumber })
+		for _, m := range lines {
+			if m.FileName {
+				continue
+			}
+			group.Count += len(m.LineFragments)
+			group.Cases = append(group.Cases, fmt.Sprintf("/%s#L%d", f.FileName, m.LineNumber))
 		}
-		lineNo ++
-	})
+	}
 	return nil
 }
 
-func genOccurrenceReport(p IProject, name string, items []string) error {
+// getOccurrenceShards returns the current index state of repo
+func getOccurrenceShards(ctx context.Context, searcher zoekt.Searcher, repo string) (*OccurrenceShards, error) {
+	q := &query.Repo{Regexp: regexp.MustCompile("^" + regexp.QuoteMeta(repo) + "$")}
+	rl, err := searcher.List(ctx, q, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(rl.Repos) == 0 {
+		return nil, fmt.Errorf("%s is not indexed", repo)
+	}
+	r := rl.Repos[0]
+	return &OccurrenceShards{ID: r.IndexMetadata.ID, Branches: r.Repository.Branches}, nil
+}
+
+func genOccurrenceReport(ctx context.Context, p IProject, name string, items []string, opts OccurrenceReportOptions) error {
 	// items = scope1.scope2.....name
-	var report OccurrenceReport
-	// TODO read prev report if exists
-	report.Status = 1
+	if opts.Searcher == nil {
+		return fmt.Errorf("occurrence report %s: no searcher", name)
+	}
+	progress := func(status OccurrenceStatus) {
+		if opts.Progress != nil {
+			opts.Progress(status)
+		}
+	}
 	groupMap := make(map[string]*OccurrenceGroup)
-
+	groupNames := make([]string, 0)
 	for _, one := range items {
 		groupName := getScopeGroupName(one)
 		group, ok := groupMap[groupName]
@@ -109,57 +217,87 @@ func genOccurrenceReport(p IProject, name string, items []string) error {
 			group.Items = make([]string, 0)
 			group.Cases = make([]string, 0)
 			groupMap[groupName] = group
+			groupNames = append(groupNames, groupName)
 		}
 		if !stringContains(group.Items, one) {
 			group.Items = append(group.Items, one)
 		}
 	}
 
-	baseDir := p.GetBaseDir()
-	ignoredDirs := []string {".git", ".p4", ".zoekt"}
-	filepath.Walk(baseDir, func (path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-		if info.IsDir() {
-			if stringContains(ignoredDirs, info.Name()) {
-				return filepath.SkipDir
+	// the index of a project is named after its directory, see contrib.Index
+	repo := filepath.Base(p.GetBaseDir())
+	shards, err := getOccurrenceShards(ctx, opts.Searcher, repo)
+	if err != nil {
+		return err
+	}
+
+	// groups of the previous report stay valid while the shards are unchanged
+	prevGroups := make(map[string]*OccurrenceGroup)
+	prev, prevVersion, err := loadOccurrenceReport(p, name)
+	if err == nil && shards.equal(prev.Shards[repo]) {
+		for _, group := range prev.Groups {
+			if len(group.Items) > 0 {
+				prevGroups[getScopeGroupName(group.Items[0])] = group
 			}
+		}
+	}
+
+	status := OccurrenceStatus{Generating: true, Total: len(groupNames)}
+	progress(status)
+	changed := len(prevGroups) != len(groupNames)
+	for _, groupName := range groupNames {
+		group := groupMap[groupName]
+		if prev, ok := prevGroups[groupName]; ok && slices.Equal(prev.Items, group.Items) {
+			group.Count = prev.Count
+			group.Cases = prev.Cases
 		} else {
-			e := processOneFileOccurrence(path, baseDir, groupMap)
-			if e != nil {
-				fmt.Println("occurrenceReport | cannot process:", name, path, e)
+			changed = true
+			if err := searchOccurrenceGroup(ctx, opts.Searcher, repo, group); err != nil {
+				return err
 			}
 		}
+		status.Done++
+		progress(status)
+	}
+	status.Generating = false
+	if !changed {
+		// same items on the same shards
 		return nil
-	})
+	}
 
-	report.Status = 0
+	var report OccurrenceReport
+	report.Status = status
 	report.GenTime = time.Now().Unix()
-	report.Groups = make([]*OccurrenceGroup, 0)
-	for _, group := range groupMap {
-		report.Groups = append(report.Groups, group)
+	report.Shards = map[string]*OccurrenceShards{repo: shards}
+	report.Groups = make([]*OccurrenceGroup, 0, len(groupNames))
+	for _, groupName := range groupNames {
+		report.Groups = append(report.Groups, groupMap[groupName])
 	}
 
-	metaBaseDir := p.GetMetadataDir()
-	reportBaseDir := filepath.Join(metaBaseDir, ".zoekt", "report", "occurrence")
-	contrib.PrepareDirectory(reportBaseDir)
-	reportPath := filepath.Join(reportBaseDir, fmt.Sprintf("%s.json", name))
-	rpf, err := os.Create(reportPath)
-	if err != nil { return err }
-	defer rpf.Close()
 	b, err := json.Marshal(report)
 	if err != nil { return err }
-	_, err = rpf.Write(b)
-	if err != nil { return err }
+	s, err := MetadataStorage(p)
+	if err != nil {
+		return err
+	}
+	// only replace the report generation started from
+	_, err = s.CompareAndSwap(getOccurrenceReportKey(s, name), prevVersion, b)
+	if errors.Is(err, keyval.ErrConflict) {
+		// a newer report was stored meanwhile
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	os.Remove(getLegacyOccurrenceReportPath(p, name))
 	return nil
 }
 
-func (p *P4Project) GenOccurrenceReport(name string, items []string) error {
-	return genOccurrenceReport(p, name, items)
+func (p *P4Project) GenOccurrenceReport(ctx context.Context, name string, items []string, opts OccurrenceReportOptions) error {
+	return genOccurrenceReport(ctx, p, name, items, opts)
 }
 
-func (p *GitProject) GenOccurrenceReport(name string, items []string) error {
-	return genOccurrenceReport(p, name, items)
+func (p *GitProject) GenOccurrenceReport(ctx context.Context, name string, items []string, opts OccurrenceReportOptions) error {
+	return genOccurrenceReport(ctx, p, name, items, opts)
 }
 
@@ -0,0 +1,13 @@
+package analysis
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGenOccurrenceReportWithoutSearcher(t *testing.T) {
+	p := &StubProject{Name: "report", BaseDir: t.TempDir()}
+	if err := p.GenOccurrenceReport(context.Background(), "r", []string{"Klass.Rename"}, OccurrenceReportOptions{}); err == nil {
+		t.Error("generating a report without searcher succeeded")
+	}
+}
@@ -0,0 +1,33 @@
+package analysis
+
+import (
+	"path/filepath"
+	"sync"
+
+	"github.com/sourcegraph/zoekt/contrib/keyval"
+)
+
+// metadataStorages caches the storages of the metadata directories, since a
+// bbolt database can only be opened once.
+var metadataStorages = struct {
+	sync.Mutex
+	byDir map[string]keyval.Storage
+}{byDir: make(map[string]keyval.Storage)}
+
+// MetadataStorage returns the storage for the track points and reports of
+// p. It is kept in the .zoekt directory of the metadata directory of p, with
+// the backend of keyval.DefaultConfig.
+func MetadataStorage(p IProject) (keyval.Storage, error) {
+	dir := filepath.Join(p.GetMetadataDir(), ".zoekt")
+	metadataStorages.Lock()
+	defer metadataStorages.Unlock()
+	if s, ok := metadataStorages.byDir[dir]; ok {
+		return s, nil
+	}
+	s, err := keyval.Open(keyval.Config{Backend: keyval.DefaultConfig().Backend, BaseDir: dir})
+	if err != nil {
+		return nil, err
+	}
+	metadataStorages.byDir[dir] = s
+	return s, nil
+}
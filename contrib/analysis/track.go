@@ -1,13 +1,15 @@
 package analysis
 
 import (
+	"bytes"
 	"fmt"
+	"errors"
 	"os"
 	"path/filepath"
 	"encoding/json"
 	"strings"
 	"sync"
-	"github.com/sourcegraph/zoekt/contrib"
+	"github.com/sourcegraph/zoekt/contrib/keyval"
 )
 
 type DiffMap struct {
@@ -346,57 +348,99 @@ type TrackFile struct {
 	Meta    TrackFileMeta
 	Points  []TrackPoint
 	mutex   *sync.Mutex
+	// version of the stored track points Meta and Points were loaded from
+	version keyval.Version
+	// set if they were loaded from the track file of older versions
+	legacy bool
 }
 
 // metadata
-// /project/(.git|.p4)/.zoekt/track/path... ._ -> timestmp, points
+// track://path... in the storage of the project, see MetadataStorage:
+// timestamp, points
+// older versions: /project/(.git|.p4)/.zoekt/track/path... ._
 
 func NewTrackFile(p IProject, path string) *TrackFile {
-	tf := &TrackFile{p, path, TrackFileMeta{1, 0, ""}, nil, &sync.Mutex{}}
+	tf := &TrackFile{Project: p, Path: path, Meta: TrackFileMeta{1, 0, ""}, mutex: &sync.Mutex{}}
 	return tf
 }
 
-func (f *TrackFile) Load() error {
-	metaBaseDir := f.Project.GetMetadataDir()
-	track := filepath.Join(metaBaseDir, ".zoekt", "track", f.Path + "._")
-	header := true
+func (f *TrackFile) key(s keyval.Storage) string {
+	return s.UrlToKey("track://" + strings.TrimSuffix(f.Path, "/"))
+}
 
-	var loadErr error
-	err := contrib.File2Lines(track, func (line string) {
-		if loadErr != nil {
-			return
-		}
-		if line == "" {
-			return
-		}
-		if strings.HasPrefix(line, "#") {
-			return
+func (f *TrackFile) legacyPath() string {
+	return filepath.Join(f.Project.GetMetadataDir(), ".zoekt", "track", f.Path+"._")
+}
+
+// parse reads the metadata from the first line of b, and one track point
+// from each following line.
+func (f *TrackFile) parse(b []byte) error {
+	f.Meta = TrackFileMeta{1, 0, ""}
+	f.Points = nil
+	header := true
+	for _, line := range strings.Split(string(b), "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
 		}
-		b := []byte(line)
 		if header {
 			header = false
 			// load basic info
-			loadErr = json.Unmarshal(b, &f.Meta)
-			if loadErr != nil {
-				return
+			if err := json.Unmarshal([]byte(line), &f.Meta); err != nil {
+				return err
 			}
-			return
-		}
-		if f.Points == nil {
-			f.Points = make([]TrackPoint, 0)
+			continue
 		}
 		// load track points
 		tp := TrackPoint{}
-		loadErr = json.Unmarshal(b, &tp)
-		if loadErr != nil {
-			return
+		if err := json.Unmarshal([]byte(line), &tp); err != nil {
+			return err
 		}
 		f.Points = append(f.Points, tp)
-	})
-	if loadErr == nil {
-		loadErr = err
 	}
-	return loadErr
+	return nil
+}
+
+func (f *TrackFile) marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	b, err := json.Marshal(f.Meta)
+	if err != nil {
+		return nil, err
+	}
+	buf.Write(b)
+	buf.WriteByte('\n')
+	for _, p := range f.Points {
+		b, err = json.Marshal(p)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// Load reads the stored track points. It returns keyval.ErrNotFound if the
+// file has none.
+func (f *TrackFile) Load() error {
+	s, err := MetadataStorage(f.Project)
+	if err != nil {
+		return err
+	}
+	b, v, err := s.Get(f.key(s))
+	f.legacy = false
+	if errors.Is(err, keyval.ErrNotFound) {
+		// fall back to the track file of older versions; Save moves it
+		// into the storage
+		legacy, lerr := os.ReadFile(f.legacyPath())
+		if lerr != nil {
+			return err
+		}
+		b, f.legacy = legacy, true
+	} else if err != nil {
+		return err
+	}
+	f.version = v
+	return f.parse(b)
 }
 
 func (f *TrackFile) GetById(id int) *TrackPoint {
@@ -471,38 +515,49 @@ func (f *TrackFile) Del(id int) error {
 	return nil
 }
 
+// Save stores the track points. It returns keyval.ErrConflict if another
+// writer stored them since they were loaded, see Update.
 func (f *TrackFile) Save() error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
-	metaBaseDir := f.Project.GetMetadataDir()
-	track := filepath.Join(metaBaseDir, ".zoekt", "track", f.Path + "._")
-	dirname := filepath.Dir(track)
-	contrib.PrepareDirectory(dirname)
-
-	trf, err := os.Create(track)
+	s, err := MetadataStorage(f.Project)
 	if err != nil {
 		return err
 	}
-	defer trf.Close()
-	b, err := json.Marshal(f.Meta)
-	if err != nil { return err }
-	_, err = trf.Write(b)
-	if err != nil { return err }
-	_, err = trf.WriteString("\n")
-	if err != nil { return err }
-	if f.Points != nil {
-		for _, p := range f.Points {
-			b, err = json.Marshal(p)
-			if err != nil { return err }
-			_, err = trf.Write(b)
-			if err != nil { return err }
-			_, err = trf.WriteString("\n")
-			if err != nil { return err }
-		}
+	b, err := f.marshal()
+	if err != nil {
+		return err
+	}
+	v, err := s.CompareAndSwap(f.key(s), f.version, b)
+	if err != nil {
+		return err
+	}
+	f.version = v
+	if f.legacy {
+		f.legacy = false
+		os.Remove(f.legacyPath())
 	}
 	return nil
 }
 
+// Update syncs the track points, applies fn to them and saves them. If
+// another writer saved them in the meantime, it starts over, so fn must only
+// change the track points.
+func (f *TrackFile) Update(fn func() error) error {
+	for {
+		err := f.Sync()
+		if err == nil {
+			err = fn()
+		}
+		if err == nil {
+			err = f.Save()
+		}
+		if !errors.Is(err, keyval.ErrConflict) {
+			return err
+		}
+	}
+}
+
 func (f *TrackFile) TrackLines () error {
 	if f.Points == nil { return nil }
 	commits, err := f.Project.GetFileCommitInfo(f.Path, 0, 1)
@@ -554,8 +609,6 @@ func (f *TrackFile) Sync() error {
 	}
 	baseDir := f.Project.GetBaseDir()
 	source := filepath.Join(baseDir, f.Path)
-	metaBaseDir := f.Project.GetMetadataDir()
-	track := filepath.Join(metaBaseDir, ".zoekt", "track", f.Path + "._")
 	info, err := os.Stat(source)
 	if err != nil {
 		return err
@@ -565,20 +618,16 @@ func (f *TrackFile) Sync() error {
 	}
 	updatedTs := info.ModTime().Unix()
 
-	dirname := filepath.Dir(track)
-	contrib.PrepareDirectory(dirname)
-	info, err = os.Stat(track)
-
-	if os.IsNotExist(err) {
+	// load data and compare with current timestamp
+	err = f.Load()
+	if errors.Is(err, keyval.ErrNotFound) {
 		// init track
-		f.Meta.Timestamp = 0
+		f.Meta = TrackFileMeta{1, 0, ""}
+		f.Points = nil
+		f.version = keyval.NoVersion
 		err = f.SyncMetadata()
 		if err != nil { return err }
-	} else if err == nil {
-		// load data and compare with current timestamp
-		err = f.Load()
-		if err != nil { return err }
-	} else {
+	} else if err != nil {
 		return err
 	}
 	if updatedTs != f.Meta.Timestamp {
@@ -0,0 +1,70 @@
+package analysis
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sourcegraph/zoekt/contrib/keyval"
+)
+
+func TestTrackFileLegacyMigration(t *testing.T) {
+	p := &StubProject{
+		Name:    "track",
+		BaseDir: t.TempDir(),
+		Commits: map[string]*CommitDetails{
+			"c1": {Id: "c1", CommitFiles: []*CommitFileInfo{{Path: "/main.go"}}},
+		},
+		History: []string{"c1"},
+	}
+
+	tf := NewTrackFile(p, "/main.go")
+	if err := tf.Load(); !errors.Is(err, keyval.ErrNotFound) {
+		t.Fatalf("Load without track points: got %v, want ErrNotFound", err)
+	}
+
+	// Older versions kept the track points in a file next to the project.
+	legacy := tf.legacyPath()
+	if err := os.MkdirAll(filepath.Dir(legacy), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(legacy, []byte(`{"nid":3,"mtime":0,"rev":""}`+"\n"+`{"id":2,"o":{"c0":7},"line":7}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := tf.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if !tf.legacy || tf.Meta.NextId != 3 || tf.GetById(2) == nil {
+		t.Fatalf("legacy track points not loaded: %+v", tf)
+	}
+
+	// Saving moves them into the storage.
+	if _, err := tf.Add(9); err != nil {
+		t.Fatal(err)
+	}
+	if err := tf.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(legacy); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("legacy track file still exists: %v", err)
+	}
+	loaded := NewTrackFile(p, "/main.go")
+	if err := loaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if loaded.legacy || loaded.GetById(2) == nil || loaded.GetByLine(9) == nil {
+		t.Errorf("track points not stored: %+v", loaded)
+	}
+
+	// A track file saved by another writer since it was loaded conflicts.
+	if _, err := loaded.Add(11); err != nil {
+		t.Fatal(err)
+	}
+	if err := loaded.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tf.Save(); !errors.Is(err, keyval.ErrConflict) {
+		t.Errorf("saving a stale track file: got %v, want ErrConflict", err)
+	}
+}
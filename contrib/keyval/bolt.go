@@ -0,0 +1,182 @@
+package keyval
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// boltFile is the name of the database of StorageBolt in its base directory.
+const boltFile = "keyval.db"
+
+var boltBucket = []byte("keyval")
+
+// StorageBolt stores all values in a bbolt database. Every write is a
+// transaction, so values are never partially written, and the database can
+// only be opened by one process at a time.
+//
+// Keys are the escaped parts of the URL joined with "/", like the relative
+// paths of StorageFilesystem. Values are stored behind their 8 byte version.
+type StorageBolt struct {
+	db *bolt.DB
+}
+
+// OpenStorageBolt opens or creates the database of a StorageBolt in
+// baseDir.
+func OpenStorageBolt(baseDir string) (*StorageBolt, error) {
+	if baseDir == "" {
+		return nil, errors.New("[E] [Storage.Bolt] empty base directory")
+	}
+	if err := os.MkdirAll(baseDir, 0755); err != nil {
+		return nil, err
+	}
+	// Fail rather than wait forever if another process has the database
+	// open.
+	db, err := bolt.Open(filepath.Join(baseDir, boltFile), 0644, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(boltBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &StorageBolt{db: db}, nil
+}
+
+func (s *StorageBolt) UrlToKey(url_ string) string {
+	return strings.Join(append(urlToParts(url_), "_"), "/")
+}
+
+func (s *StorageBolt) KeyToUrl(key string) string {
+	return partsToUrl(strings.Split(key, "/"))
+}
+
+func (s *StorageBolt) WithSubkey(key, subkey string) string {
+	return path.Join(key, subkey)
+}
+
+func (s *StorageBolt) ListSubkey(key string, after string, limit int) ([]string, error) {
+	if limit <= 0 {
+		limit = MAX_SUBKEY_DISPLAY_NUMBER
+	}
+	prefix := key + "/"
+	r := make([]string, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(boltBucket).Cursor()
+		k, _ := c.Seek([]byte(prefix + after))
+		for k != nil && len(r) < limit && bytes.HasPrefix(k, []byte(prefix)) {
+			name := string(k[len(prefix):])
+			if dir, _, ok := strings.Cut(name, "/"); ok {
+				// Skip the other keys below dir: "0" follows "/".
+				name = dir + "/"
+				k, _ = c.Seek([]byte(prefix + dir + "0"))
+			} else {
+				k, _ = c.Next()
+			}
+			if name > after {
+				r = append(r, name)
+			}
+		}
+		return nil
+	})
+	return r, err
+}
+
+func (s *StorageBolt) Scan(prefix string, after string, limit int) ([]string, error) {
+	r := make([]string, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(boltBucket).Cursor()
+		k, _ := c.Seek([]byte(max(prefix, after)))
+		for ; k != nil && bytes.HasPrefix(k, []byte(prefix)); k, _ = c.Next() {
+			if limit > 0 && len(r) >= limit {
+				break
+			}
+			if string(k) > after {
+				r = append(r, string(k))
+			}
+		}
+		return nil
+	})
+	return r, err
+}
+
+func decodeBoltValue(v []byte) ([]byte, Version) {
+	return bytes.Clone(v[8:]), Version(binary.BigEndian.Uint64(v))
+}
+
+// get returns the value of key in tx and its version.
+func (s *StorageBolt) get(tx *bolt.Tx, key string) ([]byte, Version, error) {
+	v := tx.Bucket(boltBucket).Get([]byte(key))
+	if v == nil {
+		return nil, NoVersion, ErrNotFound
+	}
+	value, ver := decodeBoltValue(v)
+	return value, ver, nil
+}
+
+// put stores value at key in tx with a new version.
+func (s *StorageBolt) put(tx *bolt.Tx, key string, value []byte) (Version, error) {
+	b := tx.Bucket(boltBucket)
+	seq, err := b.NextSequence()
+	if err != nil {
+		return NoVersion, err
+	}
+	v := binary.BigEndian.AppendUint64(make([]byte, 0, 8+len(value)), seq)
+	return Version(seq), b.Put([]byte(key), append(v, value...))
+}
+
+func (s *StorageBolt) Get(key string) (value []byte, ver Version, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		value, ver, err = s.get(tx, key)
+		return err
+	})
+	return value, ver, err
+}
+
+func (s *StorageBolt) Put(key string, value []byte) (ver Version, err error) {
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		ver, err = s.put(tx, key, value)
+		return err
+	})
+	return ver, err
+}
+
+func (s *StorageBolt) CompareAndSwap(key string, old Version, value []byte) (ver Version, err error) {
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		_, cur, err := s.get(tx, key)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+		if cur != old {
+			return ErrConflict
+		}
+		ver, err = s.put(tx, key, value)
+		return err
+	})
+	return ver, err
+}
+
+func (s *StorageBolt) Del(key string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(boltBucket)
+		if b.Get([]byte(key)) == nil {
+			return ErrNotFound
+		}
+		return b.Delete([]byte(key))
+	})
+}
+
+func (s *StorageBolt) Close() error {
+	return s.db.Close()
+}
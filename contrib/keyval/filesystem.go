@@ -1,23 +1,37 @@
 package keyval
 
 import (
-	"path/filepath"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
 	"net/url"
 	"os"
-	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
-	"errors"
-	"fmt"
+	"sync"
 )
 
-const MAX_SUBKEY_DISPLAY_NUMBER = 4096;
+const MAX_SUBKEY_DISPLAY_NUMBER = 4096
 
-type StorageFilesystem struct{
-	Storage
+// tmpPrefix starts the names of the files StorageFilesystem writes before
+// renaming them into place. Listings and scans skip them.
+const tmpPrefix = ".keyval-tmp-"
+
+// StorageFilesystem stores each value in a file below a base directory.
+// Writes replace files atomically, and are serialized so that
+// CompareAndSwap is atomic within the process.
+type StorageFilesystem struct {
+	baseDir string
+	mu      sync.Mutex
 }
 
 type config struct {
 	BaseDir string
+	Backend string
+	Index   bool
 }
 
 var defaultConfig config
@@ -34,6 +48,14 @@ func init() {
 		panic("[E] [Storage.Filesystem] cannot assign base directory")
 	}
 	defaultConfig.BaseDir += string(filepath.Separator)
+	defaultConfig.Backend = os.Getenv("KEYVAL_STORAGE_BACKEND")
+	defaultConfig.Index = true
+	if indexStr := os.Getenv("KEYVAL_STORAGE_INDEX"); indexStr != "" {
+		defaultConfig.Index, err = strconv.ParseBool(indexStr)
+		if err != nil {
+			panic("[E] [Storage.Filesystem] invalid KEYVAL_STORAGE_INDEX")
+		}
+	}
 }
 
 func GetBaseDir() string {
@@ -44,23 +66,40 @@ func IsKeyvalFSEnabled() bool {
 	return defaultConfig.BaseDir != ""
 }
 
-func (s *StorageFilesystem) prepare(path string) error {
+// DefaultConfig returns the configuration of the default storage: the
+// directory from $KEYVAL_STORAGE_FS_BASE_DIR and the backend from
+// $KEYVAL_STORAGE_BACKEND. The storage is indexed unless
+// $KEYVAL_STORAGE_INDEX is false.
+func DefaultConfig() Config {
+	return Config{Backend: defaultConfig.Backend, BaseDir: defaultConfig.BaseDir, Index: defaultConfig.Index}
+}
+
+// NewStorageFilesystem returns a storage which keeps its files below
+// baseDir.
+func NewStorageFilesystem(baseDir string) (*StorageFilesystem, error) {
+	if baseDir == "" {
+		return nil, errors.New("[E] [Storage.Filesystem] empty base directory")
+	}
+	baseDir, err := filepath.Abs(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageFilesystem{baseDir: baseDir + string(filepath.Separator)}, nil
+}
+
+// check returns an error if path is outside of the base directory.
+func (s *StorageFilesystem) check(path string) error {
 	path, err := filepath.Abs(path)
 	if err != nil {
 		return err
 	}
-	if !strings.HasPrefix(path, defaultConfig.BaseDir) {
+	if !strings.HasPrefix(path+string(filepath.Separator), s.baseDir) {
 		return errors.New("[E] [Storage.Filesystem] hacked file path")
 	}
-	err = os.MkdirAll(path, 0755)
-	if err != nil {
-		return err
-	}
-
 	return nil
 }
 
-func (s *StorageFilesystem) keyUnderLine(part string) string {
+func keyUnderLine(part string) string {
 	// _ is control character, so we add _ when we meet under line part
 	// e.g. _ -> __, ___ -> ____
 	if part == "" {
@@ -74,7 +113,7 @@ func (s *StorageFilesystem) keyUnderLine(part string) string {
 	return "_" + part
 }
 
-func (s *StorageFilesystem) urlUnderLine(part string) string {
+func urlUnderLine(part string) string {
 	// _ is control character, so we remove _ when we meet under line part
 	// e.g. __ -> _, ____ -> ___
 	if part == "" {
@@ -88,10 +127,9 @@ func (s *StorageFilesystem) urlUnderLine(part string) string {
 	return string([]rune(part)[1:])
 }
 
-func (s *StorageFilesystem) UrlToKey(url_ string) string {
-	// convert url to key, where the key is a file path
-	// url: https://www.github.com/dna2zodiac/keyval
-	// key: /baseDir/https/www.github.com/dna2zodiac/keyval/_
+// urlToParts returns the escaped path parts of the key of url_, without the
+// trailing "_".
+func urlToParts(url_ string) []string {
 	if strings.Index(url_, "://") < 0 {
 		if strings.HasPrefix(url_, "//") {
 			// e.g. //github.com -> keyval://github.com
@@ -102,37 +140,52 @@ func (s *StorageFilesystem) UrlToKey(url_ string) string {
 		}
 	}
 	parts := strings.Split(url_, "/")
-	parts[0] = string([]rune(parts[0])[0:len(parts[0]) - 1])
-	for i, part := range parts {
-		parts[i] = url.QueryEscape(s.keyUnderLine(part))
+	parts[0] = strings.TrimSuffix(parts[0], ":")
+	escaped := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part == "" {
+			continue
+		}
+		escaped = append(escaped, url.QueryEscape(keyUnderLine(part)))
 	}
-	key_ := filepath.Join(parts...)
-	key_ = filepath.Join(defaultConfig.BaseDir, key_, "_")
-	return key_
+	return escaped
 }
 
-func (s *StorageFilesystem) KeyToUrl(key_ string) string {
-	// convert key to url
-	// key: /baseDir/https/www.github.com/dna2zodiac/keyval/_
-	// url: https://www.github.com/dna2zodiac/keyval
-	if !strings.HasSuffix(key_, "_") {
-		return ""
-	}
-	if !strings.HasPrefix(key_, defaultConfig.BaseDir) {
+// partsToUrl is the inverse of urlToParts. It returns "" if parts are not
+// the parts of a key.
+func partsToUrl(parts []string) string {
+	if len(parts) < 2 || parts[len(parts)-1] != "_" {
 		return ""
 	}
-	key_ = string([]rune(key_)[len(defaultConfig.BaseDir):])
-	parts := strings.Split(key_, string(filepath.Separator))
-	parts[0] = string([]rune(parts[0])[0:len(parts[0]) - 1])
+	parts = parts[:len(parts)-1]
+	decoded := make([]string, len(parts))
 	for i, part := range parts {
-		decoded, err := url.QueryUnescape(s.urlUnderLine(part))
-		parts[i] = decoded
+		d, err := url.QueryUnescape(part)
 		if err != nil {
 			return ""
 		}
+		decoded[i] = urlUnderLine(d)
 	}
-	url_ := parts[0] + "://" + strings.Join(parts[1:len(parts) - 1], "/")
-	return url_
+	return decoded[0] + "://" + strings.Join(decoded[1:], "/")
+}
+
+func (s *StorageFilesystem) UrlToKey(url_ string) string {
+	// convert url to key, where the key is a file path
+	// url: https://www.github.com/dna2zodiac/keyval
+	// key: /baseDir/https/www.github.com/dna2zodiac/keyval/_
+	parts := append([]string{s.baseDir}, urlToParts(url_)...)
+	return filepath.Join(append(parts, "_")...)
+}
+
+func (s *StorageFilesystem) KeyToUrl(key_ string) string {
+	// convert key to url
+	// key: /baseDir/https/www.github.com/dna2zodiac/keyval/_
+	// url: https://www.github.com/dna2zodiac/keyval
+	rel, ok := strings.CutPrefix(key_, s.baseDir)
+	if !ok {
+		return ""
+	}
+	return partsToUrl(strings.Split(rel, string(filepath.Separator)))
 }
 
 func (s *StorageFilesystem) WithSubkey(key, subkey string) string {
@@ -142,64 +195,170 @@ func (s *StorageFilesystem) WithSubkey(key, subkey string) string {
 	return filepath.Join(key, strings.ReplaceAll(subkey, "/", string(filepath.Separator)))
 }
 
-func (s *StorageFilesystem) ListSubkey(key string) []string {
-	r := make([]string, 0)
-	f, err := os.Open(key)
-	if err != nil {
-		return r
+// page returns the names after after, at most limit of them.
+func page(names []string, after string, limit int) []string {
+	i := sort.SearchStrings(names, after)
+	if i < len(names) && names[i] == after {
+		i++
 	}
-	defer f.Close()
-	fileList, err := f.Readdir(MAX_SUBKEY_DISPLAY_NUMBER)
-	if err != nil {
-		return r
+	names = names[i:]
+	if limit > 0 && len(names) > limit {
+		names = names[:limit]
 	}
-	for _, item := range fileList {
+	return names
+}
+
+func (s *StorageFilesystem) ListSubkey(key string, after string, limit int) ([]string, error) {
+	if err := s.check(key); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(key)
+	if errors.Is(err, fs.ErrNotExist) {
+		return []string{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	r := make([]string, 0, len(entries))
+	for _, item := range entries {
+		if strings.HasPrefix(item.Name(), tmpPrefix) {
+			continue
+		}
 		if item.IsDir() {
-			r = append(r, item.Name() + string(filepath.Separator))
+			r = append(r, item.Name()+string(filepath.Separator))
 		} else {
 			r = append(r, item.Name())
 		}
 	}
-	return r
+	sort.Strings(r)
+	if limit <= 0 {
+		limit = MAX_SUBKEY_DISPLAY_NUMBER
+	}
+	return page(r, after, limit), nil
 }
 
-func (s *StorageFilesystem) Get(key string) ([]byte, bool) {
-	info, err := os.Stat(key)
+func (s *StorageFilesystem) Scan(prefix string, after string, limit int) ([]string, error) {
+	if err := s.check(prefix); err != nil {
+		return nil, err
+	}
+	// Walk the deepest directory containing all keys with prefix.
+	dir := prefix
+	if !strings.HasSuffix(dir, string(filepath.Separator)) {
+		dir = filepath.Dir(dir)
+	}
+	r := make([]string, 0)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), tmpPrefix) || !strings.HasPrefix(path, prefix) {
+			return nil
+		}
+		r = append(r, path)
+		return nil
+	})
 	if err != nil {
-		return nil, false
+		return nil, err
 	}
-	m := info.Mode()
-	if m & os.ModeDir != 0 || m & os.ModeDevice != 0 {
-		return nil, false
+	sort.Strings(r)
+	return page(r, after, limit), nil
+}
+
+// version returns the version of value, a hash of its contents.
+func version(value []byte) Version {
+	h := fnv.New64a()
+	h.Write(value)
+	if v := Version(h.Sum64()); v != NoVersion {
+		return v
 	}
-	if m & os.ModeNamedPipe != 0 || m & os.ModeSocket != 0 || m & os.ModeIrregular != 0 {
-		return nil, false
+	return 1
+}
+
+func (s *StorageFilesystem) Get(key string) ([]byte, Version, error) {
+	if err := s.check(key); err != nil {
+		return nil, NoVersion, err
 	}
-	r, err := ioutil.ReadFile(key)
-	if err != nil {
-		return nil, false
+	info, err := os.Stat(key)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, NoVersion, ErrNotFound
+	} else if err != nil {
+		return nil, NoVersion, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, NoVersion, ErrNotFound
 	}
-	return r, true
+	r, err := os.ReadFile(key)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, NoVersion, ErrNotFound
+	} else if err != nil {
+		return nil, NoVersion, err
+	}
+	return r, version(r), nil
 }
 
-func (s *StorageFilesystem) Put(key string, value []byte) bool {
-	dir, _ := filepath.Split(key)
-	err := s.prepare(dir)
-	if err != nil {
-		return false
+// write replaces the file key with value. s.mu must be held.
+func (s *StorageFilesystem) write(key string, value []byte) (Version, error) {
+	if err := s.check(key); err != nil {
+		return NoVersion, err
+	}
+	dir := filepath.Dir(key)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return NoVersion, err
 	}
-	err = ioutil.WriteFile(key, value, 0644)
+	f, err := os.CreateTemp(dir, tmpPrefix+"*")
 	if err != nil {
-		return false
+		return NoVersion, err
 	}
-	return true
+	defer os.Remove(f.Name())
+	if _, err := f.Write(value); err != nil {
+		f.Close()
+		return NoVersion, err
+	}
+	if err := f.Close(); err != nil {
+		return NoVersion, err
+	}
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		return NoVersion, err
+	}
+	if err := os.Rename(f.Name(), key); err != nil {
+		return NoVersion, err
+	}
+	return version(value), nil
 }
 
-func (s *StorageFilesystem) Del(key string) bool {
+func (s *StorageFilesystem) Put(key string, value []byte) (Version, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.write(key, value)
+}
+
+func (s *StorageFilesystem) CompareAndSwap(key string, old Version, value []byte) (Version, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, cur, err := s.Get(key)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return NoVersion, err
+	}
+	if cur != old {
+		return NoVersion, ErrConflict
+	}
+	return s.write(key, value)
+}
+
+func (s *StorageFilesystem) Del(key string) error {
+	if err := s.check(key); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	err := os.Remove(key)
-	if err != nil {
-		return false
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
 	}
-	return true
+	return err
 }
 
+func (s *StorageFilesystem) Close() error {
+	return nil
+}
@@ -1,17 +1,33 @@
 package keyval
 
 import (
-	"net/http"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"strconv"
 	"strings"
-	"io/ioutil"
+	"sync"
 )
 
 const (
 	maxValueSize = 1024 * 1024 * 4
 )
 
-var defaultStorage = StorageFilesystem{}
+var (
+	defaultStorageOnce sync.Once
+	defaultStorage     Storage
+	defaultStorageErr  error
+)
+
+// DefaultStorage returns the storage served by ServeBasic, see
+// DefaultConfig. It is opened on first use.
+func DefaultStorage() (Storage, error) {
+	defaultStorageOnce.Do(func() {
+		defaultStorage, defaultStorageErr = Open(DefaultConfig())
+	})
+	return defaultStorage, defaultStorageErr
+}
 
 func ServeBasic(w http.ResponseWriter, r *http.Request) {
 	// /keyval/_?k=keyval://path/to/sth&sk=index/0000
@@ -27,19 +43,24 @@ func ServeBasic(w http.ResponseWriter, r *http.Request) {
 		http.NotFound(w, r)
 		return
 	}
+	storage, err := DefaultStorage()
+	if err != nil {
+		http.Error(w, "Internal Error", http.StatusInternalServerError)
+		return
+	}
 	subkey := qv.Get("sk")
 	switch r.Method {
 	case "GET":
-		getValue(key, subkey, w, r)
+		getValue(storage, key, subkey, w, r)
 		return
 	case "POST":
-		putKeyValue(key, subkey, w, r)
+		putKeyValue(storage, key, subkey, w, r)
 		return
 	case "PUT":
-		putKeyValue(key, subkey, w, r)
+		putKeyValue(storage, key, subkey, w, r)
 		return
 	case "DELETE":
-		delKey(key, subkey, w, r)
+		delKey(storage, key, subkey, w, r)
 		return
 	}
 	// PATCH, OPTION, ...
@@ -47,84 +68,128 @@ func ServeBasic(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprint(w, "")
 }
 
-func getValue(url string, subkey string, w http.ResponseWriter, r *http.Request) {
+// writeError writes the status for err, which is not nil.
+func writeError(err error, w http.ResponseWriter, r *http.Request) {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		http.NotFound(w, r)
+	case errors.Is(err, ErrConflict):
+		http.Error(w, "Precondition Failed", http.StatusPreconditionFailed)
+	default:
+		http.Error(w, "Internal Error", http.StatusInternalServerError)
+	}
+}
+
+func getValue(storage Storage, url string, subkey string, w http.ResponseWriter, r *http.Request) {
 	// e.g. ?k=keyval://test               --> /storage/keyval/test/_/_
 	//      ?k=keyval://test&sk=index/0000 --> /storage/keyval/test/_/index/0000
 	//      ?k=keyval://test&a=list        --> _
 	//                                         index/
+	//      ?k=keyval://test&a=list&after=_&n=10 --> index/
 	qv := r.URL.Query()
 	a := qv.Get("a")
-	key := defaultStorage.UrlToKey(url)
+	key := storage.UrlToKey(url)
 	switch a {
 	case "list":
 		if subkey != "" {
-			key = defaultStorage.WithSubkey(key, subkey)
+			key = storage.WithSubkey(key, subkey)
 		}
-		getKeySubkeyList(key, w, r)
+		n, _ := strconv.Atoi(qv.Get("n"))
+		getKeySubkeyList(storage, key, qv.Get("after"), n, w, r)
 	default:
 		if subkey == "" {
 			subkey = "_"
 		}
-		key = defaultStorage.WithSubkey(key, subkey)
-		getKeyValue(key, w, r)
+		key = storage.WithSubkey(key, subkey)
+		getKeyValue(storage, key, w, r)
 	}
 }
 
-func getKeyValue(key string, w http.ResponseWriter, r *http.Request) {
-	b, ok := defaultStorage.Get(key)
-	if !ok {
-		http.NotFound(w, r)
+func getKeyValue(storage Storage, key string, w http.ResponseWriter, r *http.Request) {
+	b, v, err := storage.Get(key)
+	if err != nil {
+		writeError(err, w, r)
 		return
 	}
 	w.Header().Add("Content-Type", "text/plain")
+	w.Header().Set("ETag", formatETag(v))
 	w.Write(b)
 }
 
-func getKeySubkeyList(key string, w http.ResponseWriter, r *http.Request) {
-	list := defaultStorage.ListSubkey(key)
+func getKeySubkeyList(storage Storage, key, after string, n int, w http.ResponseWriter, r *http.Request) {
+	list, err := storage.ListSubkey(key, after, n)
+	if err != nil {
+		writeError(err, w, r)
+		return
+	}
 	b := []byte(strings.Join(list, "\n"))
 	w.Header().Add("Content-Type", "text/plain")
 	w.Write(b)
 }
 
-func putKeyValue(url string, subkey string, w http.ResponseWriter, r *http.Request) {
-	key := defaultStorage.UrlToKey(url)
+func formatETag(v Version) string {
+	return fmt.Sprintf(`"%x"`, uint64(v))
+}
+
+func parseETag(s string) (Version, bool) {
+	s, ok := strings.CutPrefix(s, `"`)
+	if !ok {
+		return NoVersion, false
+	}
+	s, ok = strings.CutSuffix(s, `"`)
+	if !ok {
+		return NoVersion, false
+	}
+	v, err := strconv.ParseUint(s, 16, 64)
+	return Version(v), err == nil
+}
+
+func putKeyValue(storage Storage, url string, subkey string, w http.ResponseWriter, r *http.Request) {
+	// The value is only stored if the key still has the version of the
+	// ETag in If-Match, or doesn't exist yet with "If-None-Match: *".
+	key := storage.UrlToKey(url)
 	if subkey == "" {
 		subkey = "_"
 	}
-	key = defaultStorage.WithSubkey(key, subkey)
-	r.Body = http.MaxBytesReader(w, r.Body, maxValueSize)	
-	value, err := ioutil.ReadAll(r.Body)
+	key = storage.WithSubkey(key, subkey)
+	r.Body = http.MaxBytesReader(w, r.Body, maxValueSize)
+	value, err := io.ReadAll(r.Body)
 	if err != nil {
 		http.Error(w, "Bad Request", http.StatusBadRequest)
 		return
 	}
-	ok := defaultStorage.Put(key, value)
-	if !ok {
-		http.Error(w, "Bad Request", http.StatusBadRequest)
+	var v Version
+	if m := r.Header.Get("If-Match"); m != "" {
+		old, ok := parseETag(m)
+		if !ok {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		v, err = storage.CompareAndSwap(key, old, value)
+	} else if r.Header.Get("If-None-Match") == "*" {
+		v, err = storage.CompareAndSwap(key, NoVersion, value)
+	} else {
+		v, err = storage.Put(key, value)
+	}
+	if err != nil {
+		writeError(err, w, r)
 		return
 	}
 	w.Header().Add("Content-Type", "text/plain")
+	w.Header().Set("ETag", formatETag(v))
 	w.Write([]byte(fmt.Sprintf("%s#%s", url, subkey)))
 }
 
-func delKey(url string, subkey string, w http.ResponseWriter, r *http.Request) {
-	key := defaultStorage.UrlToKey(url)
+func delKey(storage Storage, url string, subkey string, w http.ResponseWriter, r *http.Request) {
+	key := storage.UrlToKey(url)
 	if subkey == "" {
 		subkey = "_"
 	}
-	key = defaultStorage.WithSubkey(key, subkey)
-	_, ok := defaultStorage.Get(key)
-	if !ok {
-		http.NotFound(w, r)
-		return
-	}
-	ok = defaultStorage.Del(key)
-	if !ok {
-		http.Error(w, "Internal Error", http.StatusInternalServerError)
+	key = storage.WithSubkey(key, subkey)
+	if err := storage.Del(key); err != nil {
+		writeError(err, w, r)
 		return
 	}
 	w.Header().Add("Content-Type", "text/plain")
 	w.Write([]byte(fmt.Sprintf("%s#%s", url, subkey)))
 }
-
@@ -0,0 +1,341 @@
+package keyval
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/zoekt"
+	"github.com/sourcegraph/zoekt/index"
+)
+
+const (
+	// indexRepo is the repository name of the shards of StorageIndexed.
+	indexRepo = "keyval"
+
+	// dirtyFile marks an index which may miss writes, because they were not
+	// flushed before the process stopped.
+	dirtyFile = "dirty"
+
+	// flushDelay is how long writes wait for more writes before they are
+	// flushed into a delta shard.
+	flushDelay = time.Second
+
+	// maxPending is the number of written keys at which they are flushed
+	// without waiting for flushDelay.
+	maxPending = 256
+)
+
+// deltaCompaction is when the delta shards of StorageIndexed are folded
+// back into a single shard.
+var deltaCompaction = index.CompactionThresholds{
+	MaxDeltaShards: 64,
+	MaxDeltaBytes:  64 << 20,
+}
+
+// IndexDir returns the directory of the search index of the storage in
+// baseDir, see StorageIndexed.
+func IndexDir(baseDir string) string {
+	// XXX: pretent as a p4 repo? easy to ignore .p4 folder
+	return filepath.Join(baseDir, ".p4", ".zoekt", "index")
+}
+
+// indexable is implemented by the storages whose values StorageIndexed can
+// index.
+type indexable interface {
+	Storage
+
+	// root returns the prefix of all keys, to Scan all of them.
+	root() string
+
+	// name returns the document name of key: its escaped URL parts and
+	// subkey, joined with "/".
+	name(key string) string
+}
+
+func (s *StorageFilesystem) root() string {
+	return s.baseDir
+}
+
+func (s *StorageFilesystem) name(key string) string {
+	return filepath.ToSlash(strings.TrimPrefix(key, s.baseDir))
+}
+
+func (s *StorageBolt) root() string {
+	return ""
+}
+
+func (s *StorageBolt) name(key string) string {
+	return key
+}
+
+// StorageIndexed keeps a zoekt index of the values of a storage, so they can
+// be searched with contrib.Search. Each document is named after its key, see
+// IndexDir.
+//
+// Writes are batched: the written keys are indexed into a delta shard, which
+// tombstones their documents in the older shards, once no more writes came
+// for a second, once many keys were written, or on Flush. Searches which
+// Flush first see every value which was written before.
+//
+// The index is rebuilt from the storage when the storage is opened if it
+// doesn't exist, or if writes may not have been flushed. Values written by
+// others than the StorageIndexed are only indexed then.
+type StorageIndexed struct {
+	Storage
+	dir string
+
+	mu      sync.Mutex
+	pending map[string]struct{}
+	timer   *time.Timer
+	closed  bool
+
+	// flushMu serializes builds, so that the shards of one flush are
+	// finished before the next one builds on them.
+	flushMu sync.Mutex
+}
+
+// NewStorageIndexed returns s with a search index in dir. It builds the
+// index if needed.
+func NewStorageIndexed(s Storage, dir string) (*StorageIndexed, error) {
+	if _, ok := s.(indexable); !ok {
+		return nil, fmt.Errorf("keyval: cannot index a %T", s)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	si := &StorageIndexed{
+		Storage: s,
+		dir:     dir,
+		pending: map[string]struct{}{},
+	}
+	opts := si.options()
+	_, err := os.Stat(filepath.Join(dir, dirtyFile))
+	if errors.Is(err, os.ErrNotExist) && len(opts.FindAllShards()) > 0 {
+		return si, nil
+	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err := si.rebuild(); err != nil {
+		return nil, fmt.Errorf("keyval: indexing %s: %w", dir, err)
+	}
+	return si, nil
+}
+
+// IndexDir returns the directory of the index.
+func (s *StorageIndexed) IndexDir() string {
+	return s.dir
+}
+
+func (s *StorageIndexed) options() index.Options {
+	opts := index.Options{
+		IndexDir:     s.dir,
+		DisableCTags: true,
+		RepositoryDescription: zoekt.Repository{
+			Name:     indexRepo,
+			Branches: []zoekt.RepositoryBranch{{Name: "HEAD"}},
+		},
+	}
+	opts.SetDefaults()
+	return opts
+}
+
+// rebuild replaces the index with a new one of all values.
+func (s *StorageIndexed) rebuild() error {
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+	if err := s.markDirty(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.pending = map[string]struct{}{}
+	s.mu.Unlock()
+
+	is := s.Storage.(indexable)
+	b, err := index.NewBuilder(s.options())
+	if err != nil {
+		return err
+	}
+	defer b.Finish()
+	keys, err := is.Scan(is.root(), "", 0)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		name := is.name(key)
+		// Keys start with the URL scheme, which can't start with a dot,
+		// so this skips the index itself.
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		value, _, err := is.Get(key)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if err := b.Add(index.Document{Name: name, Content: value}); err != nil {
+			return err
+		}
+	}
+	if err := b.Finish(); err != nil {
+		return err
+	}
+	return s.markClean()
+}
+
+func (s *StorageIndexed) markDirty() error {
+	f, err := os.Create(filepath.Join(s.dir, dirtyFile))
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// markClean removes the dirty marker if no writes are pending. It holds
+// s.mu until the marker is gone, so that it can't remove the marker of a
+// write which comes in meanwhile.
+func (s *StorageIndexed) markClean() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.pending) > 0 {
+		return nil
+	}
+	err := os.Remove(filepath.Join(s.dir, dirtyFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// written records that key was written. The first key of a batch marks the
+// index dirty, and fails the write if it can't.
+func (s *StorageIndexed) written(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.pending) == 0 {
+		if err := s.markDirty(); err != nil {
+			return err
+		}
+	}
+	s.pending[key] = struct{}{}
+	switch {
+	case s.closed:
+	case len(s.pending) == maxPending:
+		go s.flushLogged()
+	case s.timer == nil:
+		s.timer = time.AfterFunc(flushDelay, s.flushLogged)
+	}
+	return nil
+}
+
+func (s *StorageIndexed) flushLogged() {
+	if err := s.Flush(); err != nil {
+		log.Printf("[E] [Storage.Indexed] flushing %s: %v", s.dir, err)
+	}
+}
+
+// Flush indexes the keys written so far.
+func (s *StorageIndexed) Flush() error {
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+
+	s.mu.Lock()
+	keys := s.pending
+	s.pending = map[string]struct{}{}
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	s.mu.Unlock()
+	if len(keys) == 0 {
+		return nil
+	}
+
+	if err := s.build(keys); err != nil {
+		// Try again with the next flush.
+		s.mu.Lock()
+		for key := range keys {
+			s.pending[key] = struct{}{}
+		}
+		s.mu.Unlock()
+		return err
+	}
+
+	opts := s.options()
+	if ok, err := opts.NeedsCompaction(deltaCompaction); err != nil {
+		return err
+	} else if ok {
+		if err := index.CompactDeltaShards(opts); err != nil {
+			return err
+		}
+	}
+	return s.markClean()
+}
+
+// build writes a delta shard with the current values of keys, and
+// tombstones their documents in the older shards. s.flushMu must be held.
+func (s *StorageIndexed) build(keys map[string]struct{}) error {
+	is := s.Storage.(indexable)
+	opts := s.options()
+	opts.IsDelta = true
+	b, err := index.NewBuilder(opts)
+	if err != nil {
+		return err
+	}
+	defer b.Finish()
+	for key := range keys {
+		name := is.name(key)
+		b.MarkFileAsChangedOrRemoved(name)
+		value, _, err := is.Get(key)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if err := b.Add(index.Document{Name: name, Content: value}); err != nil {
+			return err
+		}
+	}
+	return b.Finish()
+}
+
+func (s *StorageIndexed) Put(key string, value []byte) (Version, error) {
+	v, err := s.Storage.Put(key, value)
+	if err != nil {
+		return v, err
+	}
+	return v, s.written(key)
+}
+
+func (s *StorageIndexed) CompareAndSwap(key string, old Version, value []byte) (Version, error) {
+	v, err := s.Storage.CompareAndSwap(key, old, value)
+	if err != nil {
+		return v, err
+	}
+	return v, s.written(key)
+}
+
+func (s *StorageIndexed) Del(key string) error {
+	if err := s.Storage.Del(key); err != nil {
+		return err
+	}
+	return s.written(key)
+}
+
+// Close flushes the pending writes and closes the storage.
+func (s *StorageIndexed) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	err := s.Flush()
+	if cerr := s.Storage.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,133 @@
+package keyval
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/zoekt"
+	"github.com/sourcegraph/zoekt/index"
+	"github.com/sourcegraph/zoekt/query"
+	"github.com/sourcegraph/zoekt/search"
+)
+
+// searchIndex returns the names of the documents in dir which match q.
+func searchIndex(t *testing.T, dir, q string) []string {
+	t.Helper()
+	searcher, err := search.NewDirectorySearcher(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer searcher.Close()
+	parsed, err := query.Parse(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := searcher.Search(context.Background(), parsed, &zoekt.SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := []string{}
+	for _, f := range res.Files {
+		names = append(names, f.FileName)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestStorageIndexed(t *testing.T) {
+	old := deltaCompaction
+	deltaCompaction = index.CompactionThresholds{MaxDeltaShards: 3}
+	defer func() { deltaCompaction = old }()
+
+	bolt, err := OpenStorageBolt(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bolt.Close()
+	dir := filepath.Join(t.TempDir(), "index")
+	s, err := NewStorageIndexed(bolt, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := s.options()
+	a, b, c := s.UrlToKey("test://example.com/a"), s.UrlToKey("test://example.com/b"), s.UrlToKey("test://example.com/c")
+
+	put := func(key, value string) {
+		t.Helper()
+		if _, err := s.Put(key, []byte(value)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	check := func(q string, want ...string) {
+		t.Helper()
+		if want == nil {
+			want = []string{}
+		}
+		if d := cmp.Diff(want, searchIndex(t, dir, q)); d != "" {
+			t.Errorf("search %q (-want +got):\n%s", q, d)
+		}
+	}
+
+	// Writes are found after Flush.
+	put(a, "alpha needle")
+	put(b, "beta needle")
+	if err := s.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	check("needle", a, b)
+	if _, err := os.Stat(filepath.Join(dir, dirtyFile)); !os.IsNotExist(err) {
+		t.Errorf("index still dirty after Flush: %v", err)
+	}
+
+	// Overwritten and deleted keys are tombstoned in the older shards.
+	put(a, "gamma")
+	if err := s.Del(b); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	check("needle")
+	check("alpha")
+	check("gamma", a)
+	if shards := opts.FindAllShards(); len(shards) != 3 {
+		t.Errorf("got shards %v, want an empty one and 2 delta shards", shards)
+	}
+
+	// The third delta shard compacts the index.
+	put(c, "delta needle")
+	if err := s.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	check("needle", c)
+	if shards := opts.FindAllShards(); len(shards) != 1 {
+		t.Errorf("got shards %v after compaction, want 1", shards)
+	} else if repos, _, err := index.ReadMetadataPath(shards[0]); err != nil || len(repos[0].FileTombstones) != 0 {
+		t.Errorf("compacted shard has tombstones: %v, %v", repos, err)
+	}
+
+	// Writes which were not flushed when the process stopped mark the index
+	// dirty, so that the next open rebuilds it.
+	put(b, "epsilon needle")
+	s.mu.Lock()
+	s.timer.Stop()
+	s.closed = true
+	s.mu.Unlock()
+	if _, err := os.Stat(filepath.Join(dir, dirtyFile)); err != nil {
+		t.Fatalf("index not dirty after a write: %v", err)
+	}
+	check("epsilon")
+	if _, err := NewStorageIndexed(bolt, dir); err != nil {
+		t.Fatal(err)
+	}
+	check("epsilon", b)
+	check("needle", b, c)
+	if _, err := os.Stat(filepath.Join(dir, dirtyFile)); !os.IsNotExist(err) {
+		t.Errorf("index still dirty after the rebuild: %v", err)
+	}
+}
@@ -1,11 +1,126 @@
 package keyval
 
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNotFound is returned for keys which don't exist.
+	ErrNotFound = errors.New("keyval: key not found")
+
+	// ErrConflict is returned by CompareAndSwap if the key has another
+	// version than expected.
+	ErrConflict = errors.New("keyval: version conflict")
+)
+
+// Version identifies a value stored at a key. Versions are opaque: they only
+// tell whether a key changed, not how often or in which order.
+type Version uint64
+
+// NoVersion is the version of a key which doesn't exist.
+const NoVersion Version = 0
+
+// Storage is a key-value store. Keys are built from URLs with UrlToKey and
+// WithSubkey, and their format depends on the storage.
 type Storage interface {
 	UrlToKey(url string) string
 	KeyToUrl(key string) string
 	WithSubkey(key string, subkey string) string
-	ListSubkey(key string) []string
-	Get(key string) ([]byte, bool)
-	Put(key string, value []byte) bool
-	Del(key string) bool
+
+	// ListSubkey returns the names of the subkeys one level below key in
+	// order, those with subkeys of their own with a trailing separator. It
+	// starts after the name after and returns at most limit names, or
+	// MAX_SUBKEY_DISPLAY_NUMBER if limit is 0.
+	ListSubkey(key string, after string, limit int) ([]string, error)
+
+	// Scan returns the keys which start with prefix in order. It starts
+	// after the key after and returns at most limit keys, or all of them if
+	// limit is 0.
+	Scan(prefix string, after string, limit int) ([]string, error)
+
+	// Get returns the value of key and its version.
+	Get(key string) ([]byte, Version, error)
+
+	// Put stores value at key and returns its new version.
+	Put(key string, value []byte) (Version, error)
+
+	// CompareAndSwap stores value at key if key has version old, and returns
+	// ErrConflict otherwise. With NoVersion it only creates key.
+	CompareAndSwap(key string, old Version, value []byte) (Version, error)
+
+	// Del deletes key.
+	Del(key string) error
+
+	Close() error
+}
+
+// Update replaces the value of key with the result of fn, which gets the
+// current value and whether key exists. If another writer changes key in the
+// meantime, fn is called again with the new value, so fn must not have side
+// effects.
+func Update(s Storage, key string, fn func(value []byte, ok bool) ([]byte, error)) (Version, error) {
+	for {
+		value, v, err := s.Get(key)
+		ok := err == nil
+		if errors.Is(err, ErrNotFound) {
+			value, v = nil, NoVersion
+		} else if err != nil {
+			return NoVersion, err
+		}
+
+		if value, err = fn(value, ok); err != nil {
+			return NoVersion, err
+		}
+
+		v, err = s.CompareAndSwap(key, v, value)
+		if !errors.Is(err, ErrConflict) {
+			return v, err
+		}
+	}
+}
+
+const (
+	// BackendFilesystem stores each value in a file, see StorageFilesystem.
+	BackendFilesystem = "filesystem"
+
+	// BackendBolt stores all values in a bbolt database, see StorageBolt.
+	BackendBolt = "bbolt"
+)
+
+// Config selects and configures a storage, see Open.
+type Config struct {
+	// Backend is BackendFilesystem or BackendBolt. It defaults to
+	// BackendFilesystem.
+	Backend string
+
+	// BaseDir is the directory holding the storage.
+	BaseDir string
+
+	// Index keeps a search index of the values in IndexDir(BaseDir), see
+	// StorageIndexed.
+	Index bool
+}
+
+// Open opens the storage configured by c.
+func Open(c Config) (Storage, error) {
+	var s Storage
+	var err error
+	switch c.Backend {
+	case "", BackendFilesystem:
+		s, err = NewStorageFilesystem(c.BaseDir)
+	case BackendBolt:
+		s, err = OpenStorageBolt(c.BaseDir)
+	default:
+		return nil, fmt.Errorf("keyval: unknown backend %q", c.Backend)
+	}
+	if err != nil || !c.Index {
+		return s, err
+	}
+	si, err := NewStorageIndexed(s, IndexDir(c.BaseDir))
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+	return si, nil
 }
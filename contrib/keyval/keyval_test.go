@@ -0,0 +1,234 @@
+package keyval
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// forEachBackend runs test with a new storage of every backend.
+func forEachBackend(t *testing.T, test func(t *testing.T, s Storage)) {
+	for _, backend := range []string{BackendFilesystem, BackendBolt} {
+		t.Run(backend, func(t *testing.T) {
+			s, err := Open(Config{Backend: backend, BaseDir: t.TempDir()})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Close()
+			test(t, s)
+		})
+	}
+}
+
+func TestVersions(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s Storage) {
+		key := s.UrlToKey("test://example.com/versions")
+		if _, _, err := s.Get(key); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("Get of a missing key: got %v, want ErrNotFound", err)
+		}
+		if err := s.Del(key); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("Del of a missing key: got %v, want ErrNotFound", err)
+		}
+
+		v1, err := s.Put(key, []byte("one"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v1 == NoVersion {
+			t.Fatal("Put returned NoVersion")
+		}
+		value, v, err := s.Get(key)
+		if err != nil || string(value) != "one" || v != v1 {
+			t.Fatalf("Get = %q, %d, %v, want one, %d", value, v, err, v1)
+		}
+		v2, err := s.Put(key, []byte("two"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v2 == v1 {
+			t.Fatalf("the version %d did not change with the value", v2)
+		}
+
+		for _, tc := range []struct {
+			old     Version
+			value   string
+			wantErr error
+		}{
+			{NoVersion, "create", ErrConflict},
+			{v1, "stale", ErrConflict},
+			{v2, "three", nil},
+			{v2, "again", ErrConflict},
+		} {
+			if _, err := s.CompareAndSwap(key, tc.old, []byte(tc.value)); !errors.Is(err, tc.wantErr) {
+				t.Errorf("CompareAndSwap(%d, %s): got %v, want %v", tc.old, tc.value, err, tc.wantErr)
+			}
+		}
+		if value, _, _ := s.Get(key); string(value) != "three" {
+			t.Errorf("got %q after CompareAndSwap, want three", value)
+		}
+
+		created := s.UrlToKey("test://example.com/created")
+		if _, err := s.CompareAndSwap(created, NoVersion, []byte("new")); err != nil {
+			t.Errorf("CompareAndSwap with NoVersion of a missing key: %v", err)
+		}
+
+		if err := s.Del(key); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := s.Get(key); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Get after Del: got %v, want ErrNotFound", err)
+		}
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s Storage) {
+		key := s.UrlToKey("test://example.com/counter")
+
+		// fn starts over with the new value if another writer changed it.
+		calls := 0
+		_, err := Update(s, key, func(value []byte, ok bool) ([]byte, error) {
+			calls++
+			if calls == 1 {
+				if ok {
+					t.Errorf("first call got %q for a missing key", value)
+				}
+				if _, err := s.Put(key, []byte("other")); err != nil {
+					t.Fatal(err)
+				}
+			}
+			return append(value, '+'), nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value, _, _ := s.Get(key); calls != 2 || string(value) != "other+" {
+			t.Errorf("got %q after %d calls, want other+ after 2", value, calls)
+		}
+
+		// Errors of fn stop the update.
+		errStop := errors.New("stop")
+		if _, err := Update(s, key, func([]byte, bool) ([]byte, error) { return nil, errStop }); !errors.Is(err, errStop) {
+			t.Errorf("got %v, want the error of fn", err)
+		}
+
+		// Concurrent updates don't get lost.
+		if _, err := s.Put(key, nil); err != nil {
+			t.Fatal(err)
+		}
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 5 {
+					if _, err := Update(s, key, func(value []byte, ok bool) ([]byte, error) {
+						return append(value, '+'), nil
+					}); err != nil {
+						t.Error(err)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		if value, _, _ := s.Get(key); len(value) != 40 {
+			t.Errorf("got %d updates, want 40", len(value))
+		}
+	})
+}
+
+func TestScanAndListSubkey(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s Storage) {
+		base := s.UrlToKey("test://example.com/list")
+		for _, sub := range []string{"a", "b/1", "b/2", "b/c/3", "c", "d"} {
+			if _, err := s.Put(s.WithSubkey(base, sub), []byte(sub)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		// A key next to base, which starts with the same name.
+		if _, err := s.Put(base+"x", []byte("x")); err != nil {
+			t.Fatal(err)
+		}
+		sub := func(names ...string) []string {
+			keys := make([]string, 0, len(names))
+			for _, name := range names {
+				keys = append(keys, s.WithSubkey(base, name))
+			}
+			return keys
+		}
+
+		for _, tc := range []struct {
+			after string
+			limit int
+			want  []string
+		}{
+			{"", 0, []string{"a", "b/", "c", "d"}},
+			{"", 2, []string{"a", "b/"}},
+			// The keys below b/ are skipped as a whole.
+			{"b/", 1, []string{"c"}},
+			{"a", 0, []string{"b/", "c", "d"}},
+			{"bb", 0, []string{"c", "d"}},
+			{"d", 0, []string{}},
+		} {
+			got, err := s.ListSubkey(base, tc.after, tc.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("ListSubkey(after %q, limit %d) (-want +got):\n%s", tc.after, tc.limit, d)
+			}
+		}
+		if got, err := s.ListSubkey(s.WithSubkey(base, "missing"), "", 0); err != nil || len(got) != 0 {
+			t.Errorf("ListSubkey of a missing key = %v, %v", got, err)
+		}
+
+		prefix := s.WithSubkey(base, "b") + "/"
+		for _, tc := range []struct {
+			after string
+			limit int
+			want  []string
+		}{
+			{"", 0, sub("b/1", "b/2", "b/c/3")},
+			{"", 2, sub("b/1", "b/2")},
+			{s.WithSubkey(base, "b/2"), 0, sub("b/c/3")},
+			{s.WithSubkey(base, "b/c/3"), 0, []string{}},
+		} {
+			got, err := s.Scan(prefix, tc.after, tc.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Scan(after %q, limit %d) (-want +got):\n%s", tc.after, tc.limit, d)
+			}
+		}
+
+		// Paging through a scan visits every key once.
+		var all []string
+		after := ""
+		for {
+			page, err := s.Scan(base+"/", after, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(page) == 0 {
+				break
+			}
+			all = append(all, page...)
+			after = page[len(page)-1]
+		}
+		if d := cmp.Diff(sub("a", "b/1", "b/2", "b/c/3", "c", "d"), all); d != "" {
+			t.Errorf("paged Scan (-want +got):\n%s", d)
+		}
+	})
+}
+
+func TestOpenUnknownBackend(t *testing.T) {
+	if _, err := Open(Config{Backend: "unknown", BaseDir: t.TempDir()}); err == nil {
+		t.Error("Open of an unknown backend succeeded")
+	}
+	if _, err := Open(Config{Backend: BackendBolt}); err == nil {
+		t.Errorf("Open of %s without a base directory succeeded", BackendBolt)
+	}
+}
@@ -0,0 +1,632 @@
+// Package savedsearch stores named queries in a keyval.Storage and re-runs
+// them whenever new shards are loaded. Each run is compared with the result
+// set of the previous run, and the difference is kept as the changes of the
+// saved search and optionally posted to a webhook.
+//
+// With authentication, each user has their own saved searches, which run
+// with the permissions of the user.
+package savedsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/sourcegraph/zoekt"
+	"github.com/sourcegraph/zoekt/contrib/keyval"
+	"github.com/sourcegraph/zoekt/internal/auth"
+	"github.com/sourcegraph/zoekt/query"
+)
+
+// maxResults caps the number of files remembered per saved search.
+const maxResults = 10000
+
+// ErrNotFound is returned for saved searches which do not exist.
+var ErrNotFound = errors.New("saved search not found")
+
+var nameMatcher = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,127}$`)
+
+// Search is a named query.
+type Search struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+	// Owner is the user who saved the search, empty without
+	// authentication.
+	Owner string `json:"owner,omitempty"`
+	// Webhook, if set, receives the Changes of each run as a JSON POST
+	// request.
+	Webhook string `json:"webhook,omitempty"`
+}
+
+// Validate checks the name, query and webhook of s.
+func (s *Search) Validate() error {
+	if !nameMatcher.MatchString(s.Name) {
+		return fmt.Errorf("invalid name %q", s.Name)
+	}
+	if _, err := query.Parse(s.Query); err != nil {
+		return fmt.Errorf("invalid query: %w", err)
+	}
+	if s.Webhook != "" {
+		u, err := url.Parse(s.Webhook)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid webhook %q", s.Webhook)
+		}
+	}
+	return nil
+}
+
+// Hit is a file matching a saved search.
+type Hit struct {
+	Repository string `json:"repo"`
+	FileName   string `json:"file"`
+	// Checksum is the hex encoded checksum of the file content.
+	Checksum string `json:"checksum"`
+}
+
+// Changes is the difference between two runs of a saved search.
+type Changes struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+	// Time is when the changes were found; zero if there were none yet.
+	Time     time.Time `json:"time"`
+	Added    []Hit     `json:"added"`
+	Removed  []Hit     `json:"removed"`
+	Modified []Hit     `json:"modified"`
+}
+
+func (c *Changes) empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Modified) == 0
+}
+
+// diff compares the hits of two runs. Files are identified by repository
+// and name; a file whose checksum differs is modified.
+func diff(prev, cur []Hit) (added, removed, modified []Hit) {
+	type key struct{ repo, file string }
+	old := make(map[key]Hit, len(prev))
+	for _, h := range prev {
+		old[key{h.Repository, h.FileName}] = h
+	}
+	for _, h := range cur {
+		k := key{h.Repository, h.FileName}
+		p, ok := old[k]
+		delete(old, k)
+		switch {
+		case !ok:
+			added = append(added, h)
+		case p.Checksum != h.Checksum:
+			modified = append(modified, h)
+		}
+	}
+	for _, h := range old {
+		removed = append(removed, h)
+	}
+	sortHits(removed)
+	return added, removed, modified
+}
+
+func sortHits(hits []Hit) {
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Repository != hits[j].Repository {
+			return hits[i].Repository < hits[j].Repository
+		}
+		return hits[i].FileName < hits[j].FileName
+	})
+}
+
+// Manager stores saved searches and runs them.
+type Manager struct {
+	storage keyval.Storage
+	// key holds one subkey directory per saved search without owner, and
+	// one directory "~<owner>" per owner with theirs.
+	key    string
+	client *http.Client
+
+	// owners, if set, are the users saved searches run as.
+	owners Owners
+
+	// webhookHosts are the hosts webhooks may go to. If empty, they may go
+	// to any host with a public address.
+	webhookHosts []string
+
+	// mu serializes reads and writes of stored searches.
+	mu sync.Mutex
+
+	pendingMu sync.Mutex
+	// pending are the searches to run next; all means every saved search.
+	pending map[ref]bool
+	all     bool
+	wake    chan struct{}
+}
+
+// ref identifies a saved search.
+type ref struct {
+	owner, name string
+}
+
+// Owners looks up the users who own saved searches, see auth.Users.
+type Owners interface {
+	Principal(name string) (*auth.Principal, bool)
+}
+
+// Option configures a Manager.
+type Option func(*Manager)
+
+// WithWebhookHosts only allows webhooks to hosts, which may have private
+// addresses. A host "*.example.com" allows all subdomains of example.com.
+func WithWebhookHosts(hosts ...string) Option {
+	return func(m *Manager) {
+		m.webhookHosts = append(m.webhookHosts, hosts...)
+	}
+}
+
+// WithOwners runs each saved search as its owner, so that it only finds
+// what the owner may see. Searches of unknown owners and searches without
+// owner don't run. The searcher passed to Run must authorize requests, see
+// auth.NewSearcher.
+func WithOwners(owners Owners) Option {
+	return func(m *Manager) {
+		m.owners = owners
+	}
+}
+
+// NewManager returns a manager which keeps saved searches in storage.
+//
+// Since anyone who may save a search chooses its webhook, webhooks may only
+// go to public addresses, unless WithWebhookHosts lists the allowed hosts.
+func NewManager(storage keyval.Storage, opts ...Option) *Manager {
+	m := &Manager{
+		storage: storage,
+		key:     storage.UrlToKey("savedsearch://searches"),
+		pending: make(map[ref]bool),
+		wake:    make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	if len(m.webhookHosts) == 0 {
+		// Checked when connecting, so that host names can't resolve to
+		// internal addresses.
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !publicIP(ip) {
+				return fmt.Errorf("webhook address %s is not public", host)
+			}
+			return nil
+		}
+	}
+	m.client = &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: &http.Transport{DialContext: dialer.DialContext},
+		// A redirect could lead anywhere.
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return m
+}
+
+// publicIP reports whether ip is a public unicast address.
+func publicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || !ip.IsGlobalUnicast() {
+		return false
+	}
+	// Carrier-grade NAT, 100.64.0.0/10.
+	if ip4 := ip.To4(); ip4 != nil && ip4[0] == 100 && ip4[1]&0xc0 == 64 {
+		return false
+	}
+	return true
+}
+
+// CheckWebhook returns an error if saved searches may not post to webhook.
+// Host names are only resolved when posting.
+func (m *Manager) CheckWebhook(webhook string) error {
+	if webhook == "" {
+		return nil
+	}
+	u, err := url.Parse(webhook)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid webhook %q", webhook)
+	}
+	host := strings.ToLower(u.Hostname())
+	if len(m.webhookHosts) == 0 {
+		if ip := net.ParseIP(host); ip != nil && !publicIP(ip) {
+			return fmt.Errorf("webhook host %s is not public", host)
+		}
+		return nil
+	}
+	for _, allowed := range m.webhookHosts {
+		allowed = strings.ToLower(allowed)
+		if host == allowed {
+			return nil
+		}
+		if domain, ok := strings.CutPrefix(allowed, "*"); ok && strings.HasSuffix(host, domain) {
+			return nil
+		}
+	}
+	return fmt.Errorf("webhook host %s is not allowed", host)
+}
+
+// ownerPrefix starts the directories of the searches of an owner. Names of
+// saved searches can't start with it.
+const ownerPrefix = "~"
+
+// dir returns the key of the directory of the saved searches of owner.
+func (m *Manager) dir(owner string) string {
+	if owner == "" {
+		return m.key
+	}
+	return m.storage.WithSubkey(m.key, ownerPrefix+url.PathEscape(owner))
+}
+
+func (m *Manager) subkey(r ref, item string) string {
+	return m.storage.WithSubkey(m.dir(r.owner), path.Join(r.name, item))
+}
+
+func (m *Manager) getJSON(r ref, item string, v any) (bool, error) {
+	b, _, err := m.storage.Get(m.subkey(r, item))
+	if errors.Is(err, keyval.ErrNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, json.Unmarshal(b, v)
+}
+
+func (m *Manager) putJSON(r ref, item string, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if _, err := m.storage.Put(m.subkey(r, item), b); err != nil {
+		return fmt.Errorf("cannot store %s of saved search %s: %w", item, r.name, err)
+	}
+	return nil
+}
+
+// del deletes the item of the saved search r, if it exists.
+func (m *Manager) del(r ref, item string) error {
+	err := m.storage.Del(m.subkey(r, item))
+	if errors.Is(err, keyval.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// subkeys returns the names of the subkeys of key, one page at a time.
+func (m *Manager) subkeys(key string) ([]string, error) {
+	var names []string
+	after := ""
+	for {
+		page, err := m.storage.ListSubkey(key, after, 0)
+		if err != nil {
+			return nil, err
+		}
+		if len(page) == 0 {
+			return names, nil
+		}
+		names = append(names, page...)
+		after = page[len(page)-1]
+	}
+}
+
+// List returns the saved searches of owner ordered by name.
+func (m *Manager) List(owner string) ([]*Search, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.list(owner)
+}
+
+func (m *Manager) list(owner string) ([]*Search, error) {
+	searches := make([]*Search, 0)
+	subs, err := m.subkeys(m.dir(owner))
+	if err != nil {
+		return nil, err
+	}
+	for _, sub := range subs {
+		name := strings.TrimRight(sub, `/\`)
+		if name == sub || !nameMatcher.MatchString(name) {
+			continue
+		}
+		s, err := m.get(ref{owner, name})
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		searches = append(searches, s)
+	}
+	sort.Slice(searches, func(i, j int) bool { return searches[i].Name < searches[j].Name })
+	return searches, nil
+}
+
+// listAll returns the saved searches of all owners.
+func (m *Manager) listAll() ([]*Search, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	searches, err := m.list("")
+	if err != nil {
+		return nil, err
+	}
+	subs, err := m.subkeys(m.key)
+	if err != nil {
+		return nil, err
+	}
+	for _, sub := range subs {
+		escaped, ok := strings.CutPrefix(strings.TrimRight(sub, `/\`), ownerPrefix)
+		if !ok {
+			continue
+		}
+		owner, err := url.PathUnescape(escaped)
+		if err != nil || owner == "" {
+			continue
+		}
+		owned, err := m.list(owner)
+		if err != nil {
+			return nil, err
+		}
+		searches = append(searches, owned...)
+	}
+	return searches, nil
+}
+
+// Get returns the saved search of owner called name.
+func (m *Manager) Get(owner, name string) (*Search, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.get(ref{owner, name})
+}
+
+func (m *Manager) get(r ref) (*Search, error) {
+	if !nameMatcher.MatchString(r.name) {
+		return nil, ErrNotFound
+	}
+	s := &Search{}
+	ok, err := m.getJSON(r, "search", s)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrNotFound
+	}
+	// The directory decides the owner.
+	s.Owner = r.owner
+	return s, nil
+}
+
+// Save stores s, replacing a saved search of the same owner and name, and
+// schedules it to run. Results of a previous search of that name are kept if
+// the query is unchanged.
+func (m *Manager) Save(s *Search) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	if err := m.CheckWebhook(s.Webhook); err != nil {
+		return err
+	}
+	r := ref{s.Owner, s.Name}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if prev, err := m.get(r); err == nil && prev.Query != s.Query {
+		if err := m.del(r, "results"); err != nil {
+			return err
+		}
+		if err := m.del(r, "changes"); err != nil {
+			return err
+		}
+	}
+	if err := m.putJSON(r, "search", s); err != nil {
+		return err
+	}
+	m.schedule(r)
+	return nil
+}
+
+// Delete removes the saved search of owner called name with its results.
+func (m *Manager) Delete(owner, name string) error {
+	r := ref{owner, name}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, err := m.get(r); err != nil {
+		return err
+	}
+	for _, item := range []string{"results", "changes", "search"} {
+		if err := m.del(r, item); err != nil {
+			return fmt.Errorf("cannot delete saved search %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Changes returns the latest non-empty changes of the saved search of owner
+// called name.
+func (m *Manager) Changes(owner, name string) (*Changes, error) {
+	r := ref{owner, name}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, err := m.get(r)
+	if err != nil {
+		return nil, err
+	}
+	c := &Changes{Name: s.Name, Query: s.Query}
+	if _, err := m.getJSON(r, "changes", c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// ShardsLoaded schedules all saved searches to run. It does not block, so
+// it can be passed to search.WithLoadHook.
+func (m *Manager) ShardsLoaded(shards []string) {
+	m.pendingMu.Lock()
+	m.all = true
+	m.pendingMu.Unlock()
+	m.notify()
+}
+
+func (m *Manager) schedule(r ref) {
+	m.pendingMu.Lock()
+	m.pending[r] = true
+	m.pendingMu.Unlock()
+	m.notify()
+}
+
+func (m *Manager) notify() {
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run runs scheduled saved searches against searcher until ctx is done.
+func (m *Manager) Run(ctx context.Context, searcher zoekt.Searcher) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.wake:
+		}
+
+		m.pendingMu.Lock()
+		all, pending := m.all, m.pending
+		m.all, m.pending = false, make(map[ref]bool)
+		m.pendingMu.Unlock()
+
+		var refs []ref
+		if all {
+			searches, err := m.listAll()
+			if err != nil {
+				log.Printf("savedsearch: listing: %v", err)
+				continue
+			}
+			for _, s := range searches {
+				refs = append(refs, ref{s.Owner, s.Name})
+			}
+		} else {
+			for r := range pending {
+				refs = append(refs, r)
+			}
+			sort.Slice(refs, func(i, j int) bool {
+				if refs[i].owner != refs[j].owner {
+					return refs[i].owner < refs[j].owner
+				}
+				return refs[i].name < refs[j].name
+			})
+		}
+
+		for _, r := range refs {
+			if err := m.run(ctx, searcher, r); err != nil && !errors.Is(err, ErrNotFound) {
+				log.Printf("savedsearch: %s: %v", r.name, err)
+			}
+		}
+	}
+}
+
+// run searches a saved search again and records the changes since its
+// previous run. The first run only records the results.
+func (m *Manager) run(ctx context.Context, searcher zoekt.Searcher, r ref) error {
+	s, err := m.Get(r.owner, r.name)
+	if err != nil {
+		return err
+	}
+	if m.owners != nil {
+		p, ok := m.owners.Principal(s.Owner)
+		if !ok {
+			// The owner is gone, or the search was saved without
+			// authentication.
+			return nil
+		}
+		ctx = auth.WithPrincipal(ctx, p)
+	}
+	q, err := query.Parse(s.Query)
+	if err != nil {
+		return err
+	}
+	sres, err := searcher.Search(ctx, q, &zoekt.SearchOptions{MaxDocDisplayCount: maxResults})
+	if err != nil {
+		return err
+	}
+	hits := make([]Hit, 0, len(sres.Files))
+	for _, f := range sres.Files {
+		// 🚨 SECURITY: the searcher authorizes the owner already, but
+		// nothing the owner may not see must reach the webhook.
+		if m.owners != nil && !auth.Allowed(ctx, f.Repository) {
+			continue
+		}
+		hits = append(hits, Hit{
+			Repository: f.Repository,
+			FileName:   f.FileName,
+			Checksum:   hex.EncodeToString(f.Checksum),
+		})
+	}
+	sortHits(hits)
+
+	m.mu.Lock()
+	if cur, err := m.get(r); err != nil || cur.Query != s.Query {
+		// deleted or changed while searching
+		m.mu.Unlock()
+		return err
+	}
+	var prev []Hit
+	seen, err := m.getJSON(r, "results", &prev)
+	if err == nil {
+		err = m.putJSON(r, "results", hits)
+	}
+	c := &Changes{Name: s.Name, Query: s.Query, Time: time.Now()}
+	if seen {
+		c.Added, c.Removed, c.Modified = diff(prev, hits)
+	}
+	if err == nil && !c.empty() {
+		err = m.putJSON(r, "changes", c)
+	}
+	m.mu.Unlock()
+	if err != nil || c.empty() || s.Webhook == "" {
+		return err
+	}
+	return m.post(ctx, s.Webhook, c)
+}
+
+func (m *Manager) post(ctx context.Context, webhook string, c *Changes) error {
+	// The allowed hosts may have changed since the search was saved.
+	if err := m.CheckWebhook(webhook); err != nil {
+		return err
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", webhook, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := m.client.Do(req)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	if res.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s: %s", webhook, res.Status)
+	}
+	return nil
+}
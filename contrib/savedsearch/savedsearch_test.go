@@ -0,0 +1,161 @@
+package savedsearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/zoekt"
+	"github.com/sourcegraph/zoekt/contrib/keyval"
+	"github.com/sourcegraph/zoekt/internal/auth"
+	"github.com/sourcegraph/zoekt/query"
+)
+
+func newTestManager(t *testing.T, opts ...Option) *Manager {
+	t.Helper()
+	storage, err := keyval.Open(keyval.Config{Backend: keyval.BackendBolt, BaseDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { storage.Close() })
+	return NewManager(storage, opts...)
+}
+
+func TestCheckWebhook(t *testing.T) {
+	public := newTestManager(t)
+	allowList := newTestManager(t, WithWebhookHosts("hooks.example.com", "*.internal", "127.0.0.1"))
+	for _, tc := range []struct {
+		m       *Manager
+		webhook string
+		ok      bool
+	}{
+		{public, "", true},
+		{public, "https://hooks.example.com/x", true},
+		{public, "http://8.8.8.8/", true},
+		{public, "ftp://hooks.example.com/", false},
+		{public, "http://127.0.0.1:8080/", false},
+		{public, "http://[::1]/", false},
+		{public, "http://10.1.2.3/", false},
+		{public, "http://169.254.169.254/latest/meta-data", false},
+		{public, "http://100.64.0.1/", false},
+		{public, "http://0.0.0.0/", false},
+		{allowList, "https://hooks.example.com/x", true},
+		{allowList, "https://HOOKS.example.com/x", true},
+		{allowList, "http://ci.internal:8080/", true},
+		{allowList, "http://127.0.0.1:8080/", true},
+		{allowList, "http://internal/", false},
+		{allowList, "http://8.8.8.8/", false},
+		{allowList, "https://evil.example.com/", false},
+	} {
+		err := tc.m.CheckWebhook(tc.webhook)
+		if (err == nil) != tc.ok {
+			t.Errorf("CheckWebhook(%q) with hosts %v: got %v, want ok=%v", tc.webhook, tc.m.webhookHosts, err, tc.ok)
+		}
+	}
+}
+
+func TestPostRefusesInternalAddresses(t *testing.T) {
+	called := false
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer hook.Close()
+
+	// localhost passes CheckWebhook, but resolves to a loopback address.
+	webhook := strings.Replace(hook.URL, "127.0.0.1", "localhost", 1)
+	m := newTestManager(t)
+	if err := m.post(context.Background(), webhook, &Changes{}); err == nil || !strings.Contains(err.Error(), "not public") {
+		t.Errorf("posting to %s: got %v, want an error", webhook, err)
+	}
+
+	// Redirects are not followed.
+	redirect := httptest.NewServer(http.RedirectHandler(hook.URL, http.StatusFound))
+	defer redirect.Close()
+	m = newTestManager(t, WithWebhookHosts("127.0.0.1"))
+	if err := m.post(context.Background(), redirect.URL, &Changes{}); err == nil {
+		t.Error("posting to a redirect succeeded")
+	}
+	if called {
+		t.Error("webhook was called")
+	}
+}
+
+type owners map[string]*auth.Principal
+
+func (o owners) Principal(name string) (*auth.Principal, bool) {
+	p, ok := o[name]
+	return p, ok
+}
+
+// leakySearcher finds a file in every repository, whoever asks, and records
+// who asked.
+type leakySearcher struct {
+	zoekt.Streamer
+	callers []string
+}
+
+func (s *leakySearcher) Search(ctx context.Context, q query.Q, opts *zoekt.SearchOptions) (*zoekt.SearchResult, error) {
+	p, _ := auth.FromContext(ctx)
+	s.callers = append(s.callers, p.Name)
+	return &zoekt.SearchResult{Files: []zoekt.FileMatch{
+		{Repository: "acme/app", FileName: "a.go"},
+		{Repository: "other/app", FileName: "b.go"},
+	}}, nil
+}
+
+func TestOwners(t *testing.T) {
+	m := newTestManager(t, WithOwners(owners{
+		"alice": {Name: "alice", Repos: []*regexp.Regexp{regexp.MustCompile("^acme/")}},
+	}))
+	for _, s := range []*Search{
+		{Name: "mine", Query: "x", Owner: "alice"},
+		{Name: "mine", Query: "x", Owner: "mallory"},
+		{Name: "mine", Query: "x"},
+	} {
+		if err := m.Save(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got, err := m.List("bob"); err != nil || len(got) != 0 {
+		t.Errorf("List(bob) = %v, %v, want none", got, err)
+	}
+	if _, err := m.Get("bob", "mine"); err != ErrNotFound {
+		t.Errorf("Get(bob, mine): got %v, want ErrNotFound", err)
+	}
+	if err := m.Delete("bob", "mine"); err != ErrNotFound {
+		t.Errorf("Delete(bob, mine): got %v, want ErrNotFound", err)
+	}
+	if got, err := m.Get("alice", "mine"); err != nil || got.Owner != "alice" {
+		t.Errorf("Get(alice, mine) = %v, %v", got, err)
+	}
+
+	searcher := &leakySearcher{}
+	searches, err := m.listAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(searches) != 3 {
+		t.Fatalf("listAll: got %d searches, want 3", len(searches))
+	}
+	for _, s := range searches {
+		if err := m.run(context.Background(), searcher, ref{s.Owner, s.Name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Searches of unknown owners and without owner don't run.
+	if len(searcher.callers) != 1 || searcher.callers[0] != "alice" {
+		t.Errorf("searched as %v, want [alice]", searcher.callers)
+	}
+	var hits []Hit
+	if _, err := m.getJSON(ref{"alice", "mine"}, "results", &hits); err != nil {
+		t.Fatal(err)
+	}
+	if len(hits) != 1 || hits[0].Repository != "acme/app" {
+		t.Errorf("results of alice: got %v, want only acme/app", hits)
+	}
+}
@@ -55,6 +55,34 @@ function zoektSearch(query) {
    });
 }
 
+function zoektSuggest(query) {
+   var cookie = zoektGetCookie();
+   var auth = cookie.zoekt_auth?('Basic ' + cookie.zoekt_auth):'';
+   return zoektAjax({
+      method: 'GET',
+      url: '/api/suggest',
+      data: { q: query },
+      headers: { 'Authorization': auth }
+   });
+}
+
+function zoektSuggestTrigger() {
+   var input = document.querySelector('#txt_query');
+   var list = document.querySelector('#suggestions');
+   zoektSuggest(input.value).then(function (xhr) {
+      var obj;
+      try { obj = JSON.parse(xhr.response); } catch(err) { obj = null; }
+      list.innerHTML = '';
+      if (!obj || !obj.Suggestions) return;
+      obj.Suggestions.forEach(function (one) {
+         var option = document.createElement('option');
+         option.value = one.Query;
+         option.label = one.Kind + ': ' + one.Text;
+         list.appendChild(option);
+      });
+   }, function () {});
+}
+
 function zoektSearchTrigger() {
    var input = document.querySelector('#txt_query');
    var btn = document.querySelector('#btn_search');
@@ -99,6 +127,11 @@ function zoektInit() {
    btn.addEventListener('click', function (evt) {
       zoektSearchTrigger();
    });
+   var suggestTimer = null;
+   input.addEventListener('input', function (evt) {
+      if (suggestTimer) clearTimeout(suggestTimer);
+      suggestTimer = setTimeout(zoektSuggestTrigger, 100);
+   });
 }
 
 zoektInit();
@@ -79,7 +79,7 @@ pre.source-code {
 <body>
 <header class="header"><span><a href="#">Zoekt</a></span></header>
 <div class="searchbox">
-   <div class="search-input"><input id="txt_query" placeholder="Query" /></div>
+   <div class="search-input"><input id="txt_query" placeholder="Query" list="suggestions" autocomplete="off" /><datalist id="suggestions"></datalist></div>
    <div class="search-btn"><button id="btn_search">Search</button></div>
 </div>
 <div class="result" id="result"></div>
@@ -144,6 +144,34 @@ function zoektSearch(query) {
    });
 }
 
+function zoektSuggest(query) {
+   var cookie = zoektGetCookie();
+   var auth = cookie.zoekt_auth?('Basic ' + cookie.zoekt_auth):'';
+   return zoektAjax({
+      method: 'GET',
+      url: '/api/suggest',
+      data: { q: query },
+      headers: { 'Authorization': auth }
+   });
+}
+
+function zoektSuggestTrigger() {
+   var input = document.querySelector('#txt_query');
+   var list = document.querySelector('#suggestions');
+   zoektSuggest(input.value).then(function (xhr) {
+      var obj;
+      try { obj = JSON.parse(xhr.response); } catch(err) { obj = null; }
+      list.innerHTML = '';
+      if (!obj || !obj.Suggestions) return;
+      obj.Suggestions.forEach(function (one) {
+         var option = document.createElement('option');
+         option.value = one.Query;
+         option.label = one.Kind + ': ' + one.Text;
+         list.appendChild(option);
+      });
+   }, function () {});
+}
+
 function zoektSearchTrigger() {
    var input = document.querySelector('#txt_query');
    var btn = document.querySelector('#btn_search');
@@ -190,6 +218,11 @@ function zoektInit() {
    btn.addEventListener('click', function (evt) {
       zoektSearchTrigger();
    });
+   var suggestTimer = null;
+   input.addEventListener('input', function (evt) {
+      if (suggestTimer) clearTimeout(suggestTimer);
+      suggestTimer = setTimeout(zoektSuggestTrigger, 100);
+   });
 }
 
 zoektInit();
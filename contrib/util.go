@@ -297,7 +297,28 @@ func (a *fileAggregator) add(path string, info os.FileInfo, err error) error {
 	return nil
 }
 
+// deltaCompaction is when the shards written by IndexDelta are folded back
+// into a single shard.
+var deltaCompaction = index.CompactionThresholds{
+	MaxDeltaShards: 32,
+	MaxDeltaBytes:  64 << 20,
+}
+
+// Index indexes all files under sourcePath into indexPath
 func Index(indexPath, sourcePath string, ignoreDirs []string) error {
+	return indexSource(indexPath, sourcePath, ignoreDirs, false)
+}
+
+// IndexDelta is like Index but only indexes files changed since the last
+// run; unchanged files stay in the existing shards and files changed or
+// removed there are tombstoned. It does a full Index if there are no
+// shards to build on, see index.Options.DeltaByChecksum. The shards are
+// compacted once there are too many delta shards.
+func IndexDelta(indexPath, sourcePath string, ignoreDirs []string) error {
+	return indexSource(indexPath, sourcePath, ignoreDirs, true)
+}
+
+func indexSource(indexPath, sourcePath string, ignoreDirs []string, delta bool) error {
 	maxprocs.Set()
 	opts := index.Options{}
 	ignoreDirMap := map[string]struct{}{}
@@ -320,6 +341,8 @@ func Index(indexPath, sourcePath string, ignoreDirs []string) error {
 		return fmt.Errorf("no file for indexing")
 	}
 	opts.IndexDir = indexPath
+	opts.IsDelta = delta
+	opts.DeltaByChecksum = delta
 	opts.RepositoryDescription.Source = sourcePath
 	opts.RepositoryDescription.Name = filepath.Base(sourcePath)
 	builder, err := index.NewBuilder(opts)
@@ -359,5 +382,14 @@ func Index(indexPath, sourcePath string, ignoreDirs []string) error {
 		builder.AddFile(displayName, content)
 	}
 
-	return builder.Finish()
+	if err := builder.Finish(); err != nil {
+		return err
+	}
+	if !delta {
+		return nil
+	}
+	if ok, err := opts.NeedsCompaction(deltaCompaction); err != nil || !ok {
+		return err
+	}
+	return index.CompactDeltaShards(opts)
 }
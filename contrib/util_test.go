@@ -0,0 +1,58 @@
+package contrib
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sourcegraph/zoekt"
+	"github.com/sourcegraph/zoekt/index"
+)
+
+func TestIndexDeltaCompaction(t *testing.T) {
+	old := deltaCompaction
+	deltaCompaction = index.CompactionThresholds{MaxDeltaShards: 3}
+	defer func() { deltaCompaction = old }()
+
+	sourceDir := filepath.Join(t.TempDir(), "project")
+	if err := os.Mkdir(sourceDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(sourceDir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	indexDir := t.TempDir()
+	opts := index.Options{
+		IndexDir:              indexDir,
+		RepositoryDescription: zoekt.Repository{Name: "project"},
+	}
+
+	write("a.txt", "alpha")
+	write("b.txt", "beta")
+	if err := Index(indexDir, sourceDir, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every delta build adds a shard, until the third delta shard compacts
+	// them.
+	for i, want := range []int{2, 3, 1} {
+		write("a.txt", "alpha "+string(rune('0'+i)))
+		if err := IndexDelta(indexDir, sourceDir, nil); err != nil {
+			t.Fatal(err)
+		}
+		if shards := opts.FindAllShards(); len(shards) != want {
+			t.Fatalf("delta build %d: got shards %v, want %d", i, shards, want)
+		}
+	}
+
+	repos, _, err := index.ReadMetadataPath(opts.FindAllShards()[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repos[0].FileTombstones) != 0 {
+		t.Errorf("compacted shard has tombstones %v", repos[0].FileTombstones)
+	}
+}
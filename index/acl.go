@@ -0,0 +1,167 @@
+package index
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"slices"
+
+	"github.com/sourcegraph/zoekt"
+	"github.com/sourcegraph/zoekt/internal/auth"
+)
+
+// docACLs holds the ACL labels of the documents of a shard, see
+// Document.ACL.
+type docACLs struct {
+	// labels are the distinct labels of the shard.
+	labels []string
+
+	// docs[i] are the indexes into labels of the labels of document i. It is
+	// empty for documents visible to everyone, and nil if the shard has no
+	// ACLs.
+	docs [][]uint32
+}
+
+// marshalACL encodes the ACL labels of a document. Documents without labels
+// are encoded as an empty item.
+func marshalACL(labels []string) []byte {
+	if len(labels) == 0 {
+		return nil
+	}
+	labels = slices.Clone(labels)
+	slices.Sort(labels)
+	labels = slices.Compact(labels)
+
+	var buf []byte
+	for _, l := range labels {
+		buf = binary.AppendUvarint(buf, uint64(len(l)))
+		buf = append(buf, l...)
+	}
+	return buf
+}
+
+func unmarshalACL(data []byte) ([]string, error) {
+	var labels []string
+	for len(data) > 0 {
+		sz, m := binary.Uvarint(data)
+		if m <= 0 || sz > uint64(len(data)-m) {
+			return nil, fmt.Errorf("corrupt ACL of %d bytes", len(data))
+		}
+		data = data[m:]
+		labels = append(labels, string(data[:sz]))
+		data = data[sz:]
+	}
+	return labels, nil
+}
+
+// readACLs reads the ACL labels of all documents. They are kept in memory,
+// since they are needed for every document a search visits.
+func (d *indexData) readACLs(sec *compoundSection) (docACLs, error) {
+	var a docACLs
+	index := sec.relativeIndex()
+	if len(index) == 0 {
+		return a, nil
+	}
+
+	blob, err := d.readSectionBlob(sec.data)
+	if err != nil {
+		return a, err
+	}
+
+	ids := map[string]uint32{}
+	a.docs = make([][]uint32, len(index)-1)
+	for i := range a.docs {
+		labels, err := unmarshalACL(blob[index[i]:index[i+1]])
+		if err != nil {
+			return a, fmt.Errorf("document %d: %w", i, err)
+		}
+		for _, l := range labels {
+			id, ok := ids[l]
+			if !ok {
+				id = uint32(len(a.labels))
+				ids[l] = id
+				a.labels = append(a.labels, l)
+			}
+			a.docs[i] = append(a.docs[i], id)
+		}
+	}
+	return a, nil
+}
+
+// labelsOf returns the ACL labels of document doc.
+func (a *docACLs) labelsOf(doc uint32) []string {
+	if len(a.docs) == 0 || len(a.docs[doc]) == 0 {
+		return nil
+	}
+	labels := make([]string, 0, len(a.docs[doc]))
+	for _, id := range a.docs[doc] {
+		labels = append(labels, a.labels[id])
+	}
+	return labels
+}
+
+// visibleFunc returns a function which reports whether the caller of ctx may
+// see document doc, or nil if it may see all documents of the shard.
+func (a *docACLs) visibleFunc(ctx context.Context) func(doc uint32) bool {
+	if len(a.docs) == 0 {
+		return nil
+	}
+	groups, all := auth.Groups(ctx)
+	if all {
+		return nil
+	}
+
+	allowed := make([]bool, len(a.labels))
+	allowedAll := true
+	for i, l := range a.labels {
+		allowed[i] = slices.Contains(groups, l)
+		allowedAll = allowedAll && allowed[i]
+	}
+	if allowedAll {
+		return nil
+	}
+
+	return func(doc uint32) bool {
+		ids := a.docs[doc]
+		if len(ids) == 0 {
+			return true
+		}
+		for _, id := range ids {
+			if allowed[id] {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// countVisible returns the number of documents in [start, end) visible
+// according to visible.
+func countVisible(visible func(uint32) bool, start, end uint32) int {
+	n := 0
+	for doc := start; doc < end; doc++ {
+		if visible(doc) {
+			n++
+		}
+	}
+	return n
+}
+
+// redactStats clears the stats which are computed over all documents of a
+// shard rather than the visible ones, so that they don't reveal the contents
+// of hidden documents.
+func redactStats(s *zoekt.Stats) {
+	s.NgramMatches = 0
+	s.IndexBytesLoaded = 0
+}
+
+func (a *docACLs) sizeBytes() int {
+	sz := 24 * len(a.docs)
+	for _, ids := range a.docs {
+		sz += 4 * len(ids)
+	}
+	for _, l := range a.labels {
+		sz += 16 + len(l)
+	}
+	return sz
+}
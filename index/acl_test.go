@@ -0,0 +1,159 @@
+package index
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/zoekt"
+	"github.com/sourcegraph/zoekt/internal/auth"
+	"github.com/sourcegraph/zoekt/query"
+)
+
+func TestACL(t *testing.T) {
+	b := testShardBuilder(t, nil,
+		Document{Name: "public", Content: []byte("needle")},
+		Document{Name: "eng", Content: []byte("needle"), ACL: []string{"eng", "eng"}},
+		Document{Name: "secret", Content: []byte("needle secret"), ACL: []string{"ops", "admin"}},
+	)
+	s := searcherForTest(t, b)
+
+	d := s.(*indexData)
+	if got := d.metaData.IndexMinReaderVersion; got != 18 {
+		t.Errorf("got min reader version %d, want 18", got)
+	}
+	for doc, want := range [][]string{nil, {"eng"}, {"admin", "ops"}} {
+		if d := cmp.Diff(want, d.acls.labelsOf(uint32(doc))); d != "" {
+			t.Errorf("labels of document %d (-want +got):\n%s", doc, d)
+		}
+	}
+
+	ctxWithGroups := func(groups ...string) context.Context {
+		return auth.WithPrincipal(context.Background(), &auth.Principal{Groups: groups})
+	}
+	for _, tc := range []struct {
+		name string
+		ctx  context.Context
+		want []string
+	}{
+		{"no principal", context.Background(), []string{"public"}},
+		{"eng", ctxWithGroups("eng"), []string{"public", "eng"}},
+		{"admin", ctxWithGroups("admin"), []string{"public", "secret"}},
+		{"all groups", ctxWithGroups("eng", "ops"), []string{"public", "eng", "secret"}},
+		{"unrestricted", auth.WithUnrestrictedContext(context.Background()), []string{"public", "eng", "secret"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := s.Search(tc.ctx, &query.Substring{Pattern: "needle"}, &zoekt.SearchOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, f := range res.Files {
+				got = append(got, f.FileName)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("files (-want +got):\n%s", d)
+			}
+			if res.Stats.FileCount != len(tc.want) || res.Stats.FilesConsidered != len(tc.want) {
+				t.Errorf("got %d files and %d considered, want %d", res.Stats.FileCount, res.Stats.FilesConsidered, len(tc.want))
+			}
+
+			res, err = s.Search(tc.ctx, &query.Const{Value: true}, &zoekt.SearchOptions{EstimateDocCount: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Stats.ShardFilesConsidered != len(tc.want) {
+				t.Errorf("got %d files in the shard, want %d", res.Stats.ShardFilesConsidered, len(tc.want))
+			}
+		})
+	}
+
+	// Hidden documents don't show in the stats of a search which only they
+	// match.
+	res, err := s.Search(context.Background(), &query.Substring{Pattern: "secret"}, &zoekt.SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Stats.FileCount != 0 || res.Stats.FilesConsidered != 0 || res.Stats.NgramMatches != 0 || res.Stats.IndexBytesLoaded != 0 {
+		t.Errorf("got stats %+v for hidden matches", res.Stats)
+	}
+
+	// Limits only count visible documents.
+	res, err = s.Search(ctxWithGroups("eng"), &query.Substring{Pattern: "needle"}, &zoekt.SearchOptions{ShardMaxMatchCount: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Files) != 1 || res.Stats.FilesSkipped != 1 {
+		t.Errorf("got %d files and %d skipped, want 1 and 1", len(res.Files), res.Stats.FilesSkipped)
+	}
+}
+
+func TestMarshalACL(t *testing.T) {
+	if b := marshalACL(nil); b != nil {
+		t.Errorf("got %q for no labels", b)
+	}
+	labels, err := unmarshalACL(marshalACL([]string{"b", "", "a", "b"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff([]string{"", "a", "b"}, labels); d != "" {
+		t.Errorf("labels (-want +got):\n%s", d)
+	}
+	if _, err := unmarshalACL([]byte{5, 'a'}); err == nil {
+		t.Error("unmarshalACL succeeded on a truncated ACL")
+	}
+}
+
+func TestACLHidesExplainAndFuzzyTerms(t *testing.T) {
+	b := testShardBuilder(t, nil,
+		Document{Name: "public", Content: []byte("n := length(xs)")},
+		Document{Name: "secret", Content: []byte("n := lenqht(xs)"), ACL: []string{"ops"}},
+	)
+	s := searcherForTest(t, b)
+	d := s.(*indexData)
+
+	// Terms which only occur in hidden documents are not expanded.
+	q := &query.Fuzzy{Pattern: "lenght", Content: true}
+	for _, tc := range []struct {
+		name string
+		ctx  context.Context
+		want []string
+	}{
+		{"no principal", context.Background(), []string{"length"}},
+		{"ops", auth.WithPrincipal(context.Background(), &auth.Principal{Groups: []string{"ops"}}), []string{"length", "lenqht"}},
+	} {
+		var got []string
+		query.VisitAtoms(d.expandFuzzy(q, d.acls.visibleFunc(tc.ctx)), func(q query.Q) {
+			if s, ok := q.(*query.Substring); ok && s.Pattern != "lenght" {
+				got = append(got, s.Pattern)
+			}
+		})
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("%s: expansions (-want +got):\n%s", tc.name, diff)
+		}
+	}
+
+	// The explanation doesn't show the match tree and ngrams, which depend on
+	// the hidden documents.
+	for _, tc := range []struct {
+		name       string
+		ctx        context.Context
+		restricted bool
+	}{
+		{"no principal", context.Background(), true},
+		{"unrestricted", auth.WithUnrestrictedContext(context.Background()), false},
+	} {
+		res, err := s.Search(tc.ctx, &query.Substring{Pattern: "lenqht"}, &zoekt.SearchOptions{Explain: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Explain) != 1 {
+			t.Fatalf("%s: got %d explained shards, want 1", tc.name, len(res.Explain))
+		}
+		e := res.Explain[0]
+		if hidden := e.MatchTree == "" && e.Ngrams == nil; hidden != tc.restricted {
+			t.Errorf("%s: got match tree %q and ngrams %v", tc.name, e.MatchTree, e.Ngrams)
+		}
+	}
+}
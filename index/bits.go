@@ -17,6 +17,7 @@ package index
 import (
 	"cmp"
 	"encoding/binary"
+	"fmt"
 	"math"
 	"sort"
 	"unicode"
@@ -230,6 +231,29 @@ func unmarshalDocSections(data []byte, ds []DocumentSection) []DocumentSection {
 	return ds
 }
 
+// marshalCommit encodes the hash and author of a document's last commit. An
+// unknown commit is encoded as an empty item.
+func marshalCommit(hash, author string) []byte {
+	if hash == "" && author == "" {
+		return nil
+	}
+	buf := binary.AppendUvarint(nil, uint64(len(hash)))
+	buf = append(buf, hash...)
+	return append(buf, author...)
+}
+
+func unmarshalCommit(data []byte) (hash, author string, err error) {
+	if len(data) == 0 {
+		return "", "", nil
+	}
+	sz, m := binary.Uvarint(data)
+	if m <= 0 || sz > uint64(len(data)-m) {
+		return "", "", fmt.Errorf("corrupt commit of %d bytes", len(data))
+	}
+	data = data[m:]
+	return string(data[:sz]), string(data[sz:]), nil
+}
+
 type ngramSlice []ngram
 
 func (p ngramSlice) Len() int { return len(p) }
@@ -263,6 +263,29 @@ func TestRuneOffsetLookup(t *testing.T) {
 	}
 }
 
+func TestMarshalCommit(t *testing.T) {
+	for _, c := range [][2]string{
+		{"", ""},
+		{"abc", ""},
+		{"", "alice"},
+		{"0123456789abcdef0123456789abcdef01234567", "Bob Ünicode"},
+	} {
+		hash, author, err := unmarshalCommit(marshalCommit(c[0], c[1]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash != c[0] || author != c[1] {
+			t.Errorf("got (%q, %q), want (%q, %q)", hash, author, c[0], c[1])
+		}
+	}
+
+	for _, data := range [][]byte{{5, 'a'}, {0x80}, {0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}} {
+		if _, _, err := unmarshalCommit(data); err == nil {
+			t.Errorf("unmarshalCommit(%v) succeeded on a corrupt commit", data)
+		}
+	}
+}
+
 func TestUnmarshalDocSections(t *testing.T) {
 	f := func(nums []uint32) bool {
 		nums = sortedUnique(nums)
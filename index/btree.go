@@ -294,6 +294,9 @@ type btreeIndex struct {
 	ngramSec simpleSection
 
 	postingIndex simpleSection
+
+	// offsets64 is set if the posting index holds 64-bit offsets.
+	offsets64 bool
 }
 
 // SizeBytes returns how much memory this structure uses in the heap.
@@ -303,11 +306,9 @@ func (b btreeIndex) SizeBytes() (sz int) {
 		sz += int(pointerSize) + b.bt.sizeBytes()
 	}
 	// ngramSec
-	sz += 8
+	sz += 16
 	// postingIndex
-	sz += 8
-	// postingDataSentinelOffset
-	sz += 4
+	sz += 16
 	return
 }
 
@@ -357,17 +358,28 @@ func (b btreeIndex) Get(ng ngram) (ss simpleSection) {
 // Assumming we don't hit a page boundary, which should be rare given that we
 // only read 8 bytes, we need 1 disk access to read the posting offset.
 func (b btreeIndex) getPostingList(ngramIndex int) simpleSection {
-	relativeOffsetBytes := uint32(ngramIndex) * 4
+	width := uint64(4)
+	if b.offsets64 {
+		width = 8
+	}
+	offset := func(o []byte) uint64 {
+		if b.offsets64 {
+			return binary.BigEndian.Uint64(o)
+		}
+		return uint64(binary.BigEndian.Uint32(o))
+	}
+
+	relativeOffsetBytes := uint64(ngramIndex) * width
 
-	if relativeOffsetBytes+8 <= b.postingIndex.sz {
+	if relativeOffsetBytes+2*width <= b.postingIndex.sz {
 		// read 2 offsets
-		o, err := b.file.Read(b.postingIndex.off+relativeOffsetBytes, 8)
+		o, err := b.file.Read(b.postingIndex.off+relativeOffsetBytes, 2*width)
 		if err != nil {
 			return simpleSection{}
 		}
 
-		start := binary.BigEndian.Uint32(o[0:4])
-		end := binary.BigEndian.Uint32(o[4:8])
+		start := offset(o[:width])
+		end := offset(o[width:])
 		return simpleSection{
 			off: start,
 			sz:  end - start,
@@ -375,12 +387,12 @@ func (b btreeIndex) getPostingList(ngramIndex int) simpleSection {
 	} else {
 		// last ngram => read 1 offset and calculate the size of the posting
 		// list from the offset of index section.
-		o, err := b.file.Read(b.postingIndex.off+relativeOffsetBytes, 4)
+		o, err := b.file.Read(b.postingIndex.off+relativeOffsetBytes, width)
 		if err != nil {
 			return simpleSection{}
 		}
 
-		start := binary.BigEndian.Uint32(o[0:4])
+		start := offset(o)
 		return simpleSection{
 			off: start,
 			// The layout of the posting list compound section on disk is
@@ -396,10 +408,10 @@ func (b btreeIndex) getPostingList(ngramIndex int) simpleSection {
 	}
 }
 
-func (b btreeIndex) getBucket(bucketIndex int) (off uint32, sz uint32) {
+func (b btreeIndex) getBucket(bucketIndex int) (off uint64, sz uint64) {
 	// All but the rightmost bucket have exactly bucketSize/2 ngrams
-	sz = uint32(b.bt.opts.bucketSize / 2 * ngramEncoding)
-	off = b.ngramSec.off + uint32(bucketIndex)*sz
+	sz = uint64(b.bt.opts.bucketSize / 2 * ngramEncoding)
+	off = b.ngramSec.off + uint64(bucketIndex)*sz
 
 	// Rightmost bucket has size upto the end of the ngramSec.
 	if bucketIndex == b.bt.lastBucketIndex {
@@ -21,6 +21,7 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"math"
 	"net/url"
 	"os"
 	"os/exec"
@@ -67,7 +68,8 @@ type Options struct {
 	// Parallelism is the maximum number of shards to index in parallel
 	Parallelism int
 
-	// ShardMax sets the maximum corpus size for a single shard
+	// ShardMax sets the maximum corpus size for a single shard. It is capped
+	// at maxShardMax.
 	ShardMax int
 
 	// TrigramMax sets the maximum number of distinct trigrams per document.
@@ -102,6 +104,16 @@ type Options struct {
 	// last run.
 	IsDelta bool
 
+	// DeltaByChecksum makes a delta build find the changed documents itself,
+	// for sources which cannot tell what changed since the last run, such as
+	// directories and archives. All documents are added as usual; those whose
+	// path and content checksum match a document of the existing shards are
+	// dropped, and documents of the existing shards which are changed or not
+	// added again are tombstoned. If the existing shards cannot be built on,
+	// for example because there are none, the build falls back to a normal
+	// build. It only has an effect if IsDelta is set.
+	DeltaByChecksum bool
+
 	// changedOrRemovedFiles is a list of file paths that have been changed or removed
 	// since the last indexing job for this repository. These files will be tombstoned
 	// in the older shards for this repository.
@@ -113,6 +125,14 @@ type Options struct {
 	// Sourcegraph specific option.
 	ShardMerging bool
 
+	// CompressContent stores document contents zstd compressed, trading
+	// search CPU for disk and page cache.
+	CompressContent bool
+
+	// IndexTokens adds an index of the identifier tokens of the contents,
+	// which speeds up word queries and queries shorter than an ngram.
+	IndexTokens bool
+
 	// HeapProfileTriggerBytes is the heap allocation in bytes that will trigger a memory profile. If 0, no memory profile
 	// will be triggered. Note this trigger looks at total heap allocation (which includes both inuse and garbage objects).
 	//
@@ -129,6 +149,8 @@ type HashOptions struct {
 	ctagsPath        string
 	cTagsMustSucceed bool
 	largeFiles       []string
+	compressContent  bool
+	indexTokens      bool
 }
 
 func (o *Options) HashOptions() HashOptions {
@@ -138,6 +160,8 @@ func (o *Options) HashOptions() HashOptions {
 		ctagsPath:        o.CTagsPath,
 		cTagsMustSucceed: o.CTagsMustSucceed,
 		largeFiles:       o.LargeFiles,
+		compressContent:  o.CompressContent,
+		indexTokens:      o.IndexTokens,
 	}
 }
 
@@ -150,6 +174,13 @@ func (o *Options) GetHash() string {
 	hasher.Write(fmt.Appendf(nil, "%d", h.sizeMax))
 	hasher.Write(fmt.Appendf(nil, "%q", h.largeFiles))
 	hasher.Write(fmt.Appendf(nil, "%t", h.disableCTags))
+	// only hashed if set, to keep the hash of existing shards
+	if h.compressContent {
+		hasher.Write([]byte("compress"))
+	}
+	if h.indexTokens {
+		hasher.Write([]byte("tokens"))
+	}
 
 	return fmt.Sprintf("%x", hasher.Sum(nil))
 }
@@ -179,11 +210,13 @@ func (o *Options) Flags(fs *flag.FlagSet) {
 	x.SetDefaults()
 	fs.IntVar(&o.SizeMax, "file_limit", x.SizeMax, "maximum file size")
 	fs.IntVar(&o.TrigramMax, "max_trigram_count", x.TrigramMax, "maximum number of trigrams per document")
-	fs.IntVar(&o.ShardMax, "shard_limit", x.ShardMax, "maximum corpus size for a shard")
+	fs.IntVar(&o.ShardMax, "shard_limit", x.ShardMax, "maximum corpus size for a shard, at most 2G")
 	fs.IntVar(&o.Parallelism, "parallelism", x.Parallelism, "maximum number of parallel indexing processes.")
 	fs.StringVar(&o.IndexDir, "index", x.IndexDir, "directory for search indices")
 	fs.BoolVar(&o.CTagsMustSucceed, "require_ctags", x.CTagsMustSucceed, "If set, ctags calls must succeed.")
 	fs.Var(largeFilesFlag{o}, "large_file", "A glob pattern where matching files are to be index regardless of their size. You can add multiple patterns by setting this more than once.")
+	fs.BoolVar(&o.CompressContent, "compress_content", x.CompressContent, "If set, file contents are stored zstd compressed.")
+	fs.BoolVar(&o.IndexTokens, "index_tokens", x.IndexTokens, "If set, an index of the identifier tokens of file contents is added.")
 
 	// Sourcegraph specific
 	fs.BoolVar(&o.DisableCTags, "disable_ctags", x.DisableCTags, "If set, ctags will not be called.")
@@ -222,6 +255,14 @@ func (o *Options) Args() []string {
 		args = append(args, "-large_file", a)
 	}
 
+	if o.CompressContent {
+		args = append(args, "-compress_content")
+	}
+
+	if o.IndexTokens {
+		args = append(args, "-index_tokens")
+	}
+
 	// Sourcegraph specific
 	if o.DisableCTags {
 		args = append(args, "-disable_ctags")
@@ -241,6 +282,9 @@ type Builder struct {
 	opts     Options
 	throttle chan int
 
+	// indexFormatVersion is the format version of the shards we write.
+	indexFormatVersion int
+
 	nextShardNum int
 	todo         []*Document
 	docChecker   DocChecker
@@ -267,6 +311,13 @@ type Builder struct {
 	id string
 
 	finishCalled bool
+
+	// indexedChecksums holds the content checksums of the documents in the
+	// existing shards by path, if this is a DeltaByChecksum build.
+	indexedChecksums map[string]uint64
+	// addedPaths are the paths of the documents added to a DeltaByChecksum
+	// build.
+	addedPaths map[string]struct{}
 }
 
 type finishedShard struct {
@@ -315,6 +366,8 @@ func (o *Options) SetDefaults() {
 	}
 	if o.ShardMax == 0 {
 		o.ShardMax = 100 << 20
+	} else if o.ShardMax > maxShardMax {
+		o.ShardMax = maxShardMax
 	}
 	if o.TrigramMax == 0 {
 		o.TrigramMax = 20000
@@ -328,9 +381,30 @@ func (o *Options) SetDefaults() {
 	}
 }
 
+// offsets64ShardMax is the largest ShardMax for which shards are written in
+// IndexFormatVersion. An index file is typically about 3 times the size of
+// its corpus, so larger shards may exceed 4 GiB and need the 64-bit section
+// offsets of NextIndexFormatVersion.
+const offsets64ShardMax = 1 << 30
+
+// maxShardMax is the largest ShardMax. Content positions and the items of
+// compound sections are addressed with 32-bit offsets, so no section may
+// reach 4 GiB, whatever the format version. The content section holds the
+// corpus and the other sections are smaller than it in practice, so this
+// leaves room for the last document, which may push a shard past ShardMax.
+const maxShardMax = math.MaxInt32
+
+// indexFormatVersion returns the format version of the shards to write.
+func (o *Options) indexFormatVersion() int {
+	if o.ShardMax > offsets64ShardMax {
+		return NextIndexFormatVersion
+	}
+	return IndexFormatVersion
+}
+
 // ShardName returns the name the given index shard.
 func (o *Options) shardName(n int) string {
-	return o.shardNameVersion(IndexFormatVersion, n)
+	return o.shardNameVersion(o.indexFormatVersion(), n)
 }
 
 func (o *Options) shardNameVersion(version, n int) string {
@@ -368,6 +442,10 @@ var readVersions = []struct {
 }, {
 	IndexFormatVersion: NextIndexFormatVersion,
 	FeatureVersion:     FeatureVersion,
+}, {
+	// shards written before 64-bit offsets
+	IndexFormatVersion: 17,
+	FeatureVersion:     FeatureVersion,
 }}
 
 // IncrementalSkipIndexing returns true if the index present on disk matches
@@ -567,7 +645,16 @@ func NewBuilder(opts Options) (*Builder, error) {
 
 	b.parserBins = parserBins
 
-	if opts.IsDelta {
+	b.indexFormatVersion = b.opts.indexFormatVersion()
+
+	if opts.IsDelta && opts.DeltaByChecksum {
+		if err := b.prepareChecksumDelta(); err != nil {
+			log.Printf("falling back to normal build of %s: %v", opts.RepositoryDescription.Name, err)
+			b.opts.IsDelta = false
+		}
+	}
+
+	if b.opts.IsDelta {
 		// Delta shards build on top of previously existing shards.
 		// As a consequence, the shardNum for delta shards starts from
 		// the number following the most recently generated shard - not 0.
@@ -576,6 +663,14 @@ func NewBuilder(opts Options) (*Builder, error) {
 		// discovered as a set.
 		shards := b.opts.FindAllShards()
 		b.nextShardNum = len(shards) // shards are zero indexed, so len() provides the next number after the last one
+
+		// For the same reason, delta shards keep the format version of the
+		// shards they build on.
+		if len(shards) > 0 {
+			if _, md, err := ReadMetadataPath(shards[0]); err == nil && (md.IndexFormatVersion == IndexFormatVersion || md.IndexFormatVersion == NextIndexFormatVersion) {
+				b.indexFormatVersion = md.IndexFormatVersion
+			}
+		}
 	}
 
 	if _, err := b.newShardBuilder(); err != nil {
@@ -610,6 +705,10 @@ func (b *Builder) Add(doc Document) error {
 		doc.SkipReason = skip
 	}
 
+	if b.indexedChecksums != nil && b.unchanged(&doc) {
+		return nil
+	}
+
 	b.todo = append(b.todo, &doc)
 
 	if doc.SkipReason == SkipReasonNone {
@@ -648,6 +747,10 @@ func (b *Builder) Finish() error {
 
 	b.finishCalled = true
 
+	if b.indexedChecksums != nil {
+		b.markRemoved()
+	}
+
 	b.flush()
 	b.building.Wait()
 
@@ -955,7 +1058,7 @@ func (b *Builder) buildShard(todo []*Document, nextShardNum int) (*finishedShard
 		}
 	}
 
-	name := b.opts.shardName(nextShardNum)
+	name := b.opts.shardNameVersion(b.indexFormatVersion, nextShardNum)
 
 	shardBuilder, err := b.newShardBuilder()
 	if err != nil {
@@ -1020,6 +1123,13 @@ func (b *Builder) newShardBuilder() (*ShardBuilder, error) {
 	}
 	shardBuilder.IndexTime = b.indexTime
 	shardBuilder.ID = b.id
+	shardBuilder.indexFormatVersion = b.indexFormatVersion
+	if b.opts.CompressContent {
+		shardBuilder.contentEncoding = ContentEncodingZstd
+	}
+	if b.opts.IndexTokens {
+		shardBuilder.tokens = map[string][]uint32{}
+	}
 	return shardBuilder, nil
 }
 
@@ -6,9 +6,12 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"maps"
+	"math"
 	"os"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -54,7 +57,7 @@ func TestBuildv16(t *testing.T) {
 		}
 	}
 
-	wantP := filepath.Join("../testdata/shards", "repo_v16.00000.zoekt")
+	wantP := filepath.Join("../testdata/shards/current", "repo_v16.00000.zoekt")
 
 	// fields indexTime and id depend on time. For this test, we copy the fields from
 	// the old shard.
@@ -134,6 +137,16 @@ func TestFlags(t *testing.T) {
 		want: Options{
 			LargeFiles: []string{"*.md", "\\!*.yaml"},
 		},
+	}, {
+		args: []string{"-compress_content"},
+		want: Options{
+			CompressContent: true,
+		},
+	}, {
+		args: []string{"-index_tokens"},
+		want: Options{
+			IndexTokens: true,
+		},
 	}}
 
 	ignored := []cmp.Option{
@@ -212,7 +225,7 @@ func TestIncrementalSkipIndexing(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			tc.opts.IndexDir = "../testdata/shards"
+			tc.opts.IndexDir = "../testdata/shards/current"
 			t.Log(tc.opts.IndexState())
 			got := tc.opts.IncrementalSkipIndexing()
 			if got != tc.want {
@@ -258,6 +271,62 @@ func TestDontCountContentOfSkippedFiles(t *testing.T) {
 	}
 }
 
+func TestBuilder_offsets64(t *testing.T) {
+	for _, tc := range []struct {
+		shardMax int
+		want     int
+	}{
+		{shardMax: 100 << 20, want: IndexFormatVersion},
+		{shardMax: 2 << 30, want: NextIndexFormatVersion},
+	} {
+		dir := t.TempDir()
+		b, err := NewBuilder(Options{
+			IndexDir:              dir,
+			ShardMax:              tc.shardMax,
+			RepositoryDescription: zoekt.Repository{Name: "repo"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := b.AddFile("f", []byte("hello world")); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.Finish(); err != nil {
+			t.Fatal(err)
+		}
+
+		_, md, err := ReadMetadataPath(filepath.Join(dir, fmt.Sprintf("repo_v%d.00000.zoekt", tc.want)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if md.IndexFormatVersion != tc.want {
+			t.Errorf("shard max %d: got v%d, want v%d", tc.shardMax, md.IndexFormatVersion, tc.want)
+		}
+	}
+}
+
+func TestSetDefaults_shardMax(t *testing.T) {
+	for _, tc := range []struct {
+		shardMax int
+		want     int
+	}{
+		{shardMax: 0, want: 100 << 20},
+		{shardMax: 1 << 30, want: 1 << 30},
+		{shardMax: maxShardMax, want: maxShardMax},
+		// Larger shards would have sections of 4 GiB and more.
+		{shardMax: math.MaxInt, want: maxShardMax},
+	} {
+		opts := Options{ShardMax: tc.shardMax, DisableCTags: true}
+		opts.SetDefaults()
+		if opts.ShardMax != tc.want {
+			t.Errorf("shard max %d: got %d, want %d", tc.shardMax, opts.ShardMax, tc.want)
+		}
+		if opts.indexFormatVersion() != NextIndexFormatVersion && tc.shardMax > offsets64ShardMax {
+			t.Errorf("shard max %d: got v%d, want v%d", tc.shardMax, opts.indexFormatVersion(), NextIndexFormatVersion)
+		}
+	}
+}
+
 func TestPartialSuccess(t *testing.T) {
 	dir := t.TempDir()
 
@@ -1199,3 +1268,94 @@ func TestOptions_shardName(t *testing.T) {
 		t.Fatalf("expected shard name to be with no tenant:\ngot:  %q\nwant: %q", got, want)
 	}
 }
+
+func TestBuilder_DeltaByChecksum(t *testing.T) {
+	indexDir := t.TempDir()
+
+	build := func(files map[string]string) []string {
+		t.Helper()
+		opts := Options{
+			IndexDir:              indexDir,
+			RepositoryDescription: zoekt.Repository{Name: "repo", ID: 1},
+			DisableCTags:          true,
+			IsDelta:               true,
+			DeltaByChecksum:       true,
+		}
+		b, err := NewBuilder(opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		names := slices.Sorted(maps.Keys(files))
+		for _, name := range names {
+			if err := b.AddFile(name, []byte(files[name])); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := b.Finish(); err != nil {
+			t.Fatal(err)
+		}
+		return opts.FindAllShards()
+	}
+
+	liveDocs := func(shard string) []string {
+		t.Helper()
+		repos, _, err := ReadMetadataPath(shard)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := loadShard(shard)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer s.Close()
+		d := s.(*indexData)
+		var names []string
+		for i := uint32(0); i < d.numDocs(); i++ {
+			if _, ok := repos[0].FileTombstones[string(d.fileName(i))]; !ok {
+				names = append(names, string(d.fileName(i)))
+			}
+		}
+		slices.Sort(names)
+		return names
+	}
+
+	// Without existing shards, the build falls back to a normal build.
+	shards := build(map[string]string{"a.go": "package a", "b.go": "package b", "c.go": "package c"})
+	if len(shards) != 1 {
+		t.Fatalf("got shards %v, want 1", shards)
+	}
+
+	shards = build(map[string]string{"a.go": "package a", "b.go": "package b2", "d.go": "package d"})
+	if len(shards) != 2 {
+		t.Fatalf("got shards %v, want 2", shards)
+	}
+	if d := cmp.Diff([]string{"a.go"}, liveDocs(shards[0])); d != "" {
+		t.Errorf("live documents of the first shard (-want +got):\n%s", d)
+	}
+	if d := cmp.Diff([]string{"b.go", "d.go"}, liveDocs(shards[1])); d != "" {
+		t.Errorf("documents of the delta shard (-want +got):\n%s", d)
+	}
+
+	// Nothing changed, so no shard is added.
+	shards = build(map[string]string{"a.go": "package a", "b.go": "package b2", "d.go": "package d"})
+	if len(shards) != 2 {
+		t.Fatalf("got shards %v, want 2", shards)
+	}
+	if d := cmp.Diff([]string{"a.go"}, liveDocs(shards[0])); d != "" {
+		t.Errorf("live documents of the first shard (-want +got):\n%s", d)
+	}
+	if d := cmp.Diff([]string{"b.go", "d.go"}, liveDocs(shards[1])); d != "" {
+		t.Errorf("documents of the delta shard (-want +got):\n%s", d)
+	}
+
+	// A file changed in a delta shard is tombstoned there.
+	shards = build(map[string]string{"a.go": "package a", "b.go": "package b3"})
+	if len(shards) != 3 {
+		t.Fatalf("got shards %v, want 3", shards)
+	}
+	for i, want := range [][]string{{"a.go"}, nil, {"b.go"}} {
+		if d := cmp.Diff(want, liveDocs(shards[i])); d != "" {
+			t.Errorf("live documents of shard %d (-want +got):\n%s", i, d)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package index
+
+import (
+	"bytes"
+	"strings"
+	"time"
+)
+
+// commitPathsSeparator separates the message of a commit document from the
+// list of paths the commit changed. It is an ASCII record separator on a line
+// of its own, which does not occur in ordinary commit messages.
+const commitPathsSeparator = "\n\x1e\n"
+
+// CommitDocument returns the document which represents a commit in the
+// index. The commit is searchable by its message and the paths it changed,
+// and is only returned for queries containing type:commit.
+func CommitDocument(hash, author string, date time.Time, message string, paths []string, branches []string) Document {
+	var b strings.Builder
+	b.WriteString(message)
+	b.WriteString(commitPathsSeparator)
+	for _, p := range paths {
+		b.WriteString(p)
+		b.WriteByte('\n')
+	}
+
+	return Document{
+		Name:         hash,
+		Content:      []byte(b.String()),
+		Branches:     branches,
+		Category:     FileCategoryCommit,
+		CommitHash:   hash,
+		CommitAuthor: author,
+		CommitDate:   date,
+	}
+}
+
+// parseCommitContent splits the content of a commit document into the commit
+// message and the changed paths.
+func parseCommitContent(content []byte) (message string, paths []string) {
+	msg, rest, ok := bytes.Cut(content, []byte(commitPathsSeparator))
+	if !ok {
+		return string(content), nil
+	}
+	for _, p := range bytes.Split(rest, []byte{'\n'}) {
+		if len(p) > 0 {
+			paths = append(paths, string(p))
+		}
+	}
+	return string(msg), paths
+}
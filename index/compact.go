@@ -0,0 +1,246 @@
+package index
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"slices"
+)
+
+// CompactionThresholds define when the delta shards of a repository are
+// compacted. A zero field disables the corresponding check.
+type CompactionThresholds struct {
+	// MaxDeltaShards is the number of delta shards at which a repository is
+	// compacted.
+	MaxDeltaShards int
+
+	// MaxDeltaBytes is the total size of the delta shards at which a
+	// repository is compacted.
+	MaxDeltaBytes int64
+}
+
+// deltaShards returns the number and total size of the shards in shards
+// which were written by delta builds. The shards of a build share the ID in
+// their IndexMetadata, so those are the shards whose ID differs from the ID
+// of the first shard.
+func deltaShards(shards []string) (n int, size int64, err error) {
+	var baseID string
+	for i, shard := range shards {
+		_, md, err := ReadMetadataPath(shard)
+		if err != nil {
+			return 0, 0, err
+		}
+		if i == 0 {
+			baseID = md.ID
+			continue
+		}
+		if md.ID == baseID {
+			continue
+		}
+		fi, err := os.Stat(shard)
+		if err != nil {
+			return 0, 0, err
+		}
+		n++
+		size += fi.Size()
+	}
+	return n, size, nil
+}
+
+// NeedsCompaction reports whether the delta shards of the repository in o
+// exceed t.
+func (o *Options) NeedsCompaction(t CompactionThresholds) (bool, error) {
+	shards := o.FindAllShards()
+	if len(shards) <= 1 {
+		return false, nil
+	}
+	n, size, err := deltaShards(shards)
+	if err != nil {
+		return false, err
+	}
+	return (t.MaxDeltaShards > 0 && n >= t.MaxDeltaShards) ||
+		(t.MaxDeltaBytes > 0 && size >= t.MaxDeltaBytes), nil
+}
+
+// CompactDeltaShards folds the shards of the repository in opts, a normal
+// build followed by delta builds, into new shards which contain only the
+// live documents and no FileTombstones. Like a normal build, a new shard is
+// started once opts.ShardMax bytes have been added, so usually the result is
+// a single shard. The repository metadata is taken from the newest shard.
+//
+// Each new shard atomically replaces the shard with its name, and the
+// remaining old shards are removed from the highest number down. In
+// between, searchers see some documents twice, but never miss one. If
+// compaction is interrupted, the next one repairs the shards: a document is
+// live only in the last shard containing its path.
+func CompactDeltaShards(opts Options) error {
+	shards := opts.FindAllShards()
+	if len(shards) <= 1 {
+		return nil
+	}
+
+	var ds []*indexData
+	defer func() {
+		for _, d := range ds {
+			d.Close()
+		}
+	}()
+	for _, shard := range shards {
+		d, err := openShard(shard)
+		if err != nil {
+			return fmt.Errorf("compacting %s: %w", shard, err)
+		}
+		ds = append(ds, d)
+		if len(d.repoMetaData) != 1 {
+			return fmt.Errorf("compacting %s: compound shards are not supported", shard)
+		}
+	}
+
+	// A document is live if its path is not tombstoned in its shard and not
+	// in a later shard.
+	live := make([][]bool, len(ds))
+	later := map[string]struct{}{}
+	for i := len(ds) - 1; i >= 0; i-- {
+		d := ds[i]
+		tombstones := d.repoMetaData[0].FileTombstones
+		live[i] = make([]bool, d.numDocs())
+		var names []string
+		for docID := range live[i] {
+			name := string(d.fileName(uint32(docID)))
+			if _, ok := tombstones[name]; ok {
+				continue
+			}
+			if _, ok := later[name]; ok {
+				continue
+			}
+			live[i][docID] = true
+			names = append(names, name)
+		}
+		for _, name := range names {
+			later[name] = struct{}{}
+		}
+	}
+
+	base, newest := ds[0], ds[len(ds)-1]
+	repo := newest.repoMetaData[0]
+	repo.FileTombstones = nil
+	if repo.Metadata == nil {
+		repo.Metadata = make(map[string]string)
+	}
+
+	shardMax := opts.ShardMax
+	if shardMax == 0 {
+		shardMax = 100 << 20
+	} else if shardMax > maxShardMax {
+		shardMax = maxShardMax
+	}
+
+	// Like in merge, the token index is kept if all shards have one.
+	tokens := !slices.ContainsFunc(ds, func(d *indexData) bool { return !d.tokens.enabled() })
+
+	newShard := func() (*ShardBuilder, error) {
+		sb := newShardBuilder()
+		sb.indexFormatVersion = base.metaData.IndexFormatVersion
+		sb.contentEncoding = base.metaData.ContentEncoding
+		if tokens {
+			sb.tokens = map[string][]uint32{}
+		}
+		sb.IndexTime = newest.metaData.IndexTime
+		sb.ID = newest.metaData.ID
+		return sb, sb.setRepository(&repo)
+	}
+
+	// tmpNames are the new shards, numbered from 0.
+	var tmpNames []string
+	defer func() {
+		for _, tmpName := range tmpNames {
+			os.Remove(tmpName)
+		}
+	}()
+	writeShard := func(sb *ShardBuilder) error {
+		tmpName := opts.shardNameVersion(sb.indexFormatVersion, len(tmpNames)) + ".compact.tmp"
+		tmpNames = append(tmpNames, tmpName)
+		return builderWriteAll(tmpName, sb)
+	}
+
+	sb, err := newShard()
+	if err != nil {
+		return err
+	}
+	for i, d := range ds {
+		for docID, ok := range live[i] {
+			if !ok {
+				continue
+			}
+			if err := addDocument(d, sb, 0, uint32(docID)); err != nil {
+				return err
+			}
+			if int(sb.ContentSize()) < shardMax {
+				continue
+			}
+			if err := writeShard(sb); err != nil {
+				return err
+			}
+			if sb, err = newShard(); err != nil {
+				return err
+			}
+		}
+	}
+	if sb.NumFiles() > 0 || len(tmpNames) == 0 {
+		if err := writeShard(sb); err != nil {
+			return err
+		}
+	}
+	if len(tmpNames) > len(shards) {
+		return fmt.Errorf("compacting %d shards of %s resulted in %d shards", len(shards), repo.Name, len(tmpNames))
+	}
+
+	// The .meta files belong to the old shards. They are removed after the
+	// rename, so that the stale tombstones only hide documents which are
+	// also in the later shards.
+	n := len(tmpNames)
+	for i, tmpName := range tmpNames {
+		if err := os.Rename(tmpName, shards[i]); err != nil {
+			return err
+		}
+		if err := os.Remove(shards[i] + ".meta"); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	tmpNames = nil
+
+	// Removing the highest numbers first keeps the numbering contiguous for
+	// FindAllShards.
+	for i := len(shards) - 1; i >= n; i-- {
+		paths, err := IndexFilePaths(shards[i])
+		if err != nil {
+			return err
+		}
+		for _, p := range paths {
+			if err := os.Remove(p); err != nil {
+				return err
+			}
+		}
+	}
+
+	log.Printf("compacted %d shards of %s into %d", len(shards), repo.Name, n)
+	return nil
+}
+
+// openShard loads the shard at path. Its .meta file is applied.
+func openShard(path string) (*indexData, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	iFile, err := NewIndexFile(f)
+	if err != nil {
+		return nil, err
+	}
+	searcher, err := NewSearcher(iFile)
+	if err != nil {
+		iFile.Close()
+		return nil, err
+	}
+	return searcher.(*indexData), nil
+}
@@ -0,0 +1,181 @@
+package index
+
+import (
+	"maps"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/zoekt"
+)
+
+func TestCompactDeltaShards(t *testing.T) {
+	indexDir := t.TempDir()
+	opts := Options{
+		IndexDir:              indexDir,
+		RepositoryDescription: zoekt.Repository{Name: "repo", ID: 1},
+		DisableCTags:          true,
+		IsDelta:               true,
+		DeltaByChecksum:       true,
+	}
+
+	build := func(files map[string]string) {
+		t.Helper()
+		b, err := NewBuilder(opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range slices.Sorted(maps.Keys(files)) {
+			if err := b.AddFile(name, []byte(files[name])); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := b.Finish(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// contents returns the contents of the documents in the shards by name,
+	// failing on duplicates.
+	contents := func(shards []string) map[string]string {
+		t.Helper()
+		got := map[string]string{}
+		for _, shard := range shards {
+			s, err := loadShard(shard)
+			if err != nil {
+				t.Fatal(err)
+			}
+			d := s.(*indexData)
+			for i := uint32(0); i < d.numDocs(); i++ {
+				name := string(d.fileName(i))
+				if _, ok := d.repoMetaData[0].FileTombstones[name]; ok {
+					continue
+				}
+				if _, ok := got[name]; ok {
+					t.Fatalf("%s is live in more than one shard", name)
+				}
+				c, err := d.readContents(i)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got[name] = string(c)
+			}
+			s.Close()
+		}
+		return got
+	}
+
+	build(map[string]string{"a.go": "package a", "b.go": "package b", "c.go": "package c"})
+	build(map[string]string{"a.go": "package a", "b.go": "package b2", "d.go": "package d"})
+	build(map[string]string{"a.go": "package a", "b.go": "package b3", "d.go": "package d", "e.go": "package e"})
+
+	shards := opts.FindAllShards()
+	if len(shards) != 3 {
+		t.Fatalf("got shards %v, want 3", shards)
+	}
+	want := contents(shards)
+
+	for _, tc := range []struct {
+		thresholds CompactionThresholds
+		want       bool
+	}{
+		{CompactionThresholds{}, false},
+		{CompactionThresholds{MaxDeltaShards: 2}, true},
+		{CompactionThresholds{MaxDeltaShards: 3}, false},
+		{CompactionThresholds{MaxDeltaBytes: 1}, true},
+		{CompactionThresholds{MaxDeltaBytes: 1 << 30}, false},
+	} {
+		got, err := opts.NeedsCompaction(tc.thresholds)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.want {
+			t.Errorf("NeedsCompaction(%+v): got %v, want %v", tc.thresholds, got, tc.want)
+		}
+	}
+
+	if err := CompactDeltaShards(opts); err != nil {
+		t.Fatal(err)
+	}
+
+	shards = opts.FindAllShards()
+	if len(shards) != 1 {
+		t.Fatalf("got shards %v after compaction, want 1", shards)
+	}
+	if _, err := os.Stat(shards[0] + ".meta"); !os.IsNotExist(err) {
+		t.Errorf("got .meta file after compaction: %v", err)
+	}
+	if d := cmp.Diff(want, contents(shards)); d != "" {
+		t.Errorf("documents after compaction (-want +got):\n%s", d)
+	}
+	if got, err := opts.NeedsCompaction(CompactionThresholds{MaxDeltaShards: 1}); err != nil || got {
+		t.Errorf("NeedsCompaction after compaction: got %v, %v", got, err)
+	}
+
+	// Delta builds continue on top of the compacted shard.
+	build(map[string]string{"a.go": "package a4", "b.go": "package b3", "d.go": "package d", "e.go": "package e"})
+	shards = opts.FindAllShards()
+	if len(shards) != 2 {
+		t.Fatalf("got shards %v, want 2", shards)
+	}
+	want = map[string]string{"a.go": "package a4", "b.go": "package b3", "d.go": "package d", "e.go": "package e"}
+	if d := cmp.Diff(want, contents(shards)); d != "" {
+		t.Errorf("documents after delta build (-want +got):\n%s", d)
+	}
+}
+
+func TestCompactDeltaShards_interrupted(t *testing.T) {
+	indexDir := t.TempDir()
+	opts := Options{
+		IndexDir:              indexDir,
+		RepositoryDescription: zoekt.Repository{Name: "repo", ID: 1},
+		DisableCTags:          true,
+		IsDelta:               true,
+		DeltaByChecksum:       true,
+	}
+	for _, content := range []string{"package a", "package a2"} {
+		b, err := NewBuilder(opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := b.AddFile("a.go", []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.Finish(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// An interrupted compaction leaves the first shard without the
+	// tombstone of a.go.
+	shards := opts.FindAllShards()
+	if len(shards) != 2 {
+		t.Fatalf("got shards %v, want 2", shards)
+	}
+	if err := os.Remove(shards[0] + ".meta"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := CompactDeltaShards(opts); err != nil {
+		t.Fatal(err)
+	}
+
+	shards = opts.FindAllShards()
+	if len(shards) != 1 {
+		t.Fatalf("got shards %v after compaction, want 1", shards)
+	}
+	s, err := loadShard(shards[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	d := s.(*indexData)
+	if d.numDocs() != 1 {
+		t.Fatalf("got %d documents, want 1", d.numDocs())
+	}
+	if c, err := d.readContents(0); err != nil || string(c) != "package a2" {
+		t.Errorf("got contents %q, %v, want %q", c, err, "package a2")
+	}
+}
@@ -0,0 +1,208 @@
+package index
+
+import (
+	"container/list"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// ContentEncodingZstd stores document contents as zstd frames, each holding
+// the concatenated contents of IndexMetadata.ContentBlockDocs documents.
+const ContentEncodingZstd = "zstd"
+
+// defaultContentBlockDocs is the number of documents per compressed block.
+// Larger blocks compress better, but every read decompresses a whole block.
+const defaultContentBlockDocs = 16
+
+// contentBlockCacheSize is the number of decompressed blocks kept per shard.
+// Documents are searched in order, so a few blocks cover the documents a
+// search is looking at.
+const contentBlockCacheSize = 8
+
+var (
+	zstdEncoderOnce sync.Once
+	zstdEncoder     *zstd.Encoder
+	zstdDecoderOnce sync.Once
+	zstdDecoder     *zstd.Decoder
+)
+
+// getZstdEncoder returns an encoder for EncodeAll, which is safe for
+// concurrent use.
+func getZstdEncoder() *zstd.Encoder {
+	zstdEncoderOnce.Do(func() {
+		zstdEncoder, _ = zstd.NewWriter(nil)
+	})
+	return zstdEncoder
+}
+
+// getZstdDecoder returns a decoder for DecodeAll, which is safe for
+// concurrent use.
+func getZstdDecoder() *zstd.Decoder {
+	zstdDecoderOnce.Do(func() {
+		zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+	})
+	return zstdDecoder
+}
+
+// writeContentBlocks writes the contents of docs as zstd blocks of
+// blockDocs documents into blocks, and the offsets of the uncompressed
+// contents, including the end of the last document, into offsets.
+func writeContentBlocks(w *writer, docs []*searchableString, blockDocs int, blocks *compoundSection, offsets *simpleSection) {
+	enc := getZstdEncoder()
+
+	blocks.start(w)
+	var block, compressed []byte
+	for i := 0; i < len(docs); i += blockDocs {
+		block = block[:0]
+		for _, d := range docs[i:min(i+blockDocs, len(docs))] {
+			block = append(block, d.data...)
+		}
+		compressed = enc.EncodeAll(block, compressed[:0])
+		blocks.addItem(w, compressed)
+	}
+	blocks.end(w)
+
+	offsets.start(w)
+	var off uint32
+	for _, d := range docs {
+		w.U32(off)
+		off += uint32(len(d.data))
+	}
+	w.U32(off)
+	offsets.end(w)
+}
+
+// contentBlocks reads compressed document contents.
+type contentBlocks struct {
+	file IndexFile
+
+	// start and index locate the compressed blocks in file.
+	start uint64
+	index []uint32
+
+	// docs is the number of documents per block.
+	docs uint32
+
+	cache *blockCache
+}
+
+// block returns the decompressed block i.
+func (c *contentBlocks) block(i uint32) ([]byte, error) {
+	if b, ok := c.cache.get(i); ok {
+		return b, nil
+	}
+	if int(i)+1 >= len(c.index) {
+		return nil, fmt.Errorf("content block %d out of range", i)
+	}
+	blob, err := c.file.Read(c.start+uint64(c.index[i]), uint64(c.index[i+1]-c.index[i]))
+	if err != nil {
+		return nil, err
+	}
+	b, err := getZstdDecoder().DecodeAll(blob, nil)
+	if err != nil {
+		return nil, fmt.Errorf("content block %d: %w", i, err)
+	}
+	c.cache.add(i, b)
+	return b, nil
+}
+
+// slice returns up to sz bytes from offset off of the contents of doc,
+// given the uncompressed document boundaries. The result does not extend
+// past the block of doc.
+func (c *contentBlocks) slice(boundaries []uint32, doc uint32, off, sz uint32) ([]byte, error) {
+	b, err := c.block(doc / c.docs)
+	if err != nil {
+		return nil, err
+	}
+	base := boundaries[doc/c.docs*c.docs]
+	if off < base || off-base > uint32(len(b)) {
+		return nil, fmt.Errorf("content offset %d out of range of document %d", off, doc)
+	}
+	start := off - base
+	end := min(uint64(start)+uint64(sz), uint64(len(b)))
+	return b[start:end], nil
+}
+
+// readContents returns the contents of doc.
+func (c *contentBlocks) readContents(boundaries []uint32, doc uint32) ([]byte, error) {
+	return c.slice(boundaries, doc, boundaries[doc], boundaries[doc+1]-boundaries[doc])
+}
+
+// readContentSlice returns up to sz bytes from offset off relative to the
+// start of all contents. Unlike slice, it continues into the following
+// blocks, so it only returns less than sz bytes at the end of the contents.
+func (c *contentBlocks) readContentSlice(boundaries []uint32, off, sz uint32) ([]byte, error) {
+	numDocs := uint32(len(boundaries) - 1)
+	// the last document starting at or before off
+	doc := sort.Search(int(numDocs), func(i int) bool { return boundaries[i] > off }) - 1
+	if doc < 0 {
+		return nil, fmt.Errorf("content offset %d out of range", off)
+	}
+	end := min(uint64(off)+uint64(sz), uint64(boundaries[numDocs]))
+
+	var out []byte
+	for d := uint32(doc); d < numDocs && uint64(off) < end; d = (d/c.docs + 1) * c.docs {
+		b, err := c.slice(boundaries, d, off, uint32(end-uint64(off)))
+		if err != nil {
+			return nil, err
+		}
+		if out == nil && uint64(off)+uint64(len(b)) == end {
+			// All of it is in one block, which is the common case.
+			return b, nil
+		}
+		out = append(out, b...)
+		off += uint32(len(b))
+	}
+	return out, nil
+}
+
+// blockCache is a small LRU cache of blocks, such as decompressed contents
+// or chunks of a file.
+type blockCache struct {
+	mu    sync.Mutex
+	size  int
+	lru   *list.List
+	items map[uint32]*list.Element
+}
+
+type blockCacheEntry struct {
+	block uint32
+	data  []byte
+}
+
+func newBlockCache(size int) *blockCache {
+	return &blockCache{
+		size:  size,
+		lru:   list.New(),
+		items: make(map[uint32]*list.Element, size),
+	}
+}
+
+func (c *blockCache) get(block uint32) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[block]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(e)
+	return e.Value.(*blockCacheEntry).data, true
+}
+
+func (c *blockCache) add(block uint32, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[block]; ok {
+		c.lru.MoveToFront(e)
+		return
+	}
+	c.items[block] = c.lru.PushFront(&blockCacheEntry{block: block, data: data})
+	if c.lru.Len() > c.size {
+		e := c.lru.Back()
+		c.lru.Remove(e)
+		delete(c.items, e.Value.(*blockCacheEntry).block)
+	}
+}
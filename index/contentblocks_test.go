@@ -0,0 +1,129 @@
+package index
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/zoekt"
+	"github.com/sourcegraph/zoekt/query"
+)
+
+// searchContent searches docs for "needle" in a shard with the given content
+// encoding.
+func searchContent(t *testing.T, docs []Document, encoding string) ([]zoekt.FileMatch, *indexData) {
+	t.Helper()
+	b := testShardBuilder(t, &zoekt.Repository{Name: "repo"}, docs...)
+	b.contentEncoding = encoding
+
+	var buf bytes.Buffer
+	if err := b.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	searcher, err := NewSearcher(&memSeeker{buf.Bytes()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(searcher.Close)
+
+	res, err := searcher.Search(context.Background(), &query.Substring{Pattern: "needle", Content: true}, &zoekt.SearchOptions{Whole: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	clearScores(res)
+	return res.Files, searcher.(*indexData)
+}
+
+func TestCompressedContent(t *testing.T) {
+	// more documents than fit in a block, with multi-byte runes so that
+	// match offsets go through readContentSlice.
+	var docs []Document
+	for i := range 2*defaultContentBlockDocs + 3 {
+		docs = append(docs, Document{
+			Name:    fmt.Sprintf("f%d.txt", i),
+			Content: []byte(fmt.Sprintf("%s\nnäive needle%d\n", strings.Repeat("ü", i*100), i)),
+		})
+	}
+
+	want, raw := searchContent(t, docs, "")
+	if len(want) != len(docs) {
+		t.Fatalf("got %d files, want %d", len(want), len(docs))
+	}
+	got, d := searchContent(t, docs, ContentEncodingZstd)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("results differ (-raw +zstd):\n%s", diff)
+	}
+
+	if d.metaData.ContentEncoding != ContentEncodingZstd || d.metaData.ContentBlockDocs != defaultContentBlockDocs {
+		t.Errorf("got encoding %q with %d docs per block", d.metaData.ContentEncoding, d.metaData.ContentBlockDocs)
+	}
+	if d.metaData.IndexMinReaderVersion != 16 {
+		t.Errorf("got min reader version %d, want 16", d.metaData.IndexMinReaderVersion)
+	}
+	if diff := cmp.Diff(raw.boundaries, d.boundaries); diff != "" {
+		t.Errorf("boundaries differ (-raw +zstd):\n%s", diff)
+	}
+	for i := range docs {
+		c, err := d.readContents(uint32(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(c, docs[i].Content) {
+			t.Errorf("document %d: got %q", i, c)
+		}
+	}
+}
+
+func TestBlockCache(t *testing.T) {
+	c := newBlockCache(2)
+	c.add(1, []byte("1"))
+	c.add(2, []byte("2"))
+	if _, ok := c.get(1); !ok {
+		t.Fatal("block 1 missing")
+	}
+	// evicts 2, the least recently used
+	c.add(3, []byte("3"))
+	if _, ok := c.get(2); ok {
+		t.Error("block 2 not evicted")
+	}
+	for _, b := range []uint32{1, 3} {
+		if _, ok := c.get(b); !ok {
+			t.Errorf("block %d missing", b)
+		}
+	}
+}
+
+func TestCompressedContent_blockBoundary(t *testing.T) {
+	// Short documents with multi-byte runes, so that rune offset samples
+	// sit in one block while the matches are in the next.
+	var docs []Document
+	var all []byte
+	for i := range 2*defaultContentBlockDocs + 3 {
+		content := []byte(fmt.Sprintf("üü needle%d\n", i))
+		docs = append(docs, Document{Name: fmt.Sprintf("f%d.txt", i), Content: content})
+		all = append(all, content...)
+	}
+
+	want, _ := searchContent(t, docs, "")
+	if len(want) != len(docs) {
+		t.Fatalf("got %d files, want %d", len(want), len(docs))
+	}
+	got, d := searchContent(t, docs, ContentEncodingZstd)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("results differ (-raw +zstd):\n%s", diff)
+	}
+
+	// A slice across the block boundary has all of the requested bytes.
+	off := d.boundaries[defaultContentBlockDocs] - 4
+	b, err := d.contentBlocks.readContentSlice(d.boundaries, off, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := all[off : off+8]; !bytes.Equal(b, want) {
+		t.Errorf("got %q across the block boundary, want %q", b, want)
+	}
+}
@@ -16,10 +16,12 @@ package index
 
 import (
 	"bytes"
+	"cmp"
 	"log"
 	"path"
 	"slices"
 	"sort"
+	"strings"
 	"unicode"
 	"unicode/utf8"
 
@@ -909,6 +911,42 @@ func SortFiles(ms []zoekt.FileMatch) {
 	boostNovelExtension(ms, 2, 0.9)
 }
 
+// SortFilesBy sorts file matches in the order requested by by. SortByScore
+// is the same as SortFiles. The other orders only depend on the file
+// metadata, so they are stable across searches.
+func SortFilesBy(ms []zoekt.FileMatch, by zoekt.SortBy) {
+	byRepo := func(a, b *zoekt.FileMatch) int {
+		return cmp.Or(
+			strings.Compare(a.Repository, b.Repository),
+			strings.Compare(a.FileName, b.FileName),
+			strings.Compare(a.Version, b.Version),
+		)
+	}
+
+	switch by {
+	case zoekt.SortByPath:
+		slices.SortFunc(ms, func(a, b zoekt.FileMatch) int {
+			return cmp.Or(
+				strings.Compare(a.FileName, b.FileName),
+				byRepo(&a, &b),
+			)
+		})
+	case zoekt.SortByRepo:
+		slices.SortFunc(ms, func(a, b zoekt.FileMatch) int {
+			return byRepo(&a, &b)
+		})
+	case zoekt.SortByCommitDate:
+		slices.SortFunc(ms, func(a, b zoekt.FileMatch) int {
+			return cmp.Or(
+				b.CommitDate.Compare(a.CommitDate),
+				byRepo(&a, &b),
+			)
+		})
+	default:
+		SortFiles(ms)
+	}
+}
+
 func boostNovelExtension(ms []zoekt.FileMatch, boostOffset int, minScoreRatio float64) {
 	if len(ms) <= boostOffset+1 {
 		return
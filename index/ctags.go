@@ -84,6 +84,7 @@ func parseSymbols(todo []*Document, languageMap ctags.LanguageMap, parserBins ct
 		}
 		doc.Symbols = symOffsets
 		doc.SymbolsMetaData = symMetaData
+		doc.SymbolScopes = symbolScopes(doc.Content, symOffsets, symMetaData)
 	}
 
 	return nil
@@ -177,6 +178,56 @@ func (t *tagsToSections) Convert(content []byte, tags []*ctags.Entry) ([]Documen
 	return symOffsets, symMetaData, nil
 }
 
+// symbolScopes returns the byte range enclosing the definition of each symbol
+// in secs. ctags only tells us where a symbol starts, so we approximate: a
+// scope starts at the beginning of the line of its symbol and ends at the
+// beginning of the line of the next symbol which is not nested in it, or at the
+// end of content.
+//
+// secs must be sorted and metaData must be parallel to secs.
+func symbolScopes(content []byte, secs []DocumentSection, metaData []*zoekt.Symbol) []DocumentSection {
+	lineStart := func(off uint32) uint32 {
+		return uint32(bytes.LastIndexByte(content[:off], '\n') + 1)
+	}
+
+	scopes := make([]DocumentSection, len(secs))
+	for i, sec := range secs {
+		scopes[i] = DocumentSection{
+			Start: lineStart(sec.Start),
+			End:   uint32(len(content)),
+		}
+		name := symbolScopeName(metaData[i])
+		for j := i + 1; j < len(secs); j++ {
+			if !isNestedScope(metaData[j].Parent, name) {
+				scopes[i].End = max(scopes[i].Start, lineStart(secs[j].Start))
+				break
+			}
+		}
+	}
+	return scopes
+}
+
+// symbolScopeName returns the scope name that symbols defined inside sym would
+// have as their parent, eg. "HttpClient.retry".
+func symbolScopeName(sym *zoekt.Symbol) string {
+	if sym.Parent == "" {
+		return sym.Sym
+	}
+	return sym.Parent + "." + sym.Sym
+}
+
+// isNestedScope returns true if parent is the scope name or a scope inside of
+// it. ctags separates scopes with "." for most languages and "::" for C++ and
+// Rust.
+func isNestedScope(parent, name string) bool {
+	if parent == "" {
+		return false
+	}
+	parent = strings.ReplaceAll(parent, "::", ".")
+	name = strings.ReplaceAll(name, "::", ".")
+	return parent == name || strings.HasPrefix(parent, name+".")
+}
+
 // newLinesIndices returns an array of all indexes of '\n' aswell as a final
 // value for the length of the document.
 func (t *tagsToSections) newLinesIndices(in []byte) []uint32 {
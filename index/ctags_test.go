@@ -19,6 +19,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/sourcegraph/zoekt"
 	"github.com/sourcegraph/zoekt/internal/ctags"
 )
 
@@ -306,3 +307,48 @@ func requireCTags(tb testing.TB) {
 		tb.Skip("universal-ctags is missing")
 	}
 }
+
+func TestSymbolScopes(t *testing.T) {
+	c := []byte("class Client {\n  void retry() {}\n  void close() {}\n}\nvoid main() {}\n")
+
+	secs := []DocumentSection{
+		{Start: 6, End: 12},
+		{Start: 22, End: 27},
+		{Start: 40, End: 45},
+		{Start: 58, End: 62},
+	}
+	metaData := []*zoekt.Symbol{
+		{Sym: "Client"},
+		{Sym: "retry", Parent: "Client"},
+		{Sym: "close", Parent: "Client"},
+		{Sym: "main"},
+	}
+
+	got := symbolScopes(c, secs, metaData)
+	want := []DocumentSection{
+		{Start: 0, End: 53},
+		{Start: 15, End: 33},
+		{Start: 33, End: 53},
+		{Start: 53, End: uint32(len(c))},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestIsNestedScope(t *testing.T) {
+	for _, tc := range []struct {
+		parent, name string
+		want         bool
+	}{
+		{"", "Client", false},
+		{"Client", "Client", true},
+		{"Client.retry", "Client", true},
+		{"ns::Client", "ns.Client", true},
+		{"ClientPool", "Client", false},
+	} {
+		if got := isNestedScope(tc.parent, tc.name); got != tc.want {
+			t.Errorf("isNestedScope(%q, %q) = %v, want %v", tc.parent, tc.name, got, tc.want)
+		}
+	}
+}
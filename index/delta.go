@@ -0,0 +1,131 @@
+package index
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc64"
+	"os"
+	"sort"
+)
+
+// prepareChecksumDelta reads the content checksums of the existing shards
+// for a DeltaByChecksum build. It returns an error if the build cannot be a
+// delta build on top of them.
+func (b *Builder) prepareChecksumDelta() error {
+	shards := b.opts.FindAllShards()
+	if len(shards) == 0 {
+		return fmt.Errorf("no existing shards found for repository")
+	}
+
+	checksums := map[string]uint64{}
+	for _, shard := range shards {
+		if err := b.readChecksums(shard, checksums); err != nil {
+			return fmt.Errorf("reading checksums from shard %q: %w", shard, err)
+		}
+	}
+
+	b.indexedChecksums = checksums
+	b.addedPaths = map[string]struct{}{}
+	return nil
+}
+
+// readChecksums adds the content checksums of the documents of shard
+// which are not tombstoned to checksums, by path. The same checks as in
+// Finish are applied, so that a delta build which would fail there falls
+// back to a normal build instead.
+func (b *Builder) readChecksums(shard string, checksums map[string]uint64) error {
+	f, err := os.Open(shard)
+	if err != nil {
+		return err
+	}
+	iFile, err := NewIndexFile(f)
+	if err != nil {
+		return err
+	}
+	defer iFile.Close()
+
+	rd := &reader{r: iFile}
+	var toc indexTOC
+	if err := rd.readTOCSections(&toc, []string{"metaData", "repoMetaData", "fileNames", "contentChecksums"}); err != nil {
+		return err
+	}
+	repos, _, err := rd.parseMetadata(toc.metaData, toc.repoMetaData)
+	if err != nil {
+		return err
+	}
+
+	if len(repos) != 1 {
+		return fmt.Errorf("delta shard builds don't support compound shards")
+	}
+	repository := repos[0]
+	if repository.ID != b.opts.RepositoryDescription.ID {
+		return fmt.Errorf("shard doesn't contain repository ID %d (%q)", b.opts.RepositoryDescription.ID, b.opts.RepositoryDescription.Name)
+	}
+	if !BranchNamesEqual(repository.Branches, b.opts.RepositoryDescription.Branches) {
+		return deltaBranchSetError{shardName: shard, old: repository.Branches, new: b.opts.RepositoryDescription.Branches}
+	}
+	if b.opts.GetHash() != repository.IndexOptions {
+		return &deltaIndexOptionsMismatchError{shardName: shard, newOptions: b.opts.HashOptions()}
+	}
+
+	names, err := iFile.Read(toc.fileNames.data.off, toc.fileNames.data.sz)
+	if err != nil {
+		return err
+	}
+	nameIndex := toc.fileNames.relativeIndex()
+	sums, err := iFile.Read(toc.contentChecksums.off, toc.contentChecksums.sz)
+	if err != nil {
+		return err
+	}
+	if n := len(nameIndex) - 1; n > 0 && len(sums) != n*crc64.Size {
+		return fmt.Errorf("got %d bytes of content checksums for %d documents", len(sums), n)
+	}
+
+	for i := 0; i+1 < len(nameIndex); i++ {
+		name := string(names[nameIndex[i]:nameIndex[i+1]])
+		if _, ok := repository.FileTombstones[name]; ok {
+			continue
+		}
+		checksums[name] = binary.BigEndian.Uint64(sums[i*crc64.Size:])
+	}
+	return nil
+}
+
+// unchanged reports whether doc is in the existing shards of a
+// DeltaByChecksum build with the same contents. Documents which changed
+// are marked to be tombstoned in the existing shards.
+func (b *Builder) unchanged(doc *Document) bool {
+	b.addedPaths[doc.Name] = struct{}{}
+
+	sum, ok := b.indexedChecksums[doc.Name]
+	if !ok {
+		return false
+	}
+
+	// The checksum is of the contents as stored, see ShardBuilder.Add.
+	content := doc.Content
+	if doc.SkipReason != SkipReasonNone {
+		content = []byte(notIndexedMarker + doc.SkipReason.explanation())
+	}
+	if crc64.Checksum(content, crc64.MakeTable(crc64.ISO)) == sum {
+		return true
+	}
+
+	b.MarkFileAsChangedOrRemoved(doc.Name)
+	return false
+}
+
+// markRemoved marks the documents of the existing shards of a
+// DeltaByChecksum build which were not added again to be tombstoned.
+func (b *Builder) markRemoved() {
+	var removed []string
+	for name := range b.indexedChecksums {
+		if _, ok := b.addedPaths[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(removed)
+	for _, name := range removed {
+		b.MarkFileAsChangedOrRemoved(name)
+	}
+}
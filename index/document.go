@@ -1,6 +1,10 @@
 package index
 
-import "github.com/sourcegraph/zoekt"
+import (
+	"time"
+
+	"github.com/sourcegraph/zoekt"
+)
 
 // Document holds a document (file) to index.
 type Document struct {
@@ -16,6 +20,25 @@ type Document struct {
 	// Document sections for symbols. Offsets should use bytes.
 	Symbols         []DocumentSection
 	SymbolsMetaData []*zoekt.Symbol
+
+	// SymbolScopes holds the byte range enclosing the definition of each
+	// symbol, eg. the body of a method. It is parallel to Symbols and scopes
+	// may nest. If nil, the scope of a symbol is its section in Symbols.
+	SymbolScopes []DocumentSection
+
+	// CommitDate is the committer date of the last commit that touched the
+	// document, if known. It is used to sort results by zoekt.SortByCommitDate.
+	CommitDate time.Time
+
+	// CommitHash and CommitAuthor identify the last commit that touched the
+	// document, if known.
+	CommitHash   string
+	CommitAuthor string
+
+	// ACL holds the labels, eg. group names, of the callers which may see the
+	// document. A document without labels is visible to everyone who may see
+	// its repository. See auth.Groups for how callers present their labels.
+	ACL []string
 }
 
 type SkipReason int
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"path/filepath"
 	"regexp/syntax"
 	"sort"
 	"strings"
@@ -118,6 +119,18 @@ func (d *indexData) simplify(in query.Q) query.Q {
 			if !has {
 				return &query.Const{Value: false}
 			}
+		case *query.Type:
+			if r.Type == query.TypeCommit && !d.hasCommitDocs {
+				return &query.Const{Value: false}
+			}
+		case *query.Author:
+			if len(d.commitsIndex) == 0 {
+				return &query.Const{Value: false}
+			}
+		case *query.CommitDate:
+			if len(d.commitDates) == 0 {
+				return &query.Const{Value: false}
+			}
 		case *query.Meta:
 			return d.simplifyMultiRepo(q, func(repo *zoekt.Repository) bool {
 				if repo.Metadata == nil {
@@ -155,18 +168,65 @@ func (d *indexData) Search(ctx context.Context, q query.Q, opts *zoekt.SearchOpt
 	}
 
 	q = d.simplify(q)
+
+	// visible is set if the caller may not see some documents of the shard,
+	// see Document.ACL. Stats computed over all documents then don't say
+	// anything about the hidden ones.
+	visible := d.acls.visibleFunc(ctx)
+	if visible != nil {
+		defer func() {
+			if sr == nil {
+				return
+			}
+			redactStats(&sr.Stats)
+			// The pruned tree and the chosen ngrams depend on the
+			// contents of the hidden documents too.
+			for i := range sr.Explain {
+				sr.Explain[i].MatchTree = ""
+				sr.Explain[i].Ngrams = nil
+				sr.Explain[i].BruteForce = false
+			}
+		}()
+	}
+
+	var explain *zoekt.ShardExplain
+	if opts.Explain {
+		explain = &zoekt.ShardExplain{
+			Shard: filepath.Base(d.file.Name()),
+			Query: q.String(),
+		}
+		defer func() {
+			if sr == nil {
+				return
+			}
+			explain.MatchTreeConstruction = sr.Stats.MatchTreeConstruction
+			explain.MatchTreeSearch = sr.Stats.MatchTreeSearch
+			explain.FilesConsidered = sr.Stats.FilesConsidered
+			explain.FileCount = sr.Stats.FileCount
+			sr.Explain = append(sr.Explain, *explain)
+		}()
+	}
+
 	if c, ok := q.(*query.Const); ok && !c.Value {
 		return &res, nil
 	}
 
 	if opts.EstimateDocCount {
 		res.Stats.ShardFilesConsidered = len(d.fileBranchMasks)
+		if visible != nil {
+			res.Stats.ShardFilesConsidered = countVisible(visible, 0, uint32(len(d.fileBranchMasks)))
+		}
 		return &res, nil
 	}
 
+	// Commit documents are only returned for type:commit queries.
+	if d.hasCommitDocs && !hasTypeCommit(q) {
+		q = query.NewAnd(q, &query.Not{Child: &query.Type{Type: query.TypeCommit, Child: &query.Const{Value: true}}})
+	}
+
 	q = query.Map(q, query.ExpandFileContent)
 
-	mt, err := d.newMatchTree(q, matchTreeOpt{})
+	mt, err := d.newMatchTree(q, matchTreeOpt{visible: visible})
 	if err != nil {
 		return nil, err
 	}
@@ -179,8 +239,17 @@ func (d *indexData) Search(ctx context.Context, q query.Q, opts *zoekt.SearchOpt
 		return nil, err
 	}
 	res.Stats.MatchTreeConstruction = timer.Elapsed()
+	if explain != nil && mt != nil {
+		explainMatchTree(explain, mt)
+	}
 	if mt == nil {
-		res.Stats.ShardsSkippedFilter++
+		// Whether the ngram filter rejects the shard depends on the hidden
+		// documents too.
+		if visible != nil {
+			res.Stats.ShardsScanned++
+		} else {
+			res.Stats.ShardsSkippedFilter++
+		}
 		return &res, nil
 	}
 
@@ -201,6 +270,12 @@ func (d *indexData) Search(ctx context.Context, q query.Q, opts *zoekt.SearchOpt
 	docCount := uint32(len(d.fileBranchMasks))
 	lastDoc := int(-1)
 
+	// When paginating, the caller has already seen the documents before the
+	// cursor and only needs a page worth of files.
+	if opts.Cursor != nil {
+		lastDoc = int(opts.Cursor.Doc) - 1
+	}
+
 nextFileMatch:
 	for {
 		canceled := false
@@ -230,6 +305,12 @@ nextFileMatch:
 				continue
 			}
 
+			// 🚨 SECURITY: Skip documents the caller may not see before they are
+			// matched, so that they don't count towards any limits or stats.
+			if visible != nil && !visible(nextDoc) {
+				continue
+			}
+
 			// Skip documents that are tombstoned
 			if len(repoMetadata.FileTombstones) > 0 {
 				if _, tombstoned := repoMetadata.FileTombstones[string(d.fileName(nextDoc))]; tombstoned {
@@ -261,7 +342,15 @@ nextFileMatch:
 		}
 
 		if canceled || (res.Stats.MatchCount >= opts.ShardMaxMatchCount && opts.ShardMaxMatchCount > 0) {
-			res.Stats.FilesSkipped += int(docCount - nextDoc)
+			if visible != nil {
+				res.Stats.FilesSkipped += countVisible(visible, nextDoc, docCount)
+			} else {
+				res.Stats.FilesSkipped += int(docCount - nextDoc)
+			}
+			break
+		}
+
+		if opts.Cursor != nil && opts.MaxDocDisplayCount > 0 && len(res.Files) >= opts.MaxDocDisplayCount {
 			break
 		}
 
@@ -295,6 +384,15 @@ nextFileMatch:
 			FileName:           string(d.fileName(nextDoc)),
 			Checksum:           d.getChecksum(nextDoc),
 			Language:           d.languageMap[d.getLanguage(nextDoc)],
+			CommitDate:         d.getCommitDate(nextDoc),
+		}
+
+		if opts.Cursor != nil {
+			fileMatch.DocID = nextDoc
+		}
+
+		if fileMatch.CommitHash, fileMatch.CommitAuthor, err = d.readCommit(nextDoc); err != nil {
+			return nil, err
 		}
 
 		if s := d.subRepos[nextDoc]; s > 0 {
@@ -348,11 +446,20 @@ nextFileMatch:
 		repoMatchCount += len(fileMatch.LineMatches)
 		repoMatchCount += matchedChunkRanges
 
-		res.Files = append(res.Files, fileMatch)
-
 		res.Stats.MatchCount += len(fileMatch.LineMatches)
 		res.Stats.MatchCount += matchedChunkRanges
+
+		if d.getCategory(nextDoc) == FileCategoryCommit {
+			res.Commits = append(res.Commits, commitMatch(&fileMatch, cp.data(false)))
+			continue
+		}
+
+		res.Files = append(res.Files, fileMatch)
 		res.Stats.FileCount++
+
+		if len(opts.Facets) > 0 {
+			d.countFacets(&res.Facets, opts.Facets, nextDoc, &fileMatch)
+		}
 	}
 
 	for _, md := range d.repoMetaData {
@@ -371,6 +478,36 @@ nextFileMatch:
 	return &res, nil
 }
 
+// hasTypeCommit returns true if q contains a type:commit query.
+func hasTypeCommit(q query.Q) bool {
+	found := false
+	query.Map(q, func(q query.Q) query.Q {
+		if t, ok := q.(*query.Type); ok && t.Type == query.TypeCommit {
+			found = true
+		}
+		return q
+	})
+	return found
+}
+
+// commitMatch converts the match of a commit document to a CommitMatch.
+func commitMatch(fm *zoekt.FileMatch, content []byte) zoekt.CommitMatch {
+	message, paths := parseCommitContent(content)
+	return zoekt.CommitMatch{
+		Repository:   fm.Repository,
+		RepositoryID: fm.RepositoryID,
+		Hash:         fm.CommitHash,
+		Author:       fm.CommitAuthor,
+		Date:         fm.CommitDate,
+		Message:      message,
+		Paths:        paths,
+		Branches:     fm.Branches,
+		LineMatches:  fm.LineMatches,
+		ChunkMatches: fm.ChunkMatches,
+		Score:        fm.Score,
+	}
+}
+
 func addRepo(res *zoekt.SearchResult, repo *zoekt.Repository) {
 	if res.RepoURLs == nil {
 		res.RepoURLs = map[string]string{}
@@ -383,6 +520,36 @@ func addRepo(res *zoekt.SearchResult, repo *zoekt.Repository) {
 	res.LineFragments[repo.Name] = repo.LineFragmentTemplate
 }
 
+// countFacets increments the count of each requested facet for the matching
+// document.
+func (d *indexData) countFacets(facets *zoekt.Facets, names []string, doc uint32, fm *zoekt.FileMatch) {
+	for _, name := range names {
+		switch name {
+		case zoekt.FacetRepository:
+			facets.Inc(name, fm.Repository)
+		case zoekt.FacetLanguage:
+			if fm.Language != "" {
+				facets.Inc(name, fm.Language)
+			}
+		case zoekt.FacetCategory:
+			if c := d.getCategory(doc); c != FileCategoryMissing {
+				facets.Inc(name, c.String())
+			}
+		case zoekt.FacetDirectory:
+			facets.Inc(name, topLevelDir(fm.FileName))
+		}
+	}
+}
+
+// topLevelDir returns the first path component of name including the trailing
+// slash, or "/" if name is in the root directory.
+func topLevelDir(name string) string {
+	if i := strings.IndexByte(name, '/'); i >= 0 {
+		return name[:i+1]
+	}
+	return "/"
+}
+
 // Gather matches from this document. The matches are returned in document
 // order and are non-overlapping. All filename and content matches are
 // returned, with filename matches first.
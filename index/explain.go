@@ -0,0 +1,40 @@
+package index
+
+import (
+	"fmt"
+
+	"github.com/sourcegraph/zoekt"
+)
+
+// explainMatchTree describes the pruned matchTree mt in e: its string form,
+// the ngrams chosen for its substrings, and whether any part of it visits
+// every document. It must be called before mt is evaluated, since the string
+// form includes the state of the iterators.
+func explainMatchTree(e *zoekt.ShardExplain, mt matchTree) {
+	e.MatchTree = fmt.Sprint(mt)
+	visitMatchTree(mt, func(mt matchTree) {
+		switch mt := mt.(type) {
+		case *bruteForceMatchTree:
+			e.BruteForce = true
+		case *substrMatchTree:
+			res, ok := mt.matchIterator.(*ngramIterationResults)
+			if !ok {
+				return
+			}
+			if _, ok := res.matchIterator.(*noMatchTree); ok {
+				return
+			}
+			n := zoekt.NgramExplain{
+				Pattern:     mt.query.Pattern,
+				FileName:    mt.fileName,
+				Ngrams:      []string{res.first.ngram.String()},
+				Frequencies: []uint32{res.firstFreq},
+			}
+			if res.first != res.last {
+				n.Ngrams = append(n.Ngrams, res.last.ngram.String())
+				n.Frequencies = append(n.Frequencies, res.lastFreq)
+			}
+			e.Ngrams = append(e.Ngrams, n)
+		}
+	})
+}
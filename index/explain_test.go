@@ -0,0 +1,69 @@
+package index
+
+import (
+	"regexp/syntax"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/zoekt"
+	"github.com/sourcegraph/zoekt/query"
+)
+
+func TestExplain(t *testing.T) {
+	b := testShardBuilder(t, nil,
+		Document{Name: "f1", Content: []byte("needle in a haystack")},
+		Document{Name: "f2", Content: []byte("just hay")},
+		Document{Name: "f3", Content: []byte("a needle and a needle")},
+	)
+
+	res := searchForTest(t, b, &query.Substring{Pattern: "needle", Content: true}, zoekt.SearchOptions{Explain: true})
+	if len(res.Explain) != 1 {
+		t.Fatalf("got %d explanations, want 1", len(res.Explain))
+	}
+	e := res.Explain[0]
+	if e.Shard != "memseeker" || e.Query != `content_substr:"needle"` {
+		t.Errorf("got shard %q and query %q", e.Shard, e.Query)
+	}
+	if !strings.Contains(e.MatchTree, `substr("needle"`) {
+		t.Errorf("got match tree %s, want a substring", e.MatchTree)
+	}
+	// The ngrams of "needle" are chosen so that they don't overlap.
+	wantNgrams := []zoekt.NgramExplain{{
+		Pattern:     "needle",
+		Ngrams:      []string{"nee", "dle"},
+		Frequencies: []uint32{3, 3},
+	}}
+	if d := cmp.Diff(wantNgrams, e.Ngrams); d != "" {
+		t.Errorf("ngrams (-want +got):\n%s", d)
+	}
+	if e.BruteForce {
+		t.Error("substring query is brute force")
+	}
+	if e.FilesConsidered != 2 || e.FileCount != 2 {
+		t.Errorf("got %d files considered and %d matching, want 2 and 2", e.FilesConsidered, e.FileCount)
+	}
+
+	// A regexp without literals visits all documents.
+	re, err := syntax.Parse("[a-z]+", syntax.Perl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res = searchForTest(t, b, &query.Regexp{Regexp: re, Content: true}, zoekt.SearchOptions{Explain: true})
+	if len(res.Explain) != 1 || !res.Explain[0].BruteForce || len(res.Explain[0].Ngrams) != 0 {
+		t.Errorf("got explanation %+v, want brute force", res.Explain)
+	}
+
+	// A shard which can't match is explained without a match tree.
+	res = searchForTest(t, b, &query.Repo{Regexp: regexp.MustCompile("nomatch")}, zoekt.SearchOptions{Explain: true})
+	if len(res.Explain) != 1 || res.Explain[0].Query != "FALSE" || res.Explain[0].MatchTree != "" {
+		t.Errorf("got explanation %+v, want a false query", res.Explain)
+	}
+
+	// Explanations are only returned on request.
+	if res := searchForTest(t, b, &query.Substring{Pattern: "needle"}); res.Explain != nil {
+		t.Errorf("got explanation %+v without Explain", res.Explain)
+	}
+}
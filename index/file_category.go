@@ -24,9 +24,16 @@ const (
 	FileCategoryDotFile
 	FileCategoryBinary
 	FileCategoryDocumentation
+	// FileCategoryCommit marks a document which holds a commit rather than a
+	// file. It is set by the commit indexer and never determined by go-enry.
+	FileCategoryCommit
 )
 
 func DetermineFileCategory(doc *Document) {
+	if doc.Category == FileCategoryCommit {
+		return
+	}
+
 	if doc.SkipReason == SkipReasonBinary {
 		doc.Category = FileCategoryBinary
 		return
@@ -65,6 +72,33 @@ func (c FileCategory) lowPriority() bool {
 	return c == FileCategoryTest || c == FileCategoryVendored || c == FileCategoryGenerated || c == FileCategoryBinary
 }
 
+func (c FileCategory) String() string {
+	switch c {
+	case FileCategoryMissing:
+		return "missing"
+	case FileCategoryDefault:
+		return "default"
+	case FileCategoryTest:
+		return "test"
+	case FileCategoryVendored:
+		return "vendored"
+	case FileCategoryGenerated:
+		return "generated"
+	case FileCategoryConfig:
+		return "config"
+	case FileCategoryDotFile:
+		return "dotfile"
+	case FileCategoryBinary:
+		return "binary"
+	case FileCategoryDocumentation:
+		return "documentation"
+	case FileCategoryCommit:
+		return "commit"
+	default:
+		return "unknown"
+	}
+}
+
 func (c FileCategory) encode() (byte, error) {
 	switch c {
 	case FileCategoryMissing:
@@ -85,6 +119,8 @@ func (c FileCategory) encode() (byte, error) {
 		return 7, nil
 	case FileCategoryBinary:
 		return 8, nil
+	case FileCategoryCommit:
+		return 9, nil
 	default:
 		return 0, errors.New("unrecognized file category")
 	}
@@ -108,6 +144,8 @@ func decodeCategory(c byte) (FileCategory, error) {
 		return FileCategoryDocumentation, nil
 	case 8:
 		return FileCategoryBinary, nil
+	case 9:
+		return FileCategoryCommit, nil
 	default:
 		return FileCategoryMissing, errors.New("unrecognized file category")
 	}
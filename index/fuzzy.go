@@ -0,0 +1,299 @@
+package index
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sourcegraph/zoekt"
+	"github.com/sourcegraph/zoekt/query"
+)
+
+const (
+	// fuzzyBoost scales the score of the expansions of a fuzzy query, so that
+	// exact matches rank above them.
+	fuzzyBoost = 0.5
+
+	// maxFuzzyExpansions is the maximum number of terms a fuzzy query expands
+	// into per shard.
+	maxFuzzyExpansions = 8
+)
+
+// fuzzyAlphabet are the runes inserted and substituted for the candidates
+// at edit distance 1 from the ngram index. Identifiers are what people
+// misspell.
+const fuzzyAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789_"
+
+type fuzzyCandidate struct {
+	term string
+	dist int
+	freq uint32
+
+	// inDict is set if term is in the symbol dictionary or the token index,
+	// so it is known to occur in the shard.
+	inDict bool
+}
+
+// expandFuzzy rewrites q into a substring query for its pattern ORed with
+// the down weighted substring queries of the terms in the shard within
+// q.MaxDistance() edits of the pattern. The terms come from
+//
+//   - the symbol dictionary and the token index, if any, for all distances.
+//   - the ngram index for distance 1: edits of the pattern whose ngrams are
+//     all in the shard.
+//
+// The terms are ranked by distance, whether they come from a dictionary, and
+// then by the frequency of their least common ngram. Terms contained in other
+// terms are dropped, since they usually just match the same places as
+// fragments.
+//
+// If visible is set, the caller may not see some documents of the shard. The
+// terms are then only those which occur in a visible document, ranked by
+// distance alone, since the dictionaries and frequencies include the hidden
+// documents.
+func (d *indexData) expandFuzzy(q *query.Fuzzy, visible func(doc uint32) bool) query.Q {
+	exact := &query.Substring{
+		Pattern:       q.Pattern,
+		CaseSensitive: q.CaseSensitive,
+		FileName:      q.FileName,
+		Content:       q.Content,
+	}
+
+	maxDist := q.MaxDistance()
+	if maxDist == 0 {
+		return exact
+	}
+
+	fileName := q.FileName && !q.Content
+	ngrams := d.ngrams(fileName)
+	known := map[ngram]uint32{}
+	// freq returns the frequency of the least common ngram of term, which is
+	// 0 if the shard can't contain term.
+	freq := func(term string) uint32 {
+		ngs := splitNGrams([]byte(term))
+		if len(ngs) == 0 {
+			return 0
+		}
+		m := uint32(0)
+		for i, o := range ngs {
+			f, ok := known[o.ngram]
+			if !ok {
+				if q.CaseSensitive {
+					f = uint32(ngrams.Get(o.ngram).sz)
+				} else {
+					for _, v := range generateCaseNgrams(o.ngram) {
+						f += uint32(ngrams.Get(v).sz)
+					}
+				}
+				known[o.ngram] = f
+			}
+			if f == 0 {
+				return 0
+			}
+			if i == 0 || f < m {
+				m = f
+			}
+		}
+		return m
+	}
+
+	norm := func(s string) string {
+		if q.CaseSensitive {
+			return s
+		}
+		return strings.ToLower(s)
+	}
+	pattern := norm(q.Pattern)
+	patternLen := utf8.RuneCountInString(pattern)
+
+	seen := map[string]bool{pattern: true}
+	var candidates []fuzzyCandidate
+	add := func(term string, dist int, inDict bool) {
+		if seen[term] {
+			return
+		}
+		seen[term] = true
+		if f := freq(term); f > 0 {
+			candidates = append(candidates, fuzzyCandidate{term: term, dist: dist, freq: f, inDict: inDict})
+		}
+	}
+	// addTerm adds a dictionary term if it is close enough to the pattern.
+	addTerm := func(term string) {
+		n := utf8.RuneCountInString(term)
+		if n < patternLen-maxDist || n > patternLen+maxDist {
+			return
+		}
+		term = norm(term)
+		if seen[term] {
+			return
+		}
+		if dist := query.EditDistance(pattern, term); dist <= maxDist {
+			add(term, dist, true)
+		}
+	}
+
+	if !fileName {
+		d.symbols.visitNames(addTerm)
+		// The token index is lowercased.
+		if d.tokens.enabled() && !q.CaseSensitive {
+			for i := range d.tokens.numTokens() {
+				addTerm(string(d.tokens.token(i)))
+			}
+		}
+	}
+
+	for _, term := range edits1(pattern, q.CaseSensitive) {
+		add(term, 1, false)
+	}
+
+	if visible != nil {
+		candidates = slices.DeleteFunc(candidates, func(c fuzzyCandidate) bool {
+			return !d.occursVisible(&query.Substring{
+				Pattern:       c.term,
+				CaseSensitive: q.CaseSensitive,
+				FileName:      q.FileName,
+				Content:       q.Content,
+			}, visible)
+		})
+		for i := range candidates {
+			candidates[i].freq = 0
+			candidates[i].inDict = false
+		}
+	}
+
+	slices.SortFunc(candidates, func(a, b fuzzyCandidate) int {
+		if c := cmp.Compare(a.dist, b.dist); c != 0 {
+			return c
+		}
+		if a.inDict != b.inDict {
+			if a.inDict {
+				return -1
+			}
+			return 1
+		}
+		if c := cmp.Compare(b.freq, a.freq); c != 0 {
+			return c
+		}
+		return strings.Compare(a.term, b.term)
+	})
+	var kept []fuzzyCandidate
+	for _, c := range candidates {
+		if !slices.ContainsFunc(candidates, func(o fuzzyCandidate) bool {
+			return o.term != c.term && strings.Contains(o.term, c.term)
+		}) {
+			kept = append(kept, c)
+		}
+	}
+	candidates = kept
+	if len(candidates) > maxFuzzyExpansions {
+		candidates = candidates[:maxFuzzyExpansions]
+	}
+	if len(candidates) == 0 {
+		return exact
+	}
+
+	expansions := make([]query.Q, 0, len(candidates))
+	for _, c := range candidates {
+		expansions = append(expansions, &query.Substring{
+			Pattern:       c.term,
+			CaseSensitive: q.CaseSensitive,
+			FileName:      q.FileName,
+			Content:       q.Content,
+		})
+	}
+	expanded := expansions[0]
+	if len(expansions) > 1 {
+		expanded = query.NewOr(expansions...)
+	}
+	return query.NewOr(exact, &query.Boost{Child: expanded, Boost: fuzzyBoost})
+}
+
+// occursVisible reports whether q matches a document for which visible is
+// true.
+func (d *indexData) occursVisible(q query.Q, visible func(doc uint32) bool) bool {
+	mt, err := d.newMatchTree(query.Map(q, query.ExpandFileContent), matchTreeOpt{})
+	if err != nil {
+		return false
+	}
+	if mt, err = pruneMatchTree(mt); err != nil || mt == nil {
+		return false
+	}
+	cp := &contentProvider{id: d, stats: &zoekt.Stats{}}
+	docCount := uint32(len(d.fileBranchMasks))
+	lastDoc := -1
+nextDoc:
+	for {
+		doc := mt.nextDoc()
+		if int(doc) <= lastDoc {
+			doc = uint32(lastDoc + 1)
+		}
+		for doc < docCount && !d.liveDoc(doc, visible) {
+			doc++
+		}
+		if doc >= docCount {
+			return false
+		}
+		lastDoc = int(doc)
+
+		mt.prepare(doc)
+		cp.setDocument(doc)
+		known := make(map[matchTree]bool)
+		for cost := costMin; cost <= costMax; cost++ {
+			switch evalMatchTree(cp, cost, known, mt) {
+			case matchesFound:
+				return true
+			case matchesNone:
+				continue nextDoc
+			}
+		}
+	}
+}
+
+// liveDoc reports whether doc is visible and not tombstoned.
+func (d *indexData) liveDoc(doc uint32, visible func(doc uint32) bool) bool {
+	if !visible(doc) {
+		return false
+	}
+	md := &d.repoMetaData[d.repos[doc]]
+	if md.Tombstone {
+		return false
+	}
+	_, tombstoned := md.FileTombstones[string(d.fileName(doc))]
+	return !tombstoned
+}
+
+// edits1 returns the strings at edit distance 1 of s: deletions,
+// transpositions of adjacent runes, and substitutions and insertions of the
+// runes of fuzzyAlphabet, in upper case too if caseSensitive.
+func edits1(s string, caseSensitive bool) []string {
+	alphabet := []rune(fuzzyAlphabet)
+	if caseSensitive {
+		alphabet = append(alphabet, []rune(strings.ToUpper(fuzzyAlphabet[:26]))...)
+	}
+
+	rs := []rune(s)
+	var edits []string
+	edit := func(i, j int, mid ...rune) {
+		e := make([]rune, 0, len(rs)+1)
+		e = append(e, rs[:i]...)
+		e = append(e, mid...)
+		e = append(e, rs[j:]...)
+		edits = append(edits, string(e))
+	}
+	for i := range rs {
+		edit(i, i+1)
+		if i+1 < len(rs) && rs[i] != rs[i+1] {
+			edit(i, i+2, rs[i+1], rs[i])
+		}
+	}
+	for i := 0; i <= len(rs); i++ {
+		for _, r := range alphabet {
+			if i < len(rs) && r != rs[i] {
+				edit(i, i+1, r)
+			}
+			edit(i, i, r)
+		}
+	}
+	return edits
+}
@@ -0,0 +1,76 @@
+package index
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/zoekt"
+	"github.com/sourcegraph/zoekt/query"
+)
+
+func TestEdits1(t *testing.T) {
+	edits := map[string]bool{}
+	for _, e := range edits1("abc", false) {
+		edits[e] = true
+	}
+	for _, want := range []string{"bc", "ac", "ab", "bac", "acb", "xbc", "abcd", "_abc"} {
+		if !edits[want] {
+			t.Errorf("edits1 misses %q", want)
+		}
+	}
+	if edits["abc"] || edits["Abc"] {
+		t.Errorf("edits1 contains the input or case variants")
+	}
+}
+
+func TestFuzzy(t *testing.T) {
+	b := testShardBuilder(t, nil,
+		Document{Name: "f1", Content: []byte("func receive(msg string) int { return len(msg) }")},
+		Document{Name: "f2", Content: []byte("n := length(xs)")},
+		Document{Name: "f3", Content: []byte("// recieve is misspelled")},
+	)
+
+	files := func(res *zoekt.SearchResult) []string {
+		var got []string
+		for _, f := range res.Files {
+			got = append(got, f.FileName)
+		}
+		return got
+	}
+
+	for _, tc := range []struct {
+		q         query.Q
+		wantFiles []string
+	}{
+		{&query.Fuzzy{Pattern: "lenght"}, []string{"f2"}},
+		{&query.Fuzzy{Pattern: "Lenght", CaseSensitive: true}, nil},
+		{&query.Fuzzy{Pattern: "lenght", MaxDist: 1, FileName: true}, nil},
+		// Too short to expand.
+		{&query.Fuzzy{Pattern: "lne"}, nil},
+		// The exact match ranks first.
+		{&query.Fuzzy{Pattern: "recieve"}, []string{"f3", "f1"}},
+	} {
+		searcher := searcherForTest(t, b)
+		res, err := searcher.Search(context.Background(), tc.q, &zoekt.SearchOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		SortFiles(res.Files)
+		if d := cmp.Diff(tc.wantFiles, files(res)); d != "" {
+			t.Errorf("%s (-want +got):\n%s", tc.q, d)
+		}
+	}
+
+	// The expansions of a fuzzy query are down weighted substring queries.
+	d := searcherForTest(t, b).(*indexData)
+	got := d.expandFuzzy(&query.Fuzzy{Pattern: "lenght", Content: true}, nil)
+	want := query.NewOr(
+		&query.Substring{Pattern: "lenght", Content: true},
+		&query.Boost{Child: &query.Substring{Pattern: "length", Content: true}, Boost: fuzzyBoost},
+	)
+	if got.String() != want.String() {
+		t.Errorf("got expansion %s, want %s", got, want)
+	}
+}
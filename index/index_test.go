@@ -20,8 +20,10 @@ import (
 	"fmt"
 	"reflect"
 	"regexp/syntax"
+	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -67,7 +69,7 @@ func testShardBuilderCompound(t *testing.T, repos []*zoekt.Repository, docs [][]
 	t.Helper()
 
 	b := newShardBuilder()
-	b.indexFormatVersion = NextIndexFormatVersion
+	b.indexFormatVersion = compoundFormatVersion
 
 	if len(repos) != len(docs) {
 		t.Fatalf("testShardBuilderCompound: repos must be the same length as docs, got: len(repos)=%d len(docs)=%d", len(repos), len(docs))
@@ -193,12 +195,12 @@ func (s *memSeeker) Name() string {
 }
 
 func (s *memSeeker) Close() {}
-func (s *memSeeker) Read(off, sz uint32) ([]byte, error) {
+func (s *memSeeker) Read(off, sz uint64) ([]byte, error) {
 	return s.data[off : off+sz], nil
 }
 
-func (s *memSeeker) Size() (uint32, error) {
-	return uint32(len(s.data)), nil
+func (s *memSeeker) Size() (uint64, error) {
+	return uint64(len(s.data)), nil
 }
 
 func TestNewlines(t *testing.T) {
@@ -2062,7 +2064,7 @@ func TestListRepos(t *testing.T) {
 				Repos:                      1,
 				Shards:                     1,
 				Documents:                  4,
-				IndexBytes:                 412,
+				IndexBytes:                 456,
 				ContentBytes:               68,
 				NewLinesCount:              4,
 				DefaultBranchNewLinesCount: 2,
@@ -3500,6 +3502,72 @@ func TestLineAndFileName(t *testing.T) {
 	})
 }
 
+func TestNear(t *testing.T) {
+	b := testShardBuilder(t, &zoekt.Repository{Name: "reponame"},
+		Document{Name: "f1", Content: []byte("mu.lock()\ndefer mu.unlock()\n")},
+		Document{Name: "f2", Content: []byte("mu.lock()\n\n\n\n\ndefer mu.unlock()\n")},
+		Document{Name: "f3", Content: []byte("mu.lock()\n")},
+		Document{Name: "f4.go", Content: []byte("lock\nx\nunlock\nx\nx\nx\nx\nlock\n")},
+	)
+
+	cases := []struct {
+		q    query.Q
+		want []string
+	}{
+		{
+			q: &query.Near{Distance: 1, Children: []query.Q{
+				&query.Substring{Pattern: "mu.lock", Content: true},
+				&query.Substring{Pattern: "defer", Content: true},
+			}},
+			want: []string{"f1"},
+		},
+		{
+			q: &query.Near{Distance: 5, Children: []query.Q{
+				&query.Substring{Pattern: "mu.lock", Content: true},
+				&query.Substring{Pattern: "defer", Content: true},
+			}},
+			want: []string{"f1", "f2"},
+		},
+		{
+			// regexp children are evaluated too.
+			q: &query.Near{Distance: 2, Children: []query.Q{
+				&query.Regexp{Regexp: mustParseRE("(?m)^lock$"), Content: true},
+				&query.Substring{Pattern: "unlock", Content: true},
+			}},
+			want: []string{"f4.go"},
+		},
+		{
+			// children without content matches only need to match the file.
+			q: &query.Near{Distance: 0, Children: []query.Q{
+				&query.Substring{Pattern: "lock", Content: true},
+				&query.Substring{Pattern: ".go", FileName: true},
+			}},
+			want: []string{"f4.go"},
+		},
+		{
+			q: &query.Near{Distance: 0, Children: []query.Q{
+				&query.Substring{Pattern: "mu.lock", Content: true},
+				&query.Substring{Pattern: "defer", Content: true},
+			}},
+			want: nil,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.q.String(), func(t *testing.T) {
+			res := searchForTest(t, b, c.q)
+			var got []string
+			for _, f := range res.Files {
+				got = append(got, f.FileName)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("got %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
 func TestMultiLineRegex(t *testing.T) {
 	b := testShardBuilder(t, &zoekt.Repository{Name: "reponame"},
 		Document{Name: "f1", Content: []byte("apple banana\ngrape")},
@@ -4017,3 +4085,246 @@ func BenchmarkScoreChunkMatches(b *testing.B) {
 		}
 	})
 }
+
+func TestInSymbol(t *testing.T) {
+	content := []byte("class Client {\n  void retry() {}\n  void close() { retry(); }\n}\nvoid retry() {}\n")
+	// Client: 6, retry: 22, close: 40, top-level retry: 68
+	doc := Document{
+		Name:    "client.java",
+		Content: content,
+		Symbols: []DocumentSection{
+			{Start: 6, End: 12},
+			{Start: 22, End: 27},
+			{Start: 40, End: 45},
+			{Start: 68, End: 73},
+		},
+		SymbolsMetaData: []*zoekt.Symbol{
+			{Sym: "Client", Kind: "class"},
+			{Sym: "retry", Kind: "method", Parent: "Client"},
+			{Sym: "close", Kind: "method", Parent: "Client"},
+			{Sym: "retry", Kind: "function"},
+		},
+		SymbolScopes: []DocumentSection{
+			{Start: 0, End: 63},
+			{Start: 15, End: 33},
+			{Start: 33, End: 61},
+			{Start: 63, End: 79},
+		},
+	}
+	b := testShardBuilder(t, &zoekt.Repository{Name: "reponame"},
+		doc,
+		Document{Name: "other.java", Content: []byte("void retry() {}\n")},
+	)
+
+	cases := []struct {
+		q    query.Q
+		want []string
+	}{
+		{
+			q: &query.InSymbol{
+				Symbol: regexp.MustCompile("^Client\\.close$"),
+				Child:  &query.Substring{Pattern: "retry", Content: true},
+			},
+			want: []string{"  void close() { retry(); }\n"},
+		},
+		{
+			q: &query.InSymbol{
+				Symbol: regexp.MustCompile("^Client$"),
+				Child:  &query.Substring{Pattern: "retry", Content: true},
+			},
+			want: []string{"  void retry() {}\n", "  void close() { retry(); }\n"},
+		},
+		{
+			q: &query.InSymbol{
+				Symbol: regexp.MustCompile("^Client\\.retry$"),
+				Child:  &query.Substring{Pattern: "close"},
+			},
+			want: nil,
+		},
+		{
+			// matches in the file name are not restricted
+			q: &query.InSymbol{
+				Symbol: regexp.MustCompile("^main$"),
+				Child:  &query.Substring{Pattern: "retry"},
+			},
+			want: nil,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.q.String(), func(t *testing.T) {
+			res := searchForTest(t, b, c.q)
+			var got []string
+			for _, f := range res.Files {
+				if f.FileName != "client.java" {
+					t.Errorf("unexpected match in %s", f.FileName)
+				}
+				for _, l := range f.LineMatches {
+					got = append(got, string(l.Line))
+				}
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("got %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestFacets(t *testing.T) {
+	b := testShardBuilder(t, &zoekt.Repository{Name: "reponame"},
+		Document{Name: "cmd/main.go", Content: []byte("needle")},
+		Document{Name: "cmd/main_test.go", Content: []byte("needle")},
+		Document{Name: "README.md", Content: []byte("needle")},
+		Document{Name: "lib/lib.py", Content: []byte("haystack")},
+	)
+
+	t.Run("none requested", func(t *testing.T) {
+		res := searchForTest(t, b, &query.Substring{Pattern: "needle"})
+		if res.Facets != nil {
+			t.Errorf("got facets %v, want nil", res.Facets)
+		}
+	})
+
+	t.Run("all", func(t *testing.T) {
+		res := searchForTest(t, b, &query.Substring{Pattern: "needle"}, zoekt.SearchOptions{
+			Facets: []string{zoekt.FacetRepository, zoekt.FacetLanguage, zoekt.FacetCategory, zoekt.FacetDirectory, "unknown"},
+		})
+		want := zoekt.Facets{
+			zoekt.FacetRepository: {"reponame": 3},
+			zoekt.FacetLanguage:   {"Go": 2, "Markdown": 1},
+			zoekt.FacetCategory:   {"default": 1, "test": 1, "documentation": 1},
+			zoekt.FacetDirectory:  {"cmd/": 2, "/": 1},
+		}
+		if d := cmp.Diff(want, res.Facets); d != "" {
+			t.Errorf("mismatch (-want +got):\n%s", d)
+		}
+	})
+}
+
+func TestSortBy(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, time.January, d, 0, 0, 0, 0, time.UTC)
+	}
+	b := testShardBuilder(t, &zoekt.Repository{Name: "reponame"},
+		Document{Name: "b.go", Content: []byte("needle needle"), CommitDate: day(1), CommitHash: "abc", CommitAuthor: "alice"},
+		Document{Name: "c.go", Content: []byte("needle"), CommitDate: day(3)},
+		Document{Name: "a.go", Content: []byte("needle"), CommitDate: day(2)},
+		Document{Name: "d.go", Content: []byte("needle")},
+	)
+
+	names := func(files []zoekt.FileMatch) []string {
+		var got []string
+		for _, f := range files {
+			got = append(got, f.FileName)
+		}
+		return got
+	}
+
+	t.Run("commits", func(t *testing.T) {
+		res := searchForTest(t, b, &query.Substring{Pattern: "needle"})
+		got := map[string]string{}
+		for _, f := range res.Files {
+			got[f.FileName] = fmt.Sprintf("%s %s %s", f.CommitDate.Format(time.DateOnly), f.CommitHash, f.CommitAuthor)
+		}
+		want := map[string]string{
+			"a.go": "2024-01-02  ",
+			"b.go": "2024-01-01 abc alice",
+			"c.go": "2024-01-03  ",
+			"d.go": "0001-01-01  ",
+		}
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("mismatch (-want +got):\n%s", d)
+		}
+	})
+
+	for _, tc := range []struct {
+		sortBy zoekt.SortBy
+		want   []string
+	}{
+		{sortBy: zoekt.SortByPath, want: []string{"a.go", "b.go", "c.go", "d.go"}},
+		{sortBy: zoekt.SortByRepo, want: []string{"a.go", "b.go", "c.go", "d.go"}},
+		{sortBy: zoekt.SortByCommitDate, want: []string{"c.go", "a.go", "b.go", "d.go"}},
+	} {
+		t.Run(tc.sortBy.String(), func(t *testing.T) {
+			res := searchForTest(t, b, &query.Substring{Pattern: "needle"})
+			SortFilesBy(res.Files, tc.sortBy)
+			if d := cmp.Diff(tc.want, names(res.Files)); d != "" {
+				t.Errorf("mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestCommitSearch(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, time.January, d, 0, 0, 0, 0, time.UTC)
+	}
+	b := testShardBuilder(t, &zoekt.Repository{Name: "reponame"},
+		Document{Name: "main.go", Content: []byte("func fix() {}")},
+		CommitDocument("aaa", "alice", day(1), "fix the parser\n", []string{"parse.go"}, nil),
+		CommitDocument("bbb", "bob", day(3), "add a fix for main\n", []string{"main.go", "main_test.go"}, nil),
+	)
+
+	hashes := func(commits []zoekt.CommitMatch) []string {
+		var got []string
+		for _, c := range commits {
+			got = append(got, c.Hash)
+		}
+		return got
+	}
+
+	t.Run("files only", func(t *testing.T) {
+		res := searchForTest(t, b, &query.Substring{Pattern: "fix"})
+		if len(res.Commits) != 0 {
+			t.Errorf("got commits %v, want none", hashes(res.Commits))
+		}
+		if len(res.Files) != 1 || res.Files[0].FileName != "main.go" {
+			t.Errorf("got %d files, want main.go", len(res.Files))
+		}
+	})
+
+	for _, tc := range []struct {
+		q    string
+		want []string
+	}{
+		{q: "type:commit fix", want: []string{"aaa", "bbb"}},
+		{q: "type:commit", want: []string{"aaa", "bbb"}},
+		{q: "type:commit main_test.go", want: []string{"bbb"}},
+		{q: "type:commit author:ali", want: []string{"aaa"}},
+		{q: "type:commit before:2024-01-02", want: []string{"aaa"}},
+		{q: "type:commit after:2024-01-02 fix", want: []string{"bbb"}},
+		{q: "type:commit author:carol", want: nil},
+	} {
+		t.Run(tc.q, func(t *testing.T) {
+			q, err := query.Parse(tc.q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res := searchForTest(t, b, q)
+			if len(res.Files) != 0 {
+				t.Errorf("got %d files, want none", len(res.Files))
+			}
+			if d := cmp.Diff(tc.want, hashes(res.Commits)); d != "" {
+				t.Errorf("mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+
+	t.Run("commit match", func(t *testing.T) {
+		res := searchForTest(t, b, &query.Type{Type: query.TypeCommit, Child: &query.Substring{Pattern: "parser"}})
+		want := []zoekt.CommitMatch{{
+			Repository: "reponame",
+			Hash:       "aaa",
+			Author:     "alice",
+			Date:       day(1),
+			Message:    "fix the parser\n",
+			Paths:      []string{"parse.go"},
+		}}
+		if d := cmp.Diff(want, res.Commits, cmpopts.IgnoreFields(zoekt.CommitMatch{}, "LineMatches", "Score")); d != "" {
+			t.Errorf("mismatch (-want +got):\n%s", d)
+		}
+		if res.Stats.FileCount != 0 || res.Stats.MatchCount != 1 {
+			t.Errorf("got FileCount %d MatchCount %d, want 0 and 1", res.Stats.FileCount, res.Stats.MatchCount)
+		}
+	})
+}
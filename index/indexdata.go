@@ -23,6 +23,7 @@ import (
 	"math"
 	"math/bits"
 	"slices"
+	"time"
 	"unicode/utf8"
 
 	"github.com/sourcegraph/zoekt"
@@ -39,21 +40,40 @@ type indexData struct {
 
 	contentNgrams btreeIndex
 
-	newlinesStart uint32
+	newlinesStart uint64
 	newlinesIndex []uint32
 
-	docSectionsStart uint32
+	docSectionsStart uint64
 	docSectionsIndex []uint32
 
+	// byte ranges enclosing each symbol. Empty for shards written before
+	// symbol scopes were recorded.
+	symbolScopesStart uint64
+	symbolScopesIndex []uint32
+
+	commitsStart uint64
+	commitsIndex []uint32
+
+	// acls holds the ACL labels of the documents. It is empty if the shard
+	// has no ACLs.
+	acls docACLs
+
 	runeDocSections []DocumentSection
 
 	// rune offset=>byte offset mapping, relative to the start of the content corpus
 	runeOffsets runeOffsetMap
 
 	// offsets of file contents; includes end of last file
-	boundariesStart uint32
+	boundariesStart uint64
 	boundaries      []uint32
 
+	// contentBlocks is set if file contents are compressed. boundaries then
+	// are offsets into the uncompressed contents.
+	contentBlocks *contentBlocks
+
+	// tokens is the optional token index of the contents.
+	tokens tokenIndex
+
 	// rune offsets for the file content boundaries
 	fileEndRunes []uint32
 
@@ -96,6 +116,14 @@ type indexData struct {
 	// file categories for all the files.
 	categories []byte
 
+	// hasCommitDocs is true if the shard contains commit documents, see
+	// FileCategoryCommit.
+	hasCommitDocs bool
+
+	// commitDates holds the Unix time of the last commit for all the files, or
+	// 0 if it is unknown. It is nil if the shard has no commit dates.
+	commitDates []uint64
+
 	repoListEntry []zoekt.RepoListEntry
 
 	// repository indexes for all the files
@@ -110,6 +138,8 @@ type symbolData struct {
 	// TODO we don't need to store Symbol.Sym.
 	symContent []byte
 	symIndex   []byte
+	// offsets64 is set if symIndex holds 64-bit offsets.
+	offsets64 bool
 	// symKindContent is an enum of sym.Kind and sym.ParentKind
 	symKindContent []byte
 	symKindIndex   []uint32
@@ -125,19 +155,44 @@ func uint32SliceLen(a []byte) uint32 {
 	return uint32(len(a) / 4)
 }
 
+// symOffset returns the offset of parent i in the index file.
+func (d *symbolData) symOffset(i uint32) uint64 {
+	if d.offsets64 {
+		return binary.BigEndian.Uint64(d.symIndex[i*8:])
+	}
+	return uint64(uint32SliceAt(d.symIndex, i))
+}
+
 // parent returns index i of the parent enum
 func (d *symbolData) parent(i uint32) []byte {
-	delta := uint32SliceAt(d.symIndex, 0)
-	start := uint32SliceAt(d.symIndex, i) - delta
-	var end uint32
-	if i+1 == uint32SliceLen(d.symIndex) {
-		end = uint32(len(d.symContent))
+	n := uint32SliceLen(d.symIndex)
+	if d.offsets64 {
+		n /= 2
+	}
+	delta := d.symOffset(0)
+	start := d.symOffset(i) - delta
+	var end uint64
+	if i+1 == n {
+		end = uint64(len(d.symContent))
 	} else {
-		end = uint32SliceAt(d.symIndex, i+1) - delta
+		end = d.symOffset(i+1) - delta
 	}
 	return d.symContent[start:end]
 }
 
+// visitNames calls f for the names in the symbol dictionary.
+func (d *symbolData) visitNames(f func(string)) {
+	n := uint32SliceLen(d.symIndex)
+	if d.offsets64 {
+		n /= 2
+	}
+	for i := range n {
+		if name := d.parent(i); len(name) > 0 {
+			f(string(name))
+		}
+	}
+}
+
 // kind returns index i of the kind enum
 func (d *symbolData) kind(i uint32) []byte {
 	return d.symKindContent[d.symKindIndex[i]:d.symKindIndex[i+1]]
@@ -188,6 +243,15 @@ func (d *indexData) getCategory(idx uint32) FileCategory {
 	return category
 }
 
+// getCommitDate returns the date of the last commit that touched document
+// idx, or the zero time if it is unknown.
+func (d *indexData) getCommitDate(idx uint32) time.Time {
+	if len(d.commitDates) == 0 || d.commitDates[idx] == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(d.commitDates[idx]), 0).UTC()
+}
+
 // calculates stats for files in the range [start, end).
 func (d *indexData) calculateStatsForFileRange(start, end uint32) zoekt.RepoStats {
 	if start >= end {
@@ -277,10 +341,7 @@ func (d *indexData) calculateNewLinesStats(start, end uint32) (count, defaultCou
 
 		// this is readNewlines but only reading the size of each section which
 		// corresponds to the number of newlines.
-		sec := simpleSection{
-			off: d.newlinesStart + d.newlinesIndex[i],
-			sz:  d.newlinesIndex[i+1] - d.newlinesIndex[i],
-		}
+		sec := itemSection(d.newlinesStart, d.newlinesIndex, i)
 		// We are only reading the first varint which is the size. So we don't
 		// need to read more than MaxVarintLen64 bytes.
 		if sec.sz > binary.MaxVarintLen64 {
@@ -311,7 +372,7 @@ func (d *indexData) String() string {
 func (d *indexData) memoryUse() int {
 	sz := 0
 	for _, a := range [][]uint32{
-		d.newlinesIndex, d.docSectionsIndex,
+		d.newlinesIndex, d.docSectionsIndex, d.symbolScopesIndex, d.commitsIndex,
 		d.boundaries, d.fileNameIndex,
 		d.fileEndRunes, d.fileNameEndRunes,
 		d.fileEndSymbol, d.symbols.symKindIndex,
@@ -319,6 +380,10 @@ func (d *indexData) memoryUse() int {
 	} {
 		sz += 4 * len(a)
 	}
+	if d.contentBlocks != nil {
+		sz += 4 * len(d.contentBlocks.index)
+	}
+	sz += 4*len(d.tokens.textIndex) + 4*len(d.tokens.postingsIndex)
 	sz += d.runeOffsets.sizeBytes()
 	sz += d.fileNameRuneOffsets.sizeBytes()
 	sz += len(d.languages)
@@ -326,6 +391,8 @@ func (d *indexData) memoryUse() int {
 	sz += 2 * len(d.repos)
 	sz += 8 * len(d.runeDocSections)
 	sz += 8 * len(d.fileBranchMasks)
+	sz += 8 * len(d.commitDates)
+	sz += d.acls.sizeBytes()
 	sz += d.contentNgrams.SizeBytes()
 	sz += d.fileNameNgrams.SizeBytes()
 	return sz
@@ -393,6 +460,12 @@ type ngramIterationResults struct {
 	fileName      bool
 	substrBytes   []byte
 	substrLowered []byte
+
+	// The ngrams chosen to iterate over and their frequencies, for
+	// SearchOptions.Explain. first and last are the same if the pattern has
+	// only one ngram.
+	first, last         runeNgramOff
+	firstFreq, lastFreq uint32
 }
 
 func (r *ngramIterationResults) String() string {
@@ -431,11 +504,11 @@ func (d *indexData) iterateNgrams(query *query.Substring) (*ngramIterationResult
 	for i, o := range ngramOffs {
 		var freq uint32
 		if query.CaseSensitive {
-			freq = ngrams.Get(o.ngram).sz
+			freq = uint32(ngrams.Get(o.ngram).sz)
 			ngramLookups++
 		} else {
 			for _, v := range generateCaseNgrams(o.ngram) {
-				freq += ngrams.Get(v).sz
+				freq += uint32(ngrams.Get(v).sz)
 				ngramLookups++
 			}
 		}
@@ -493,6 +566,10 @@ func (d *indexData) iterateNgrams(query *query.Substring) (*ngramIterationResult
 		fileName:      query.FileName,
 		substrBytes:   patBytes,
 		substrLowered: lowerPatBytes,
+		first:         first,
+		last:          last,
+		firstFreq:     frequencies[indexMap[first.index]],
+		lastFreq:      frequencies[indexMap[last.index]],
 	}, nil
 }
 
@@ -27,12 +27,12 @@ import (
 
 type mmapedIndexFile struct {
 	name string
-	size uint32
+	size uint64
 	data []byte
 }
 
-func (f *mmapedIndexFile) Read(off, sz uint32) ([]byte, error) {
-	if off > off+sz || off+sz > uint32(len(f.data)) {
+func (f *mmapedIndexFile) Read(off, sz uint64) ([]byte, error) {
+	if off > off+sz || off+sz > uint64(len(f.data)) {
 		return nil, fmt.Errorf("out of bounds: %d, len %d, name %s", off+sz, len(f.data), f.name)
 	}
 	return f.data[off : off+sz], nil
@@ -42,7 +42,7 @@ func (f *mmapedIndexFile) Name() string {
 	return f.name
 }
 
-func (f *mmapedIndexFile) Size() (uint32, error) {
+func (f *mmapedIndexFile) Size() (uint64, error) {
 	return f.size, nil
 }
 
@@ -62,13 +62,15 @@ func NewIndexFile(f *os.File) (IndexFile, error) {
 		return nil, err
 	}
 
+	// Files of 4 GiB and more need 64-bit section offsets, see
+	// NextIndexFormatVersion. The mapping must fit in a slice.
 	sz := fi.Size()
-	if sz >= math.MaxUint32 {
+	if sz > math.MaxInt-4095 {
 		return nil, fmt.Errorf("file %s too large: %d", f.Name(), sz)
 	}
 	r := &mmapedIndexFile{
 		name: f.Name(),
-		size: uint32(sz),
+		size: uint64(sz),
 	}
 
 	rounded := (r.size + 4095) &^ 4095
@@ -0,0 +1,15 @@
+//go:build !linux && !darwin
+
+package index
+
+import (
+	"os"
+)
+
+// NewIndexFile returns a new index file. The index file takes
+// ownership of the passed in file, and may close it.
+//
+// Without mmap, the file is read with pread, see NewPreadIndexFile.
+func NewIndexFile(f *os.File) (IndexFile, error) {
+	return NewPreadIndexFile(f, DefaultPreadCacheSize)
+}
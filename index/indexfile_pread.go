@@ -0,0 +1,143 @@
+package index
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// IndexFileOpener opens an IndexFile for f, taking ownership of f. See
+// NewIndexFile and NewPreadIndexFile.
+type IndexFileOpener func(f *os.File) (IndexFile, error)
+
+// NewIndexFileFromBytes returns an IndexFile reading from data, for example
+// a shard written by ShardBuilder.Write into a bytes.Buffer. data must not
+// be modified while the IndexFile is in use. name is returned by Name; a
+// ".meta" file next to it is applied like for shards on disk.
+func NewIndexFileFromBytes(name string, data []byte) IndexFile {
+	return &bytesIndexFile{name: name, data: data}
+}
+
+type bytesIndexFile struct {
+	name string
+	data []byte
+}
+
+func (f *bytesIndexFile) Read(off, sz uint64) ([]byte, error) {
+	if off > off+sz || off+sz > uint64(len(f.data)) {
+		return nil, fmt.Errorf("out of bounds: %d, len %d, name %s", off+sz, len(f.data), f.name)
+	}
+	return f.data[off : off+sz : off+sz], nil
+}
+
+func (f *bytesIndexFile) Size() (uint64, error) {
+	return uint64(len(f.data)), nil
+}
+
+func (f *bytesIndexFile) Close() {}
+
+func (f *bytesIndexFile) Name() string {
+	return f.name
+}
+
+// preadChunkSize is the granularity in which preadIndexFile reads and
+// caches the file.
+const preadChunkSize = 64 << 10
+
+// DefaultPreadCacheSize is the cache size of NewIndexFile where shards are
+// not mmap'd.
+const DefaultPreadCacheSize = 4 << 20
+
+// NewPreadIndexFile returns an IndexFile which reads f with pread(2) instead
+// of mapping it into memory. Reads are cached in chunks, keeping at most
+// about cacheSize bytes per file. Sections which are held for the lifetime
+// of a shard, such as file names, are read into memory as a whole, so the
+// memory use of a searcher exceeds cacheSize. The IndexFile takes ownership
+// of f and closes it on Close.
+func NewPreadIndexFile(f *os.File, cacheSize int) (IndexFile, error) {
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &preadIndexFile{
+		f:     f,
+		size:  uint64(fi.Size()),
+		cache: newBlockCache(max(cacheSize/preadChunkSize, 1)),
+	}, nil
+}
+
+type preadIndexFile struct {
+	f     *os.File
+	size  uint64
+	cache *blockCache
+}
+
+// chunk returns chunk i of the file, which is shorter than preadChunkSize
+// at the end of the file.
+func (f *preadIndexFile) chunk(i uint32) ([]byte, error) {
+	if b, ok := f.cache.get(i); ok {
+		return b, nil
+	}
+	off := uint64(i) * preadChunkSize
+	b := make([]byte, min(preadChunkSize, f.size-off))
+	if _, err := f.f.ReadAt(b, int64(off)); err != nil && err != io.EOF {
+		return nil, err
+	}
+	f.cache.add(i, b)
+	return b, nil
+}
+
+// Read returns a slice which stays valid after Close, since it is never
+// reused.
+func (f *preadIndexFile) Read(off, sz uint64) ([]byte, error) {
+	if off > off+sz || off+sz > f.size {
+		return nil, fmt.Errorf("out of bounds: %d, len %d, name %s", off+sz, f.size, f.Name())
+	}
+	if sz == 0 {
+		return []byte{}, nil
+	}
+
+	first, last := off/preadChunkSize, (off+sz-1)/preadChunkSize
+	if first == last {
+		c, err := f.chunk(uint32(first))
+		if err != nil {
+			return nil, err
+		}
+		start := off - first*preadChunkSize
+		return c[start : start+sz : start+sz], nil
+	}
+
+	// Large reads, typically whole sections, bypass the cache.
+	b := make([]byte, sz)
+	if sz > 2*preadChunkSize {
+		if _, err := f.f.ReadAt(b, int64(off)); err != nil && err != io.EOF {
+			return nil, err
+		}
+		return b, nil
+	}
+	n := 0
+	for i := first; i <= last; i++ {
+		c, err := f.chunk(uint32(i))
+		if err != nil {
+			return nil, err
+		}
+		if i == first {
+			c = c[off-first*preadChunkSize:]
+		}
+		n += copy(b[n:], c)
+	}
+	return b, nil
+}
+
+func (f *preadIndexFile) Size() (uint64, error) {
+	return f.size, nil
+}
+
+func (f *preadIndexFile) Close() {
+	f.f.Close()
+}
+
+func (f *preadIndexFile) Name() string {
+	return f.f.Name()
+}
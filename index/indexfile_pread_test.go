@@ -0,0 +1,89 @@
+package index
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sourcegraph/zoekt"
+	"github.com/sourcegraph/zoekt/query"
+)
+
+func TestNewIndexFileFromBytes(t *testing.T) {
+	b := testShardBuilder(t, &zoekt.Repository{Name: "repo"}, Document{Name: "f1", Content: []byte("needle")})
+	var buf bytes.Buffer
+	if err := b.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	f := NewIndexFileFromBytes("repo.zoekt", buf.Bytes())
+	if _, err := f.Read(uint64(buf.Len())-4, 8); err == nil {
+		t.Error("want error reading past the end")
+	}
+
+	searcher, err := NewSearcher(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer searcher.Close()
+
+	res, err := searcher.Search(context.Background(), &query.Substring{Pattern: "needle"}, &zoekt.SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Files) != 1 {
+		t.Fatalf("got %d files, want 1", len(res.Files))
+	}
+}
+
+func TestPreadIndexFile(t *testing.T) {
+	data := make([]byte, 5*preadChunkSize+123)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	fn := filepath.Join(t.TempDir(), "shard.zoekt")
+	if err := os.WriteFile(fn, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	osf, err := os.Open(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := NewPreadIndexFile(osf, 2*preadChunkSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if sz, err := f.Size(); err != nil || sz != uint64(len(data)) {
+		t.Fatalf("Size() = %d, %v, want %d", sz, err, len(data))
+	}
+
+	for _, r := range []struct{ off, sz uint64 }{
+		{0, 0},
+		{0, 10},
+		{preadChunkSize - 3, 6},                  // across two chunks
+		{preadChunkSize - 3, preadChunkSize + 6}, // across three chunks
+		{10, 4 * preadChunkSize},                 // bypasses the cache
+		{uint64(len(data)) - 8, 8},               // trailer in the last, short chunk
+		{0, uint64(len(data))},
+	} {
+		got, err := f.Read(r.off, r.sz)
+		if err != nil {
+			t.Fatalf("Read(%d, %d): %v", r.off, r.sz, err)
+		}
+		if !bytes.Equal(got, data[r.off:r.off+r.sz]) {
+			t.Errorf("Read(%d, %d) returned wrong data", r.off, r.sz)
+		}
+	}
+
+	if _, err := f.Read(uint64(len(data))-4, 8); err == nil {
+		t.Error("want error reading past the end")
+	}
+
+	if n := f.(*preadIndexFile).cache.lru.Len(); n != 2 {
+		t.Errorf("got %d cached chunks, want 2", n)
+	}
+}
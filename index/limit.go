@@ -1,21 +1,40 @@
 package index
 
 import (
+	"cmp"
 	"log"
+	"slices"
+	"strings"
 
 	"github.com/sourcegraph/zoekt"
 )
 
-// SortAndTruncateFiles is a convenience around SortFiles and
+// SortAndTruncateFiles is a convenience around SortFilesBy and
 // DisplayTruncator. Given an aggregated files it will sort and then truncate
 // based on the search options.
 func SortAndTruncateFiles(files []zoekt.FileMatch, opts *zoekt.SearchOptions) []zoekt.FileMatch {
-	SortFiles(files)
+	SortFilesBy(files, opts.SortBy)
 	truncator, _ := NewDisplayTruncator(opts)
 	files, _ = truncator(files)
 	return files
 }
 
+// SortAndTruncateCommits sorts commits newest first, and truncates them to
+// opts.MaxDocDisplayCount.
+func SortAndTruncateCommits(commits []zoekt.CommitMatch, opts *zoekt.SearchOptions) []zoekt.CommitMatch {
+	slices.SortStableFunc(commits, func(a, b zoekt.CommitMatch) int {
+		return cmp.Or(
+			b.Date.Compare(a.Date),
+			strings.Compare(a.Repository, b.Repository),
+			strings.Compare(a.Hash, b.Hash),
+		)
+	})
+	if opts.MaxDocDisplayCount > 0 && len(commits) > opts.MaxDocDisplayCount {
+		commits = commits[:opts.MaxDocDisplayCount]
+	}
+	return commits
+}
+
 // DisplayTruncator is a stateful function which enforces Document and Match
 // display limits by truncating and mutating before. hasMore is true until the
 // limits are exhausted. Once hasMore is false each subsequent call will
@@ -20,6 +20,8 @@ import (
 	"log"
 	"math"
 	"regexp/syntax"
+	"slices"
+	"sort"
 	"strings"
 	"unicode/utf8"
 
@@ -160,6 +162,14 @@ type andMatchTree struct {
 	children []matchTree
 }
 
+// nearMatchTree is an andMatchTree which additionally requires that the
+// content matches of its children can be found within distance lines of each
+// other.
+type nearMatchTree struct {
+	andMatchTree
+	distance int
+}
+
 type orMatchTree struct {
 	children []matchTree
 }
@@ -178,6 +188,76 @@ type boostMatchTree struct {
 	boost float64
 }
 
+// symbolScopeSet lazily loads the scopes of the symbols of the current document
+// whose qualified name matches re. It is shared by an inSymbolMatchTree and
+// the symbolScopeMatchTrees below it.
+type symbolScopeSet struct {
+	d  *indexData
+	re *regexp.Regexp
+
+	// mutable
+	loaded bool
+	scopes []DocumentSection
+	buf    []DocumentSection
+}
+
+func (s *symbolScopeSet) reset() {
+	s.loaded = false
+	s.scopes = s.scopes[:0]
+}
+
+func (s *symbolScopeSet) get(cp *contentProvider) []DocumentSection {
+	if s.loaded {
+		return s.scopes
+	}
+	s.loaded = true
+
+	all, sz, err := s.d.readSymbolScopes(cp.idx, s.buf)
+	if err != nil {
+		cp.err = err
+		return nil
+	}
+	s.buf = all
+	cp.stats.ContentBytesLoaded += int64(sz)
+
+	secs := cp.docSections()
+	if len(all) != len(secs) {
+		return nil
+	}
+
+	data := cp.data(false)
+	symStart := s.d.fileEndSymbol[cp.idx]
+	for i, sec := range secs {
+		sym := s.d.symbols.data(symStart + uint32(i))
+		if sym == nil {
+			continue
+		}
+		sym.Sym = string(data[sec.Start:sec.End])
+		if s.re.MatchString(symbolScopeName(sym)) {
+			s.scopes = append(s.scopes, all[i])
+		}
+	}
+	return s.scopes
+}
+
+// inSymbolMatchTree matches documents which have a symbol scope matching the
+// query. The content atoms of child are wrapped in symbolScopeMatchTree, which
+// drops matches outside of the scopes.
+type inSymbolMatchTree struct {
+	child  matchTree
+	scopes *symbolScopeSet
+}
+
+// symbolScopeMatchTree restricts the candidate matches of an atom to the
+// scopes of matching symbols.
+type symbolScopeMatchTree struct {
+	matchTree
+	scopes *symbolScopeSet
+
+	// mutable
+	evaluated bool
+}
+
 // Don't visit this subtree for collecting matches.
 type noVisitMatchTree struct {
 	matchTree
@@ -409,6 +489,16 @@ func (t *orMatchTree) prepare(doc uint32) {
 	}
 }
 
+func (t *inSymbolMatchTree) prepare(doc uint32) {
+	t.scopes.reset()
+	t.child.prepare(doc)
+}
+
+func (t *symbolScopeMatchTree) prepare(doc uint32) {
+	t.evaluated = false
+	t.matchTree.prepare(doc)
+}
+
 func (t *notMatchTree) prepare(doc uint32) {
 	t.child.prepare(doc)
 }
@@ -480,6 +570,10 @@ func (t *notMatchTree) nextDoc() uint32 {
 	return 0
 }
 
+func (t *inSymbolMatchTree) nextDoc() uint32 {
+	return t.child.nextDoc()
+}
+
 func (t *fileNameMatchTree) nextDoc() uint32 {
 	return t.child.nextDoc()
 }
@@ -516,6 +610,10 @@ func (t *andMatchTree) String() string {
 	return fmt.Sprintf("and%v", t.children)
 }
 
+func (t *nearMatchTree) String() string {
+	return fmt.Sprintf("near:%d%v", t.distance, t.children)
+}
+
 func (t *regexpMatchTree) String() string {
 	f := ""
 	if t.fileName {
@@ -540,6 +638,14 @@ func (t *notMatchTree) String() string {
 	return fmt.Sprintf("not(%v)", t.child)
 }
 
+func (t *inSymbolMatchTree) String() string {
+	return fmt.Sprintf("insym(%q, %v)", t.scopes.re, t.child)
+}
+
+func (t *symbolScopeMatchTree) String() string {
+	return fmt.Sprintf("scoped(%v)", t.matchTree)
+}
+
 func (t *noVisitMatchTree) String() string {
 	return fmt.Sprintf("novisit(%v)", t.matchTree)
 }
@@ -587,6 +693,8 @@ func visitMatchTree(t matchTree, f func(matchTree)) {
 		}
 	case *andLineMatchTree:
 		visitMatchTree(&s.andMatchTree, f)
+	case *nearMatchTree:
+		visitMatchTree(&s.andMatchTree, f)
 	case *noVisitMatchTree:
 		visitMatchTree(s.matchTree, f)
 	case *notMatchTree:
@@ -595,6 +703,10 @@ func visitMatchTree(t matchTree, f func(matchTree)) {
 		visitMatchTree(s.child, f)
 	case *boostMatchTree:
 		visitMatchTree(s.child, f)
+	case *inSymbolMatchTree:
+		visitMatchTree(s.child, f)
+	case *symbolScopeMatchTree:
+		visitMatchTree(s.matchTree, f)
 	case *symbolSubstrMatchTree:
 		visitMatchTree(s.substrMatchTree, f)
 	case *symbolRegexpMatchTree:
@@ -632,6 +744,8 @@ func visitMatches(t matchTree, known map[matchTree]bool, weight float64, f func(
 		}
 	case *andLineMatchTree:
 		visitMatches(&s.andMatchTree, known, weight, f)
+	case *nearMatchTree:
+		visitMatches(&s.andMatchTree, known, weight, f)
 	case *orMatchTree:
 		for _, ch := range s.children {
 			if known[ch] {
@@ -640,6 +754,10 @@ func visitMatches(t matchTree, known map[matchTree]bool, weight float64, f func(
 		}
 	case *boostMatchTree:
 		visitMatches(s.child, known, weight*s.boost, f)
+	case *inSymbolMatchTree:
+		visitMatches(s.child, known, weight, f)
+	case *symbolScopeMatchTree:
+		visitMatches(s.matchTree, known, weight, f)
 	case *symbolSubstrMatchTree:
 		visitMatches(s.substrMatchTree, known, weight, f)
 	case *notMatchTree:
@@ -748,6 +866,83 @@ nextLine:
 	return matchesNone
 }
 
+func (t *nearMatchTree) matches(cp *contentProvider, cost int, known map[matchTree]bool) matchesState {
+	if state := evalMatchTree(cp, cost, known, &t.andMatchTree); state != matchesFound {
+		return state
+	}
+
+	// Invariant: all children have matches. Collect the lines of the content
+	// matches of each child and look for a window of distance lines which
+	// contains at least one line from every child.
+	type childLine struct {
+		line  int
+		child int
+	}
+	var lines []childLine
+	positional := 0
+	for ix, child := range t.children {
+		prev := -1
+		visitMatchAtoms(child, known, func(mt matchTree) {
+			var cands []*candidateMatch
+			switch mt := mt.(type) {
+			case *substrMatchTree:
+				cands = mt.current
+			case *regexpMatchTree:
+				cands = mt.found
+			case *wordMatchTree:
+				cands = mt.found
+			}
+			for _, c := range cands {
+				if c.fileName {
+					continue
+				}
+				line := cp.newlines().atOffset(c.byteOffset)
+				if line == prev {
+					continue
+				}
+				prev = line
+				lines = append(lines, childLine{line: line, child: ix})
+			}
+		})
+		if prev != -1 {
+			positional++
+		}
+	}
+
+	// Children without content matches (eg. a language filter) only need to
+	// match the document.
+	if positional <= 1 {
+		return matchesFound
+	}
+
+	sort.Slice(lines, func(i, j int) bool {
+		return lines[i].line < lines[j].line
+	})
+
+	// Sliding window over the lines, tracking how many lines of each child are
+	// inside the window.
+	counts := make([]int, len(t.children))
+	inWindow := 0
+	start := 0
+	for _, cur := range lines {
+		if counts[cur.child] == 0 {
+			inWindow++
+		}
+		counts[cur.child]++
+		for cur.line-lines[start].line > t.distance {
+			counts[lines[start].child]--
+			if counts[lines[start].child] == 0 {
+				inWindow--
+			}
+			start++
+		}
+		if inWindow == positional {
+			return matchesFound
+		}
+	}
+	return matchesNone
+}
+
 func (t *andMatchTree) matches(cp *contentProvider, cost int, known map[matchTree]bool) matchesState {
 	// We have found matches unless a child needs to do more work or it hasn't
 	// found matches.
@@ -921,6 +1116,70 @@ func (t *notMatchTree) matches(cp *contentProvider, cost int, known map[matchTre
 	}
 }
 
+func (t *inSymbolMatchTree) matches(cp *contentProvider, cost int, known map[matchTree]bool) matchesState {
+	if cost < costContent {
+		return matchesRequiresHigherCost
+	}
+	if len(t.scopes.get(cp)) == 0 {
+		return matchesNone
+	}
+	return evalMatchTree(cp, cost, known, t.child)
+}
+
+func (t *symbolScopeMatchTree) matches(cp *contentProvider, cost int, known map[matchTree]bool) matchesState {
+	if t.evaluated {
+		return matchesStateForSlice(t.candidates())
+	}
+	state := evalMatchTree(cp, cost, known, t.matchTree)
+	if state == matchesNone {
+		return matchesNone
+	}
+	if cost < costContent {
+		return matchesRequiresHigherCost
+	}
+	if state != matchesFound {
+		return state
+	}
+
+	scopes := t.scopes.get(cp)
+	inScope := func(c *candidateMatch) bool {
+		if c.fileName {
+			return false
+		}
+		for _, s := range scopes {
+			if s.Start <= c.byteOffset && c.byteOffset+c.byteMatchSz <= s.End {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch mt := t.matchTree.(type) {
+	case *substrMatchTree:
+		mt.current = slices.DeleteFunc(mt.current, func(c *candidateMatch) bool { return !inScope(c) })
+	case *regexpMatchTree:
+		mt.found = slices.DeleteFunc(mt.found, func(c *candidateMatch) bool { return !inScope(c) })
+	case *wordMatchTree:
+		mt.found = slices.DeleteFunc(mt.found, func(c *candidateMatch) bool { return !inScope(c) })
+	}
+	t.evaluated = true
+
+	return matchesStateForSlice(t.candidates())
+}
+
+// candidates returns the candidate matches of the wrapped atom.
+func (t *symbolScopeMatchTree) candidates() []*candidateMatch {
+	switch mt := t.matchTree.(type) {
+	case *substrMatchTree:
+		return mt.current
+	case *regexpMatchTree:
+		return mt.found
+	case *wordMatchTree:
+		return mt.found
+	}
+	return nil
+}
+
 func (t *fileNameMatchTree) matches(cp *contentProvider, cost int, known map[matchTree]bool) matchesState {
 	return evalMatchTree(cp, cost, known, t.child)
 }
@@ -965,6 +1224,10 @@ type matchTreeOpt struct {
 	// DisableWordMatchOptimization is used to disable the use of wordMatchTree.
 	// This was added since we do not support wordMatchTree with symbol search.
 	DisableWordMatchOptimization bool
+
+	// visible is set if the caller may not see some documents of the shard,
+	// see indexData.Search.
+	visible func(doc uint32) bool
 }
 
 func (d *indexData) newMatchTree(q query.Q, opt matchTreeOpt) (matchTree, error) {
@@ -993,6 +1256,22 @@ func (d *indexData) newMatchTree(q query.Q, opt matchTreeOpt) (matchTree, error)
 			// A common search we get is "\bLITERAL\b". Avoid the regex engine and
 			// provide something faster.
 			tr = wmt
+
+			// The token index has the exact documents of the word, which is
+			// better than the ngrams of short or common words.
+			if !wmt.fileName && d.tokens.enabled() && isTokenPattern(wmt.word) {
+				docs, ok, err := d.tokens.word(wmt.word)
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					return &andMatchTree{
+						children: []matchTree{
+							d.newTokenDocMatchTree(wmt.word, docs), tr, &noVisitMatchTree{subMT},
+						},
+					}, nil
+				}
+			}
 		} else {
 			tr = newRegexpMatchTree(s)
 		}
@@ -1012,6 +1291,16 @@ func (d *indexData) newMatchTree(q query.Q, opt matchTreeOpt) (matchTree, error)
 			r = append(r, ct)
 		}
 		return &andMatchTree{r}, nil
+	case *query.Near:
+		var r []matchTree
+		for _, ch := range s.Children {
+			ct, err := d.newMatchTree(ch, opt)
+			if err != nil {
+				return nil, err
+			}
+			r = append(r, ct)
+		}
+		return &nearMatchTree{andMatchTree: andMatchTree{r}, distance: s.Distance}, nil
 	case *query.Or:
 		var r []matchTree
 		for _, ch := range s.Children {
@@ -1029,6 +1318,26 @@ func (d *indexData) newMatchTree(q query.Q, opt matchTreeOpt) (matchTree, error)
 		}, err
 
 	case *query.Type:
+		if s.Type == query.TypeCommit {
+			ct, err := d.newMatchTree(s.Child, opt)
+			if err != nil {
+				return nil, err
+			}
+
+			return &andMatchTree{
+				children: []matchTree{
+					ct,
+					&docMatchTree{
+						reason:  "commit",
+						numDocs: d.numDocs(),
+						predicate: func(docID uint32) bool {
+							return d.getCategory(docID) == FileCategoryCommit
+						},
+					},
+				},
+			}, nil
+		}
+
 		if s.Type != query.TypeFileName {
 			break
 		}
@@ -1042,6 +1351,32 @@ func (d *indexData) newMatchTree(q query.Q, opt matchTreeOpt) (matchTree, error)
 			child: ct,
 		}, nil
 
+	case *query.Author:
+		return &docMatchTree{
+			reason:  "author",
+			numDocs: d.numDocs(),
+			predicate: func(docID uint32) bool {
+				_, author, err := d.readCommit(docID)
+				return err == nil && author != "" && s.Regexp.MatchString(author)
+			},
+		}, nil
+
+	case *query.CommitDate:
+		return &docMatchTree{
+			reason:  "commit date",
+			numDocs: d.numDocs(),
+			predicate: func(docID uint32) bool {
+				date := d.getCommitDate(docID)
+				if date.IsZero() {
+					return false
+				}
+				if s.Before {
+					return date.Before(s.Time)
+				}
+				return !date.Before(s.Time)
+			},
+		}, nil
+
 	case *query.Boost:
 		ct, err := d.newMatchTree(s.Child, opt)
 		if err != nil {
@@ -1078,6 +1413,21 @@ func (d *indexData) newMatchTree(q query.Q, opt matchTreeOpt) (matchTree, error)
 	case *query.Substring:
 		return d.newSubstringMatchTree(s)
 
+	case *query.Fuzzy:
+		return d.newMatchTree(query.Map(d.expandFuzzy(s, opt.visible), query.ExpandFileContent), opt)
+
+	case *query.InSymbol:
+		ct, err := d.newMatchTree(s.Child, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		scopes := &symbolScopeSet{d: d, re: s.Symbol}
+		return &inSymbolMatchTree{
+			child:  scopeMatchTreeAtoms(ct, scopes),
+			scopes: scopes,
+		}, nil
+
 	case *query.Branch:
 		masks := make([]uint64, 0, len(d.repoMetaData))
 		if s.Pattern == "HEAD" {
@@ -1258,6 +1608,32 @@ func (d *indexData) newMatchTree(q query.Q, opt matchTreeOpt) (matchTree, error)
 	return nil, nil
 }
 
+// scopeMatchTreeAtoms wraps the content atoms of mt in a symbolScopeMatchTree.
+// Subtrees which don't contribute matches are left alone.
+func scopeMatchTreeAtoms(mt matchTree, scopes *symbolScopeSet) matchTree {
+	switch s := mt.(type) {
+	case *andMatchTree:
+		for i, ch := range s.children {
+			s.children[i] = scopeMatchTreeAtoms(ch, scopes)
+		}
+	case *orMatchTree:
+		for i, ch := range s.children {
+			s.children[i] = scopeMatchTreeAtoms(ch, scopes)
+		}
+	case *andLineMatchTree:
+		scopeMatchTreeAtoms(&s.andMatchTree, scopes)
+	case *nearMatchTree:
+		scopeMatchTreeAtoms(&s.andMatchTree, scopes)
+	case *notMatchTree:
+		s.child = scopeMatchTreeAtoms(s.child, scopes)
+	case *boostMatchTree:
+		s.child = scopeMatchTreeAtoms(s.child, scopes)
+	case *substrMatchTree, *regexpMatchTree, *wordMatchTree:
+		return &symbolScopeMatchTree{matchTree: mt, scopes: scopes}
+	}
+	return mt
+}
+
 func (d *indexData) newSubstringMatchTree(s *query.Substring) (matchTree, error) {
 	st := &substrMatchTree{
 		query:         s,
@@ -1266,12 +1642,25 @@ func (d *indexData) newSubstringMatchTree(s *query.Substring) (matchTree, error)
 	}
 
 	if utf8.RuneCountInString(s.Pattern) < ngramSize {
-		return newRegexpMatchTree(&query.Regexp{
+		rmt := newRegexpMatchTree(&query.Regexp{
 			Regexp:        &syntax.Regexp{Op: syntax.OpLiteral, Rune: []rune(s.Pattern)},
 			FileName:      s.FileName,
 			Content:       s.Content,
 			CaseSensitive: s.CaseSensitive,
-		}), nil
+		})
+		if s.FileName || !d.tokens.enabled() || !isTokenPattern(s.Pattern) {
+			return rmt, nil
+		}
+
+		// Too short for ngrams, but the token index narrows the documents
+		// down.
+		docs, err := d.tokens.containing(s.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		return &andMatchTree{
+			children: []matchTree{d.newTokenDocMatchTree(s.Pattern, docs), rmt},
+		}, nil
 	}
 
 	result, err := d.iterateNgrams(s)
@@ -1376,6 +1765,22 @@ func pruneMatchTree(mt matchTree) (matchTree, error) {
 		if mt.child == nil {
 			return nil, nil
 		}
+	case *inSymbolMatchTree:
+		mt.child, err = pruneMatchTree(mt.child)
+		if err != nil {
+			return nil, err
+		}
+		if mt.child == nil {
+			return nil, nil
+		}
+	case *symbolScopeMatchTree:
+		mt.matchTree, err = pruneMatchTree(mt.matchTree)
+		if err != nil {
+			return nil, err
+		}
+		if mt.matchTree == nil {
+			return nil, nil
+		}
 	case *andLineMatchTree:
 		child, err := pruneMatchTree(&mt.andMatchTree)
 		if err != nil {
@@ -1391,6 +1796,21 @@ func pruneMatchTree(mt matchTree) (matchTree, error) {
 			// so the linematch portion is irrelevant.
 			return mt, nil
 		}
+	case *nearMatchTree:
+		child, err := pruneMatchTree(&mt.andMatchTree)
+		if err != nil {
+			return nil, err
+		}
+		if child == nil {
+			return nil, nil
+		}
+		if c, ok := child.(*andMatchTree); ok {
+			mt.andMatchTree = *c
+		} else {
+			// the and was simplified to a single clause, so there is nothing
+			// left to be near to.
+			return child, nil
+		}
 	case *notMatchTree:
 		mt.child, err = pruneMatchTree(mt.child)
 		if err != nil {
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"math"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"sort"
 
 	"github.com/sourcegraph/zoekt"
@@ -42,7 +44,7 @@ func Merge(dstDir string, files ...IndexFile) (tmpName, dstName string, _ error)
 		}
 	}
 
-	dstName = filepath.Join(dstDir, fmt.Sprintf("compound-%x_v%d.%05d.zoekt", hasher.Sum(nil), NextIndexFormatVersion, 0))
+	dstName = filepath.Join(dstDir, fmt.Sprintf("compound-%x_v%d.%05d.zoekt", hasher.Sum(nil), ib.indexFormatVersion, 0))
 	tmpName = dstName + ".tmp"
 	if err := builderWriteAll(tmpName, ib); err != nil {
 		return "", "", err
@@ -89,6 +91,24 @@ func builderWriteAll(fn string, ib *ShardBuilder) error {
 	return nil
 }
 
+// mergeFormatVersion returns the format version of the compound shard
+// merging ds. It is about as large as ds together, so like the builder we
+// only write 64-bit section offsets if it may come close to 4 GiB.
+func mergeFormatVersion(ds []*indexData) (int, error) {
+	var size uint64
+	for _, d := range ds {
+		sz, err := d.file.Size()
+		if err != nil {
+			return 0, err
+		}
+		size += sz
+	}
+	if size > math.MaxUint32/4*3 {
+		return NextIndexFormatVersion, nil
+	}
+	return compoundFormatVersion, nil
+}
+
 func merge(ds ...*indexData) (*ShardBuilder, error) {
 	if len(ds) == 0 {
 		return nil, fmt.Errorf("need 1 or more indexData to merge")
@@ -99,9 +119,23 @@ func merge(ds ...*indexData) (*ShardBuilder, error) {
 	})
 
 	sb := newShardBuilder()
-	sb.indexFormatVersion = NextIndexFormatVersion
+	var err error
+	if sb.indexFormatVersion, err = mergeFormatVersion(ds); err != nil {
+		return nil, err
+	}
+
+	// keep the token index only if all shards have one, since it must list
+	// every document.
+	if !slices.ContainsFunc(ds, func(d *indexData) bool { return !d.tokens.enabled() }) {
+		sb.tokens = map[string][]uint32{}
+	}
 
 	for _, d := range ds {
+		// keep contents compressed if any of the shards compressed them
+		if d.metaData.ContentEncoding != "" {
+			sb.contentEncoding = d.metaData.ContentEncoding
+		}
+
 		lastRepoID := -1
 		for docID := uint32(0); int(docID) < len(d.fileBranchMasks); docID++ {
 			repoID := int(d.repos[docID])
@@ -248,6 +282,10 @@ func explode(dstDir string, f IndexFile, ibFuncs ...shardBuilderFunc) (map[strin
 
 			sb = newShardBuilder()
 			sb.indexFormatVersion = IndexFormatVersion
+			sb.contentEncoding = d.metaData.ContentEncoding
+			if d.tokens.enabled() {
+				sb.tokens = map[string][]uint32{}
+			}
 			if err := sb.setRepository(&d.repoMetaData[repoID]); err != nil {
 				return shardNames, err
 			}
@@ -275,6 +313,9 @@ func addDocument(d *indexData, ib *ShardBuilder, repoID int, docID uint32) error
 		// Branches set below since it requires lookups
 		SubRepositoryPath: d.subRepoPaths[repoID][d.subRepos[docID]],
 		Language:          d.languageMap[d.getLanguage(docID)],
+		CommitDate:        d.getCommitDate(docID),
+		// Category is recomputed by the builder, except for commit documents.
+		Category: d.getCategory(docID),
 		// SkipReason not set, will be part of content from original indexer.
 	}
 
@@ -292,6 +333,16 @@ func addDocument(d *indexData, ib *ShardBuilder, repoID int, docID uint32) error
 		doc.SymbolsMetaData[i] = d.symbols.data(d.fileEndSymbol[docID] + uint32(i))
 	}
 
+	if doc.SymbolScopes, _, err = d.readSymbolScopes(docID, nil); err != nil {
+		return err
+	}
+
+	if doc.CommitHash, doc.CommitAuthor, err = d.readCommit(docID); err != nil {
+		return err
+	}
+
+	doc.ACL = d.acls.labelsOf(docID)
+
 	// calculate branches
 	{
 		mask := d.fileBranchMasks[docID]
@@ -15,8 +15,8 @@ import (
 // identical.
 func TestExplode(t *testing.T) {
 	simpleShards := []string{
-		".././testdata/shards/repo_v16.00000.zoekt",
-		".././testdata/shards/repo2_v16.00000.zoekt",
+		".././testdata/shards/current/repo_v16.00000.zoekt",
+		".././testdata/shards/current/repo2_v16.00000.zoekt",
 	}
 
 	// repo name -> IndexMetadata
@@ -126,3 +126,37 @@ func checkSameShards(t *testing.T, shard1, shard2 string) {
 
 	t.Fatalf("-%s\n+%s:\n%s", shard1, shard2, d)
 }
+
+// sizedFile is an empty IndexFile which claims to be size bytes large.
+type sizedFile struct {
+	memSeeker
+	size uint64
+}
+
+func (f *sizedFile) Size() (uint64, error) {
+	return f.size, nil
+}
+
+func TestMergeFormatVersion(t *testing.T) {
+	for _, tc := range []struct {
+		sizes []uint64
+		want  int
+	}{
+		{sizes: []uint64{1 << 20, 1 << 20}, want: compoundFormatVersion},
+		{sizes: []uint64{1 << 30, 1 << 30}, want: compoundFormatVersion},
+		// Only shards which may come close to 4 GiB get 64-bit offsets.
+		{sizes: []uint64{2 << 30, 2 << 30}, want: NextIndexFormatVersion},
+	} {
+		var ds []*indexData
+		for _, size := range tc.sizes {
+			ds = append(ds, &indexData{file: &sizedFile{size: size}})
+		}
+		got, err := mergeFormatVersion(ds)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.want {
+			t.Errorf("merging %v bytes: got v%d, want v%d", tc.sizes, got, tc.want)
+		}
+	}
+}
@@ -15,11 +15,13 @@
 package index
 
 import (
+	"bytes"
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"hash/crc64"
 	"log"
+	"math"
 	"os"
 	"slices"
 	"sort"
@@ -35,8 +37,8 @@ import (
 // IndexFile is a file suitable for concurrent read access. For performance
 // reasons, it allows a mmap'd implementation.
 type IndexFile interface {
-	Read(off uint32, sz uint32) ([]byte, error)
-	Size() (uint32, error)
+	Read(off uint64, sz uint64) ([]byte, error)
+	Size() (uint64, error)
 	Close()
 	Name() string
 }
@@ -44,10 +46,13 @@ type IndexFile interface {
 // reader is a stateful file
 type reader struct {
 	r   IndexFile
-	off uint32
+	off uint64
+
+	// offsets64 is set by readHeader for files with 64-bit section offsets.
+	offsets64 bool
 }
 
-func (r *reader) seek(off uint32) {
+func (r *reader) seek(off uint64) {
 	r.off = off
 }
 
@@ -69,6 +74,15 @@ func (r *reader) U64() (uint64, error) {
 	return binary.BigEndian.Uint64(b), nil
 }
 
+// Offset reads a section offset or size, see writer.Offset.
+func (r *reader) Offset() (uint64, error) {
+	if r.offsets64 {
+		return r.U64()
+	}
+	v, err := r.U32()
+	return uint64(v), err
+}
+
 func (r *reader) ReadByte() (byte, error) {
 	b, err := r.r.Read(r.off, 1)
 	r.off += 1
@@ -91,11 +105,11 @@ func (r *reader) Str() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	b, err := r.r.Read(r.off, uint32(slen))
+	b, err := r.r.Read(r.off, slen)
 	if err != nil {
 		return "", err
 	}
-	r.off += uint32(slen)
+	r.off += slen
 	return string(b), nil
 }
 
@@ -180,16 +194,10 @@ func (r *reader) readTOCSections(toc *indexTOC, tags []string) error {
 }
 
 func (r *reader) readHeader() (simpleSection, uint32, error) {
-	sz, err := r.r.Size()
+	tocSection, err := r.readTrailer()
 	if err != nil {
 		return simpleSection{}, 0, err
 	}
-	r.off = sz - 8
-
-	var tocSection simpleSection
-	if err := tocSection.read(r); err != nil {
-		return simpleSection{}, 0, err
-	}
 
 	r.seek(tocSection.off)
 
@@ -200,6 +208,39 @@ func (r *reader) readHeader() (simpleSection, uint32, error) {
 	return tocSection, sectionCount, nil
 }
 
+// readTrailer reads the offset and size of the TOC at the end of the file.
+// Up to v17 they are 32-bit. From offsets64FormatVersion on they are 64-bit,
+// and so are all section offsets. A 32-bit trailer never passes for a 64-bit
+// one: read as 64-bit, its size would hold the nonzero TOC offset in the
+// upper 32 bits, which exceeds the size of any file with 32-bit offsets.
+func (r *reader) readTrailer() (simpleSection, error) {
+	sz, err := r.r.Size()
+	if err != nil {
+		return simpleSection{}, err
+	}
+
+	if sz >= 16 {
+		b, err := r.r.Read(sz-16, 16)
+		if err != nil {
+			return simpleSection{}, err
+		}
+		off, tocSz := binary.BigEndian.Uint64(b), binary.BigEndian.Uint64(b[8:])
+		if off <= sz-16 && tocSz == sz-16-off {
+			r.offsets64 = true
+			return simpleSection{off: off, sz: tocSz}, nil
+		}
+	}
+
+	if sz < 8 {
+		return simpleSection{}, fmt.Errorf("file too small: %d bytes", sz)
+	}
+	r.offsets64 = false
+	r.off = sz - 8
+	var tocSection simpleSection
+	err = tocSection.read(r)
+	return tocSection, err
+}
+
 func (r *indexData) readSectionBlob(sec simpleSection) ([]byte, error) {
 	return r.file.Read(sec.off, sec.sz)
 }
@@ -220,6 +261,22 @@ func readSectionU32(f IndexFile, sec simpleSection) ([]uint32, error) {
 	return arr, nil
 }
 
+// readSectionOffsets reads a section of offsets written with writer.Offset.
+func readSectionOffsets(r *reader, sec simpleSection) ([]uint64, error) {
+	if r.offsets64 {
+		return readSectionU64(r.r, sec)
+	}
+	arr32, err := readSectionU32(r.r, sec)
+	if err != nil {
+		return nil, err
+	}
+	arr := make([]uint64, 0, len(arr32))
+	for _, o := range arr32 {
+		arr = append(arr, uint64(o))
+	}
+	return arr, nil
+}
+
 func readSectionU64(f IndexFile, sec simpleSection) ([]uint64, error) {
 	if sec.sz%8 != 0 {
 		return nil, fmt.Errorf("barf: section size %% 8 != 0: sz %d ", sec.sz)
@@ -248,8 +305,13 @@ func (r *reader) readJSON(data any, sec simpleSection) error {
 // canReadVersion returns checks if zoekt can read in md. If it can't a
 // non-nil error is returned.
 func canReadVersion(md *zoekt.IndexMetadata) bool {
-	// Backwards compatible with v16
-	return md.IndexFormatVersion == IndexFormatVersion || md.IndexFormatVersion == NextIndexFormatVersion
+	// Backwards compatible with v16 and v17, the compound shards written
+	// before 64-bit offsets.
+	switch md.IndexFormatVersion {
+	case IndexFormatVersion, 17, NextIndexFormatVersion:
+		return true
+	}
+	return false
 }
 
 func (r *reader) readIndexData(toc *indexTOC) (*indexData, error) {
@@ -280,14 +342,65 @@ func (r *reader) readIndexData(toc *indexTOC) (*indexData, error) {
 		return nil, fmt.Errorf("file needs read feature version >= %d, have read feature version %d", d.metaData.IndexMinReaderVersion, FeatureVersion)
 	}
 
-	d.boundariesStart = toc.fileContents.data.off
-	d.boundaries = toc.fileContents.relativeIndex()
+	// Items of compound sections are addressed with 32-bit offsets relative
+	// to the start of the section, see relativeIndex.
+	for _, sec := range []*compoundSection{
+		&toc.fileContents, &toc.fileContentBlocks, &toc.newlines, &toc.fileSections,
+		&toc.fileSymbolScopes, &toc.fileCommits, &toc.fileACLs, &toc.fileNames,
+		&toc.symbolKindMap, &toc.tokens, &toc.tokenPostings,
+	} {
+		if sec.data.sz > math.MaxUint32 {
+			return nil, fmt.Errorf("section of %d bytes exceeds 4 GiB", sec.data.sz)
+		}
+	}
+
+	switch d.metaData.ContentEncoding {
+	case "":
+		d.boundariesStart = toc.fileContents.data.off
+		d.boundaries = toc.fileContents.relativeIndex()
+	case ContentEncodingZstd:
+		if d.metaData.ContentBlockDocs <= 0 {
+			return nil, fmt.Errorf("invalid content block size %d", d.metaData.ContentBlockDocs)
+		}
+		d.boundaries, err = readSectionU32(d.file, toc.fileContentOffsets)
+		if err != nil {
+			return nil, err
+		}
+		d.contentBlocks = &contentBlocks{
+			file:  d.file,
+			start: toc.fileContentBlocks.data.off,
+			index: toc.fileContentBlocks.relativeIndex(),
+			docs:  uint32(d.metaData.ContentBlockDocs),
+			cache: newBlockCache(contentBlockCacheSize),
+		}
+	default:
+		return nil, fmt.Errorf("unknown content encoding %q", d.metaData.ContentEncoding)
+	}
 	d.newlinesStart = toc.newlines.data.off
 	d.newlinesIndex = toc.newlines.relativeIndex()
 	d.docSectionsStart = toc.fileSections.data.off
 	d.docSectionsIndex = toc.fileSections.relativeIndex()
+	d.symbolScopesStart = toc.fileSymbolScopes.data.off
+	d.symbolScopesIndex = toc.fileSymbolScopes.relativeIndex()
+	d.commitsStart = toc.fileCommits.data.off
+	d.commitsIndex = toc.fileCommits.relativeIndex()
+
+	if d.acls, err = d.readACLs(&toc.fileACLs); err != nil {
+		return nil, err
+	}
+
+	d.tokens = tokenIndex{
+		file:          d.file,
+		textIndex:     toc.tokens.relativeIndex(),
+		postingsStart: toc.tokenPostings.data.off,
+		postingsIndex: toc.tokenPostings.relativeIndex(),
+	}
+	if d.tokens.text, err = d.readSectionBlob(toc.tokens.data); err != nil {
+		return nil, err
+	}
 
 	d.symbols.symKindIndex = toc.symbolKindMap.relativeIndex()
+	d.symbols.offsets64 = r.offsets64
 	d.fileEndSymbol, err = readSectionU32(d.file, toc.fileEndSymbol)
 	if err != nil {
 		return nil, err
@@ -320,8 +433,17 @@ func (r *reader) readIndexData(toc *indexTOC) (*indexData, error) {
 	if err != nil {
 		return nil, err
 	}
+	commitCategory, _ := FileCategoryCommit.encode()
+	d.hasCommitDocs = bytes.IndexByte(d.categories, commitCategory) >= 0
+
+	if toc.fileCommitDates.sz > 0 {
+		d.commitDates, err = readSectionU64(d.file, toc.fileCommitDates)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	d.contentNgrams, err = d.newBtreeIndex(toc.ngramText, toc.postings)
+	d.contentNgrams, err = d.newBtreeIndex(toc.ngramText, toc.postings, r.offsets64)
 	if err != nil {
 		return nil, err
 	}
@@ -338,7 +460,7 @@ func (r *reader) readIndexData(toc *indexTOC) (*indexData, error) {
 
 	d.fileNameIndex = toc.fileNames.relativeIndex()
 
-	d.fileNameNgrams, err = d.newBtreeIndex(toc.nameNgramText, toc.namePostings)
+	d.fileNameNgrams, err = d.newBtreeIndex(toc.nameNgramText, toc.namePostings, r.offsets64)
 	if err != nil {
 		return nil, err
 	}
@@ -467,8 +589,8 @@ func (r *reader) parseMetadata(metaData simpleSection, repoMetaData simpleSectio
 
 const ngramEncoding = 8
 
-func (d *indexData) newBtreeIndex(ngramSec simpleSection, postings compoundSection) (btreeIndex, error) {
-	bi := btreeIndex{file: d.file}
+func (d *indexData) newBtreeIndex(ngramSec simpleSection, postings compoundSection, offsets64 bool) (btreeIndex, error) {
+	bi := btreeIndex{file: d.file, offsets64: offsets64}
 
 	textContent, err := d.readSectionBlob(ngramSec)
 	if err != nil {
@@ -517,27 +639,36 @@ func (d *indexData) verify() error {
 	return nil
 }
 
+// itemSection returns the section of item i of a compound section, given
+// the start of its data and its relativeIndex.
+func itemSection(start uint64, index []uint32, i uint32) simpleSection {
+	return simpleSection{
+		off: start + uint64(index[i]),
+		sz:  uint64(index[i+1] - index[i]),
+	}
+}
+
 func (d *indexData) readContents(i uint32) ([]byte, error) {
-	return d.readSectionBlob(simpleSection{
-		off: d.boundariesStart + d.boundaries[i],
-		sz:  d.boundaries[i+1] - d.boundaries[i],
-	})
+	if d.contentBlocks != nil {
+		return d.contentBlocks.readContents(d.boundaries, i)
+	}
+	return d.readSectionBlob(itemSection(d.boundariesStart, d.boundaries, i))
 }
 
 func (d *indexData) readContentSlice(off uint32, sz uint32) ([]byte, error) {
+	if d.contentBlocks != nil {
+		return d.contentBlocks.readContentSlice(d.boundaries, off, sz)
+	}
 	// TODO(hanwen): cap result if it is at the end of the content
 	// section.
 	return d.readSectionBlob(simpleSection{
-		off: d.boundariesStart + off,
-		sz:  sz,
+		off: d.boundariesStart + uint64(off),
+		sz:  uint64(sz),
 	})
 }
 
 func (d *indexData) readNewlines(i uint32, buf []uint32) ([]uint32, uint32, error) {
-	sec := simpleSection{
-		off: d.newlinesStart + d.newlinesIndex[i],
-		sz:  d.newlinesIndex[i+1] - d.newlinesIndex[i],
-	}
+	sec := itemSection(d.newlinesStart, d.newlinesIndex, i)
 	blob, err := d.readSectionBlob(sec)
 	if err != nil {
 		return nil, 0, err
@@ -550,14 +681,11 @@ func (d *indexData) readNewlines(i uint32, buf []uint32) ([]uint32, uint32, erro
 	if nl == nil {
 		nl = make([]uint32, 0)
 	}
-	return nl, sec.sz, nil
+	return nl, uint32(sec.sz), nil
 }
 
 func (d *indexData) readDocSections(i uint32, buf []DocumentSection) ([]DocumentSection, uint32, error) {
-	sec := simpleSection{
-		off: d.docSectionsStart + d.docSectionsIndex[i],
-		sz:  d.docSectionsIndex[i+1] - d.docSectionsIndex[i],
-	}
+	sec := itemSection(d.docSectionsStart, d.docSectionsIndex, i)
 	blob, err := d.readSectionBlob(sec)
 	if err != nil {
 		return nil, 0, err
@@ -571,7 +699,43 @@ func (d *indexData) readDocSections(i uint32, buf []DocumentSection) ([]Document
 		ds = make([]DocumentSection, 0)
 	}
 
-	return ds, sec.sz, nil
+	return ds, uint32(sec.sz), nil
+}
+
+// readSymbolScopes returns the byte ranges enclosing the symbols of document
+// i. It returns nil if the shard was written without symbol scopes.
+func (d *indexData) readSymbolScopes(i uint32, buf []DocumentSection) ([]DocumentSection, uint32, error) {
+	if len(d.symbolScopesIndex) == 0 {
+		return nil, 0, nil
+	}
+
+	sec := itemSection(d.symbolScopesStart, d.symbolScopesIndex, i)
+	blob, err := d.readSectionBlob(sec)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ds := unmarshalDocSections(blob, buf)
+	if ds == nil {
+		ds = make([]DocumentSection, 0)
+	}
+
+	return ds, uint32(sec.sz), nil
+}
+
+// readCommit returns the hash and author of the last commit that touched
+// document i. They are empty if the shard was written without commits.
+func (d *indexData) readCommit(i uint32) (hash, author string, err error) {
+	if len(d.commitsIndex) == 0 {
+		return "", "", nil
+	}
+
+	blob, err := d.readSectionBlob(itemSection(d.commitsStart, d.commitsIndex, i))
+	if err != nil {
+		return "", "", err
+	}
+
+	return unmarshalCommit(blob)
 }
 
 // NewSearcher creates a Searcher for a single index file.  Search
@@ -19,7 +19,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/fs"
+	"math"
 	"os"
 	"path"
 	"path/filepath"
@@ -151,7 +153,7 @@ func TestGet(t *testing.T) {
 		t.Fatalf("readIndexData: %v", err)
 	}
 
-	var off uint32 = 96
+	var off uint64 = 96
 
 	cases := []struct {
 		ng              string
@@ -469,3 +471,89 @@ func BenchmarkReadMetadata(b *testing.B) {
 		}
 	}
 }
+
+func TestReadWriteOffsets64(t *testing.T) {
+	doc := Document{
+		Name:    "f.go",
+		Content: []byte("func (k *Klass) Rename() {}\nfunc helper() {}\n"),
+		Symbols: []DocumentSection{{Start: 16, End: 22}, {Start: 33, End: 39}},
+		SymbolsMetaData: []*zoekt.Symbol{
+			{Sym: "Rename", Kind: "method", Parent: "Klass", ParentKind: "struct"},
+			{Sym: "helper", Kind: "function"},
+		},
+	}
+
+	search := func(t *testing.T, version int) []zoekt.FileMatch {
+		b, err := NewShardBuilder(&zoekt.Repository{Name: "repo"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.indexFormatVersion = version
+		for _, d := range []Document{doc, {Name: "g.txt", Content: []byte("Rename helper")}} {
+			if err := b.Add(d); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		var buf bytes.Buffer
+		if err := b.Write(&buf); err != nil {
+			t.Fatal(err)
+		}
+
+		r := reader{r: &memSeeker{buf.Bytes()}}
+		var toc indexTOC
+		if err := r.readTOC(&toc); err != nil {
+			t.Fatal(err)
+		}
+		if want := version >= offsets64FormatVersion; r.offsets64 != want {
+			t.Fatalf("got offsets64 %v, want %v", r.offsets64, want)
+		}
+
+		searcher, err := NewSearcher(&memSeeker{buf.Bytes()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer searcher.Close()
+
+		q := query.NewOr(
+			&query.Symbol{Expr: &query.Substring{Pattern: "Rename", Content: true}},
+			&query.Substring{Pattern: "helper", Content: true},
+		)
+		res, err := searcher.Search(context.Background(), q, &zoekt.SearchOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		clearScores(res)
+		return res.Files
+	}
+
+	want := search(t, IndexFormatVersion)
+	if len(want) != 2 {
+		t.Fatalf("got %d files, want 2", len(want))
+	}
+	got := search(t, NextIndexFormatVersion)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("v%d and v%d results differ (-v%d +v%d):\n%s", IndexFormatVersion, NextIndexFormatVersion, IndexFormatVersion, NextIndexFormatVersion, d)
+	}
+}
+
+func TestWriterOffset(t *testing.T) {
+	w := &writer{w: io.Discard}
+	w.Offset(math.MaxUint32)
+	if w.err != nil {
+		t.Fatal(w.err)
+	}
+	w.Offset(math.MaxUint32 + 1)
+	if w.err == nil {
+		t.Fatal("want error for offset beyond 32 bits")
+	}
+
+	w = &writer{w: io.Discard, offsets64: true}
+	w.Offset(math.MaxUint32 + 1)
+	if w.err != nil {
+		t.Fatal(w.err)
+	}
+	if w.Off() != 8 {
+		t.Errorf("got %d bytes, want 8", w.Off())
+	}
+}
@@ -16,8 +16,10 @@ package index
 
 import (
 	"encoding/binary"
+	"fmt"
 	"io"
 	"log"
+	"math"
 )
 
 var _ = log.Println
@@ -26,7 +28,10 @@ var _ = log.Println
 type writer struct {
 	err error
 	w   io.Writer
-	off uint32
+	off uint64
+
+	// offsets64 selects 64-bit section offsets, see Offset.
+	offsets64 bool
 }
 
 func (w *writer) Write(b []byte) (int, error) {
@@ -36,11 +41,11 @@ func (w *writer) Write(b []byte) (int, error) {
 
 	var n int
 	n, w.err = w.w.Write(b)
-	w.off += uint32(n)
+	w.off += uint64(n)
 	return n, w.err
 }
 
-func (w *writer) Off() uint32 { return w.off }
+func (w *writer) Off() uint64 { return w.off }
 
 func (w *writer) B(b byte) {
 	s := []byte{b}
@@ -59,6 +64,19 @@ func (w *writer) U64(n uint64) {
 	w.Write(enc[:])
 }
 
+// Offset writes a section offset or size. Without offsets64 they are
+// 32-bit, and larger values fail the write.
+func (w *writer) Offset(n uint64) {
+	if w.offsets64 {
+		w.U64(n)
+		return
+	}
+	if n > math.MaxUint32 && w.err == nil {
+		w.err = fmt.Errorf("offset %d does not fit in 32 bits, needs index format v%d", n, offsets64FormatVersion)
+	}
+	w.U32(uint32(n))
+}
+
 func (w *writer) Varint(n uint32) {
 	var enc [8]byte
 	m := binary.PutUvarint(enc[:], uint64(n))
@@ -100,8 +118,8 @@ const (
 
 // simpleSection is a simple range of bytes.
 type simpleSection struct {
-	off uint32
-	sz  uint32
+	off uint64
+	sz  uint64
 }
 
 func (s *simpleSection) kind() sectionKind {
@@ -110,27 +128,27 @@ func (s *simpleSection) kind() sectionKind {
 
 func (s *simpleSection) read(r *reader) error {
 	var err error
-	s.off, err = r.U32()
+	s.off, err = r.Offset()
 	if err != nil {
 		return err
 	}
-	s.sz, err = r.U32()
+	s.sz, err = r.Offset()
 	return err
 }
 
 func (s *simpleSection) skip(r *reader) error {
 	var err error
-	_, err = r.U32()
+	_, err = r.Offset()
 	if err != nil {
 		return err
 	}
-	_, err = r.U32()
+	_, err = r.Offset()
 	return err
 }
 
 func (s *simpleSection) write(w *writer) {
-	w.U32(s.off)
-	w.U32(s.sz)
+	w.Offset(s.off)
+	w.Offset(s.sz)
 }
 
 // compoundSection is a range of bytes containg a list of variable
@@ -138,7 +156,7 @@ func (s *simpleSection) write(w *writer) {
 type compoundSection struct {
 	data simpleSection
 
-	offsets []uint32
+	offsets []uint64
 	index   simpleSection
 }
 
@@ -152,9 +170,14 @@ func (s *compoundSection) start(w *writer) {
 
 func (s *compoundSection) end(w *writer) {
 	s.data.end(w)
+	// Items are addressed relative to the start of the data, see
+	// relativeIndex.
+	if s.data.sz > math.MaxUint32 && w.err == nil {
+		w.err = fmt.Errorf("section of %d bytes exceeds 4 GiB", s.data.sz)
+	}
 	s.index.start(w)
 	for _, o := range s.offsets {
-		w.U32(o)
+		w.Offset(o)
 	}
 	s.index.end(w)
 }
@@ -177,7 +200,7 @@ func (s *compoundSection) read(r *reader) error {
 		return err
 	}
 	var err error
-	s.offsets, err = readSectionU32(r.r, s.index)
+	s.offsets, err = readSectionOffsets(r, s.index)
 	return err
 }
 
@@ -195,13 +218,14 @@ func (s *compoundSection) skip(r *reader) error {
 
 // relativeIndex returns the relative offsets of the items (first
 // element is 0), plus a final marking the end of the last item.
+// The data of the section must be smaller than 4 GiB, see maxShardMax.
 func (s *compoundSection) relativeIndex() []uint32 {
 	ri := make([]uint32, 0, len(s.offsets)+1)
 	for _, o := range s.offsets {
-		ri = append(ri, o-s.offsets[0])
+		ri = append(ri, uint32(o-s.offsets[0]))
 	}
 	if len(s.offsets) > 0 {
-		ri = append(ri, s.data.sz)
+		ri = append(ri, uint32(s.data.sz))
 	}
 	return ri
 }
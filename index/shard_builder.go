@@ -172,11 +172,23 @@ type ShardBuilder struct {
 	indexFormatVersion int
 	featureVersion     int
 
+	// contentEncoding is the encoding of document contents, empty for raw
+	// contents or ContentEncodingZstd.
+	contentEncoding string
+
+	// tokens maps the tokens of the contents to the documents containing
+	// them. It is nil if the shard has no token index, see tokenIndex.
+	tokens map[string][]uint32
+
 	contentStrings  []*searchableString
 	nameStrings     []*searchableString
 	docSections     [][]DocumentSection
 	runeDocSections []DocumentSection
 
+	// docSymbolScopes holds the byte ranges enclosing each symbol in
+	// docSections.
+	docSymbolScopes [][]DocumentSection
+
 	symID        uint32
 	symIndex     map[string]uint32
 	symKindID    uint32
@@ -210,6 +222,20 @@ type ShardBuilder struct {
 
 	categories []byte
 
+	// commitDates holds the Unix time of each document's CommitDate, or 0 if
+	// it is unknown.
+	commitDates    []uint64
+	hasCommitDates bool
+
+	// commits holds the encoded hash and author of each document's last
+	// commit, see marshalCommit.
+	commits    [][]byte
+	hasCommits bool
+
+	// acls holds the encoded ACL labels of each document, see marshalACL.
+	acls    [][]byte
+	hasACLs bool
+
 	// IndexTime will be used as the time if non-zero. Otherwise
 	// time.Now(). This is useful for doing reproducible builds in tests.
 	IndexTime time.Time
@@ -324,6 +350,7 @@ func (b *ShardBuilder) setRepository(desc *zoekt.Repository) error {
 type symbolSlice struct {
 	symbols  []DocumentSection
 	metaData []*zoekt.Symbol
+	scopes   []DocumentSection
 }
 
 func (s symbolSlice) Len() int { return len(s.symbols) }
@@ -331,6 +358,7 @@ func (s symbolSlice) Len() int { return len(s.symbols) }
 func (s symbolSlice) Swap(i, j int) {
 	s.symbols[i], s.symbols[j] = s.symbols[j], s.symbols[i]
 	s.metaData[i], s.metaData[j] = s.metaData[j], s.metaData[i]
+	s.scopes[i], s.scopes[j] = s.scopes[j], s.scopes[i]
 }
 
 func (s symbolSlice) Less(i, j int) bool {
@@ -424,12 +452,24 @@ func (b *ShardBuilder) Add(doc Document) error {
 		doc.Content = []byte(notIndexedMarker + doc.SkipReason.explanation())
 		doc.Symbols = nil
 		doc.SymbolsMetaData = nil
+		doc.SymbolScopes = nil
 	}
 
 	DetermineLanguageIfUnknown(&doc)
 	DetermineFileCategory(&doc)
 
-	sort.Sort(symbolSlice{doc.Symbols, doc.SymbolsMetaData})
+	if doc.SymbolScopes == nil {
+		doc.SymbolScopes = slices.Clone(doc.Symbols)
+	} else if len(doc.SymbolScopes) != len(doc.Symbols) {
+		return fmt.Errorf("have %d symbol scopes, want %d", len(doc.SymbolScopes), len(doc.Symbols))
+	}
+	for _, s := range doc.SymbolScopes {
+		if s.Start > s.End || s.End > uint32(len(doc.Content)) {
+			return fmt.Errorf("symbol scope out of bounds")
+		}
+	}
+
+	sort.Sort(symbolSlice{doc.Symbols, doc.SymbolsMetaData, doc.SymbolScopes})
 	var last DocumentSection
 	for i, s := range doc.Symbols {
 		if i > 0 {
@@ -478,6 +518,10 @@ func (b *ShardBuilder) Add(doc Document) error {
 		return fmt.Errorf("too many repos in shard: max is %d", 1<<16)
 	}
 
+	if b.tokens != nil {
+		b.addTokens(uint32(len(b.contentStrings)), doc.Content)
+	}
+
 	b.subRepos = append(b.subRepos, subRepoIdx)
 	b.repos = append(b.repos, uint16(repoIdx))
 
@@ -489,6 +533,7 @@ func (b *ShardBuilder) Add(doc Document) error {
 
 	b.nameStrings = append(b.nameStrings, nameStr)
 	b.docSections = append(b.docSections, doc.Symbols)
+	b.docSymbolScopes = append(b.docSymbolScopes, doc.SymbolScopes)
 	b.fileEndSymbol = append(b.fileEndSymbol, uint32(len(b.runeDocSections)))
 	b.branchMasks = append(b.branchMasks, mask)
 	b.checksums = append(b.checksums, hasher.Sum(nil)...)
@@ -509,6 +554,25 @@ func (b *ShardBuilder) Add(doc Document) error {
 	}
 	b.categories = append(b.categories, category)
 
+	var commitDate uint64
+	if !doc.CommitDate.IsZero() {
+		commitDate = uint64(doc.CommitDate.Unix())
+		b.hasCommitDates = true
+	}
+	b.commitDates = append(b.commitDates, commitDate)
+
+	commit := marshalCommit(doc.CommitHash, doc.CommitAuthor)
+	if commit != nil {
+		b.hasCommits = true
+	}
+	b.commits = append(b.commits, commit)
+
+	acl := marshalACL(doc.ACL)
+	if acl != nil {
+		b.hasACLs = true
+	}
+	b.acls = append(b.acls, acl)
+
 	return nil
 }
 
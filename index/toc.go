@@ -43,7 +43,13 @@ const IndexFormatVersion = 16
 // 10: Compound shards; more flexible TOC format.
 // 11: Bloom filters for file names & contents
 // 12: go-enry for identifying file languages
-const FeatureVersion = 12
+// 13: Symbol scopes for insym: queries
+// 14: Commit dates of documents
+// 15: Last commit hash and author of documents
+// 16: Optionally compressed document contents
+// 17: Optional token index
+// 18: Document ACL labels
+const FeatureVersion = 18
 
 // WriteMinFeatureVersion and ReadMinFeatureVersion constrain forwards and backwards
 // compatibility. For example, if a new way to encode filenameNgrams on disk is
@@ -66,19 +72,33 @@ const WriteMinFeatureVersion = 10
 const ReadMinFeatureVersion = 8
 
 // 17: compound shard (multi repo)
-const NextIndexFormatVersion = 17
+// 18: 64-bit section offsets
+const NextIndexFormatVersion = 18
+
+// compoundFormatVersion is the format version of compound shards, unless
+// they are too large for it, see mergeFormatVersion. Readers which don't
+// know offsets64FormatVersion yet can load them.
+const compoundFormatVersion = 17
+
+// offsets64FormatVersion is the first index format version whose section
+// offsets, and the trailer pointing at the TOC, are 64-bit.
+const offsets64FormatVersion = 18
 
 type indexTOC struct {
-	fileContents compoundSection
-	fileNames    compoundSection
-	fileSections compoundSection
-	postings     compoundSection
-	newlines     compoundSection
-	ngramText    simpleSection
-	runeOffsets  simpleSection
-	fileEndRunes simpleSection
-	languages    simpleSection
-	categories   simpleSection
+	fileContents     compoundSection
+	fileNames        compoundSection
+	fileSections     compoundSection
+	fileSymbolScopes compoundSection
+	fileCommitDates  simpleSection
+	fileCommits      compoundSection
+	fileACLs         compoundSection
+	postings         compoundSection
+	newlines         compoundSection
+	ngramText        simpleSection
+	runeOffsets      simpleSection
+	fileEndRunes     simpleSection
+	languages        simpleSection
+	categories       simpleSection
 
 	fileEndSymbol  simpleSection
 	symbolMap      lazyCompoundSection
@@ -100,6 +120,14 @@ type indexTOC struct {
 	repos          simpleSection
 	reposIDsBitmap simpleSection
 
+	// Compressed contents replace fileContents, see contentBlocks.
+	fileContentBlocks  compoundSection
+	fileContentOffsets simpleSection
+
+	// Optional, see tokenIndex.
+	tokens        compoundSection
+	tokenPostings compoundSection
+
 	ranks simpleSection
 }
 
@@ -162,8 +190,14 @@ func (t *indexTOC) sectionsTaggedList() []taggedSection {
 		{"metaData", &t.metaData},
 		{"repoMetaData", &t.repoMetaData},
 		{"fileContents", &t.fileContents},
+		{"fileContentBlocks", &t.fileContentBlocks},
+		{"fileContentOffsets", &t.fileContentOffsets},
 		{"fileNames", &t.fileNames},
 		{"fileSections", &t.fileSections},
+		{"fileSymbolScopes", &t.fileSymbolScopes},
+		{"fileCommitDates", &t.fileCommitDates},
+		{"fileCommits", &t.fileCommits},
+		{"fileACLs", &t.fileACLs},
 		{"fileEndSymbol", &t.fileEndSymbol},
 		{"symbolMap", &t.symbolMap},
 		{"symbolKindMap", &t.symbolKindMap},
@@ -185,6 +219,8 @@ func (t *indexTOC) sectionsTaggedList() []taggedSection {
 		{"runeDocSections", &t.runeDocSections},
 		{"repos", &t.repos},
 		{"reposIDsBitmap", &t.reposIDsBitmap},
+		{"tokens", &t.tokens},
+		{"tokenPostings", &t.tokenPostings},
 
 		// We no longer write these sections, but we still return them here to avoid
 		// warnings about unknown sections.
@@ -0,0 +1,177 @@
+package index
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// maxTokenLength is the length of the longest token in the token index.
+// Documents with longer tokens are listed under the empty token instead,
+// which sorts first.
+const maxTokenLength = 64
+
+// The token index is an optional secondary index of the identifier tokens
+// of the document contents: the maximal runs of bytes of characterClass,
+// lowercased. For every token it lists the documents containing it.
+//
+// Any occurrence of a pattern made up of characterClass bytes is inside a
+// token, so the documents with a token containing the pattern are a
+// superset of the documents matching it. Unlike ngrams, this also works for
+// patterns shorter than ngramSize.
+
+// addTokens adds the tokens of content to the token index for doc.
+func (b *ShardBuilder) addTokens(doc uint32, content []byte) {
+	add := func(tok string) {
+		docs := b.tokens[tok]
+		if len(docs) > 0 && docs[len(docs)-1] == doc {
+			return
+		}
+		b.tokens[tok] = append(docs, doc)
+	}
+
+	for i := 0; i < len(content); {
+		if !characterClass(content[i]) {
+			i++
+			continue
+		}
+		j := i + 1
+		for j < len(content) && characterClass(content[j]) {
+			j++
+		}
+		if j-i > maxTokenLength {
+			add("")
+		} else {
+			add(string(toLower(content[i:j])))
+		}
+		i = j
+	}
+}
+
+// writeTokens writes the sorted tokens into text and their documents into
+// postings, in the same order. The empty token is always written, so that
+// a shard with a token index has at least one token.
+func writeTokens(w *writer, tokens map[string][]uint32, text, postings *compoundSection) {
+	keys := make([]string, 0, len(tokens)+1)
+	for k := range tokens {
+		keys = append(keys, k)
+	}
+	if _, ok := tokens[""]; !ok {
+		keys = append(keys, "")
+	}
+	sort.Strings(keys)
+
+	text.start(w)
+	for _, k := range keys {
+		text.addItem(w, []byte(k))
+	}
+	text.end(w)
+
+	postings.start(w)
+	for _, k := range keys {
+		postings.addItem(w, toSizedDeltas(tokens[k]))
+	}
+	postings.end(w)
+}
+
+// tokenIndex reads the token index of a shard.
+type tokenIndex struct {
+	file IndexFile
+
+	text      []byte
+	textIndex []uint32
+
+	postingsStart uint64
+	postingsIndex []uint32
+}
+
+// enabled reports whether the shard has a token index.
+func (t *tokenIndex) enabled() bool {
+	return len(t.textIndex) > 1
+}
+
+func (t *tokenIndex) numTokens() int {
+	return len(t.textIndex) - 1
+}
+
+func (t *tokenIndex) token(i int) []byte {
+	return t.text[t.textIndex[i]:t.textIndex[i+1]]
+}
+
+func (t *tokenIndex) docs(i int) ([]uint32, error) {
+	blob, err := t.file.Read(t.postingsStart+uint64(t.postingsIndex[i]), uint64(t.postingsIndex[i+1]-t.postingsIndex[i]))
+	if err != nil {
+		return nil, err
+	}
+	return fromSizedDeltas(blob, nil), nil
+}
+
+// isTokenPattern reports whether pattern can be looked up in the token
+// index.
+func isTokenPattern(pattern string) bool {
+	if pattern == "" {
+		return false
+	}
+	for i := 0; i < len(pattern); i++ {
+		if !characterClass(pattern[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// word returns the documents which contain w as a token, ignoring case. w
+// must be a token pattern. It returns false if w is too long to be in the
+// index.
+func (t *tokenIndex) word(w string) ([]uint32, bool, error) {
+	if len(w) > maxTokenLength {
+		return nil, false, nil
+	}
+	want := toLower([]byte(w))
+	n := t.numTokens()
+	i := sort.Search(n, func(i int) bool { return bytes.Compare(t.token(i), want) >= 0 })
+	if i == n || !bytes.Equal(t.token(i), want) {
+		return nil, true, nil
+	}
+	docs, err := t.docs(i)
+	return docs, true, err
+}
+
+// containing returns the sorted documents which have a token containing
+// sub, ignoring case, and the documents with tokens which are too long to
+// be in the index. sub must be a token pattern.
+func (t *tokenIndex) containing(sub string) ([]uint32, error) {
+	want := toLower([]byte(sub))
+	var docs []uint32
+	for i := range t.numTokens() {
+		// The empty token lists the documents with long tokens.
+		if i > 0 && !bytes.Contains(t.token(i), want) {
+			continue
+		}
+		d, err := t.docs(i)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, d...)
+	}
+	slices.Sort(docs)
+	return slices.Compact(docs), nil
+}
+
+// newTokenDocMatchTree returns a matchTree which narrows a content atom for
+// pattern down to docs from the token index. It returns a noMatchTree if
+// docs is empty.
+func (d *indexData) newTokenDocMatchTree(pattern string, docs []uint32) matchTree {
+	if len(docs) == 0 {
+		return &noMatchTree{Why: "token"}
+	}
+	return &docMatchTree{
+		numDocs: d.numDocs(),
+		reason:  fmt.Sprintf("token:%q", pattern),
+		predicate: func(docID uint32) bool {
+			_, ok := slices.BinarySearch(docs, docID)
+			return ok
+		},
+	}
+}
@@ -0,0 +1,111 @@
+package index
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/zoekt"
+	"github.com/sourcegraph/zoekt/query"
+)
+
+func TestAddTokens(t *testing.T) {
+	b := newShardBuilder()
+	b.tokens = map[string][]uint32{}
+	b.addTokens(0, []byte("func Foo(x_1 int) { foo.Bar(x_1) }"))
+	b.addTokens(1, []byte("bar "+strings.Repeat("a", maxTokenLength+1)))
+
+	want := map[string][]uint32{
+		"":     {1},
+		"func": {0},
+		"foo":  {0},
+		"x_1":  {0},
+		"int":  {0},
+		"bar":  {0, 1},
+	}
+	if d := cmp.Diff(want, b.tokens); d != "" {
+		t.Errorf("tokens (-want +got):\n%s", d)
+	}
+}
+
+func TestTokenIndex(t *testing.T) {
+	docs := []Document{
+		{Name: "f1", Content: []byte("int x = 1;\nfunc Go() {}")},
+		{Name: "f2", Content: []byte("xy := golang")},
+		{Name: "f3", Content: []byte("if (foo) { return; }")},
+		{Name: "f4", Content: []byte("long " + strings.Repeat("b", maxTokenLength) + "xyz")},
+	}
+
+	build := func(tokens bool) *ShardBuilder {
+		b := testShardBuilder(t, nil, docs...)
+		if !tokens {
+			return b
+		}
+		// The token index has to be enabled before adding documents.
+		b = newShardBuilder()
+		b.tokens = map[string][]uint32{}
+		if err := b.setRepository(&zoekt.Repository{}); err != nil {
+			t.Fatal(err)
+		}
+		for _, d := range docs {
+			if err := b.Add(d); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return b
+	}
+
+	d := searcherForTest(t, build(true)).(*indexData)
+	if !d.tokens.enabled() {
+		t.Fatal("token index is not enabled")
+	}
+	if d := searcherForTest(t, build(false)).(*indexData); d.tokens.enabled() {
+		t.Fatal("token index is enabled")
+	}
+
+	wordRe := func(w string) *query.Regexp {
+		re, err := syntax.Parse(`\b`+w+`\b`, syntax.Perl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &query.Regexp{Regexp: re, CaseSensitive: true, Content: true}
+	}
+
+	for _, tc := range []struct {
+		q         query.Q
+		wantFiles []string
+	}{
+		{&query.Substring{Pattern: "x", Content: true}, []string{"f1", "f2", "f4"}},
+		{&query.Substring{Pattern: "go", Content: true}, []string{"f1", "f2"}},
+		{&query.Substring{Pattern: "Go", Content: true, CaseSensitive: true}, []string{"f1"}},
+		{&query.Substring{Pattern: "; ", Content: true}, []string{"f3"}},
+		{&query.Substring{Pattern: "q", Content: true}, nil},
+		{wordRe("x"), []string{"f1"}},
+		{wordRe("foo"), []string{"f3"}},
+		{wordRe("Go"), []string{"f1"}},
+		{wordRe("go"), nil},
+	} {
+		for _, tokens := range []bool{false, true} {
+			res := searchForTest(t, build(tokens), tc.q)
+			var got []string
+			for _, f := range res.Files {
+				got = append(got, f.FileName)
+			}
+			if d := cmp.Diff(tc.wantFiles, got); d != "" {
+				t.Errorf("%s with tokens=%v (-want +got):\n%s", tc.q, tokens, d)
+			}
+		}
+	}
+
+	// The token index narrows down the documents of short patterns.
+	mt, err := d.newMatchTree(&query.Substring{Pattern: "go", Content: true}, matchTreeOpt{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fmt.Sprint(mt), `doc(token:"go")`; !strings.Contains(got, want) {
+		t.Errorf("got match tree %s, want it to contain %s", got, want)
+	}
+}
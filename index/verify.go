@@ -0,0 +1,305 @@
+package index
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc64"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+)
+
+// CorruptDir is the directory next to a shard into which QuarantineShard
+// moves it.
+const CorruptDir = ".corrupt"
+
+// ShardProblem is a problem found by VerifyShard.
+type ShardProblem struct {
+	// Doc is the index of the affected document in the shard, or -1 if the
+	// problem is not specific to a document.
+	Doc int
+
+	// Repository and FileName identify Doc.
+	Repository string
+	FileName   string
+
+	Err error
+}
+
+func (p ShardProblem) String() string {
+	if p.Doc < 0 {
+		return p.Err.Error()
+	}
+	return fmt.Sprintf("document %d (%s/%s): %v", p.Doc, p.Repository, p.FileName, p.Err)
+}
+
+// VerifyShard fully validates the shard in f. Unlike the checks done when
+// loading a shard, it reads all document contents and compares them with
+// the content checksums, and rebuilds the newline, rune offset and posting
+// list tables to compare them with the ones in the shard. This takes time
+// and memory proportional to the size of the shard.
+//
+// It returns an error if f cannot be loaded as a shard at all; the shard
+// is corrupt in that case too. f is not closed.
+func VerifyShard(f IndexFile) (problems []ShardProblem, err error) {
+	// Reading corrupt data can index out of range anywhere.
+	defer func() {
+		if r := recover(); r != nil {
+			problems, err = nil, fmt.Errorf("verifying %s: %v", f.Name(), r)
+		}
+	}()
+
+	rd := &reader{r: f}
+	var toc indexTOC
+	if err := rd.readTOC(&toc); err != nil {
+		return nil, err
+	}
+	d, err := rd.readIndexData(&toc)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &shardVerifier{d: d}
+	content, names := v.verifyDocs()
+
+	if content != nil {
+		v.verifyRuneOffsets("content", toc.runeOffsets, content)
+		v.verifyEndRunes("content", d.fileEndRunes, content)
+		v.verifyPostings("content", d.contentNgrams, content)
+	}
+	v.verifyRuneOffsets("file name", toc.nameRuneOffsets, names)
+	v.verifyEndRunes("file name", d.fileNameEndRunes, names)
+	v.verifyPostings("file name", d.fileNameNgrams, names)
+
+	sort.SliceStable(v.problems, func(i, j int) bool { return v.problems[i].Doc < v.problems[j].Doc })
+	return v.problems, nil
+}
+
+type shardVerifier struct {
+	d        *indexData
+	problems []ShardProblem
+}
+
+func (v *shardVerifier) add(doc int, format string, args ...any) {
+	p := ShardProblem{Doc: doc, Err: fmt.Errorf(format, args...)}
+	if doc >= 0 {
+		p.FileName = string(v.d.fileName(uint32(doc)))
+		if doc < len(v.d.repos) && int(v.d.repos[doc]) < len(v.d.repoMetaData) {
+			p.Repository = v.d.repoMetaData[v.d.repos[doc]].Name
+		}
+	}
+	v.problems = append(v.problems, p)
+}
+
+// verifyDocs checks the checksums and newlines of all documents. It
+// returns the postings rebuilt from the contents and the file names. The
+// content postings are nil if some contents could not be read.
+func (v *shardVerifier) verifyDocs() (content, names *postingsBuilder) {
+	d := v.d
+	content, names = newPostingsBuilder(), newPostingsBuilder()
+
+	checksums := len(d.checksums) == int(d.numDocs())*crc64.Size
+	if !checksums {
+		v.add(-1, "got %d bytes of content checksums, want %d", len(d.checksums), int(d.numDocs())*crc64.Size)
+	}
+	table := crc64.MakeTable(crc64.ISO)
+
+	for i := uint32(0); i < d.numDocs(); i++ {
+		// file names cannot fail to read, they are held in memory.
+		if _, _, err := names.newSearchableString(d.fileName(i), nil); err != nil {
+			v.add(int(i), "file name: %v", err)
+		}
+
+		data, err := d.readContents(i)
+		if err != nil {
+			v.add(int(i), "reading contents: %v", err)
+			content = nil
+			continue
+		}
+		if checksums {
+			if got, want := crc64.Checksum(data, table), binary.BigEndian.Uint64(d.getChecksum(i)); got != want {
+				v.add(int(i), "content checksum is %016x, want %016x", got, want)
+			}
+		}
+
+		if nl, _, err := d.readNewlines(i, nil); err != nil {
+			v.add(int(i), "reading newlines: %v", err)
+		} else if want := newLinesIndices(data); !slices.Equal(nl, want) {
+			v.add(int(i), "got %d newlines, want %d", len(nl), len(want))
+		}
+
+		if content != nil {
+			if _, _, err := content.newSearchableString(data, nil); err != nil {
+				v.add(int(i), "contents: %v", err)
+			}
+		}
+	}
+
+	if content == nil {
+		v.add(-1, "skipped content posting lists: some contents are unreadable")
+	}
+	return content, names
+}
+
+// docForRune returns the document containing rune offset off, or -1 if
+// the offset is past the last document.
+func docForRune(want *postingsBuilder, off uint32) int {
+	i := sort.Search(len(want.endRunes), func(i int) bool { return want.endRunes[i] > off })
+	if i == len(want.endRunes) {
+		return -1
+	}
+	return i
+}
+
+func (v *shardVerifier) verifyRuneOffsets(what string, sec simpleSection, want *postingsBuilder) {
+	blob, err := v.d.readSectionBlob(sec)
+	if err != nil {
+		v.add(-1, "reading %s rune offsets: %v", what, err)
+		return
+	}
+	got := fromSizedDeltas(blob, nil)
+	if len(got) != len(want.runeOffsets) {
+		v.add(-1, "got %d %s rune offsets, want %d", len(got), what, len(want.runeOffsets))
+		return
+	}
+	for i := range got {
+		if got[i] != want.runeOffsets[i] {
+			v.add(docForRune(want, uint32(i)*runeOffsetFrequency), "%s rune %d is at byte %d, want %d",
+				what, i*runeOffsetFrequency, got[i], want.runeOffsets[i])
+			return
+		}
+	}
+}
+
+func (v *shardVerifier) verifyEndRunes(what string, got []uint32, want *postingsBuilder) {
+	if len(got) != len(want.endRunes) {
+		v.add(-1, "got %d %s end runes, want %d", len(got), what, len(want.endRunes))
+		return
+	}
+	for i := range got {
+		if got[i] != want.endRunes[i] {
+			v.add(i, "%s ends at rune %d, want %d", what, got[i], want.endRunes[i])
+		}
+	}
+}
+
+// verifyPostings compares the posting lists of bi with the ones rebuilt in
+// want. Differences are reported per document. want is consumed.
+func (v *shardVerifier) verifyPostings(what string, bi btreeIndex, want *postingsBuilder) {
+	ngramText, err := v.d.readSectionBlob(bi.ngramSec)
+	if err != nil {
+		v.add(-1, "reading %s ngrams: %v", what, err)
+		return
+	}
+
+	// mismatches counts the ngram occurrences per document which are
+	// missing from the posting lists or which the posting lists have in
+	// excess.
+	mismatches := map[int]int{}
+	count := func(offs []uint32) {
+		for _, off := range offs {
+			mismatches[docForRune(want, off)]++
+		}
+	}
+
+	var last ngram
+	for i := 0; i+ngramEncoding <= len(ngramText); i += ngramEncoding {
+		ng := ngram(binary.BigEndian.Uint64(ngramText[i : i+ngramEncoding]))
+		if i > 0 && ng <= last {
+			v.add(-1, "%s ngram %q is out of order", what, ng)
+			return
+		}
+		last = ng
+
+		wantPostings, ok := want.postings[ng]
+		delete(want.postings, ng)
+
+		blob, err := v.d.readSectionBlob(bi.getPostingList(i / ngramEncoding))
+		if err != nil {
+			v.add(-1, "reading %s posting list of %q: %v", what, ng, err)
+			continue
+		}
+		if ok && bytes.Equal(blob, wantPostings) {
+			continue
+		}
+
+		gotOffs, valid := decodePostings(blob)
+		if !valid {
+			v.add(-1, "%s posting list of %q is not valid", what, ng)
+		}
+		wantOffs, _ := decodePostings(wantPostings)
+		missing, excess := diffSorted(wantOffs, gotOffs)
+		count(missing)
+		count(excess)
+	}
+
+	for _, p := range want.postings {
+		offs, _ := decodePostings(p)
+		count(offs)
+	}
+
+	for doc, n := range mismatches {
+		if doc < 0 {
+			v.add(-1, "%d %s ngram occurrences are past the last document", n, what)
+		} else {
+			v.add(doc, "%d %s ngram occurrences disagree with the posting lists", n, what)
+		}
+	}
+}
+
+// decodePostings returns the rune offsets of a posting list. It returns
+// false if the posting list is not valid.
+func decodePostings(b []byte) ([]uint32, bool) {
+	var offs []uint32
+	var off uint32
+	for len(b) > 0 {
+		delta, m := binary.Uvarint(b)
+		if m <= 0 {
+			return offs, false
+		}
+		b = b[m:]
+		off += uint32(delta)
+		offs = append(offs, off)
+	}
+	return offs, true
+}
+
+// diffSorted returns the values of the sorted slices want and got which
+// are only in want, and only in got.
+func diffSorted(want, got []uint32) (missing, excess []uint32) {
+	for len(want) > 0 && len(got) > 0 {
+		switch {
+		case want[0] < got[0]:
+			missing = append(missing, want[0])
+			want = want[1:]
+		case want[0] > got[0]:
+			excess = append(excess, got[0])
+			got = got[1:]
+		default:
+			want, got = want[1:], got[1:]
+		}
+	}
+	return append(missing, want...), append(excess, got...)
+}
+
+// QuarantineShard moves the shard at path and its ".meta" file into
+// CorruptDir next to it, so that they are no longer loaded. It returns the
+// new path of the shard.
+func QuarantineShard(path string) (string, error) {
+	paths, err := IndexFilePaths(path)
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(filepath.Dir(path), CorruptDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	for _, p := range paths {
+		if err := os.Rename(p, filepath.Join(dir, filepath.Base(p))); err != nil {
+			return "", err
+		}
+	}
+	return filepath.Join(dir, filepath.Base(path)), nil
+}
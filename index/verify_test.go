@@ -0,0 +1,150 @@
+package index
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/zoekt"
+)
+
+func TestVerifyShard_golden(t *testing.T) {
+	shards, err := filepath.Glob("../testdata/shards/*.zoekt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, err := filepath.Glob("../testdata/shards/current/*.zoekt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	shards = append(shards, current...)
+	shards = append(shards, "../testdata/backcompat/static_toc_v16.00000.zoekt")
+
+	for _, path := range shards {
+		name, _ := filepath.Rel("../testdata", path)
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			problems, err := VerifyShard(NewIndexFileFromBytes(path, data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, p := range problems {
+				t.Error(p)
+			}
+		})
+	}
+}
+
+func TestVerifyShard(t *testing.T) {
+	build := func(t *testing.T, encoding string) []byte {
+		b := testShardBuilder(t, &zoekt.Repository{Name: "repo"},
+			Document{Name: "a.txt", Content: []byte("first document\nwith some text\n")},
+			Document{Name: "b.txt", Content: []byte("second document\nhas a needle\n")},
+			Document{Name: "c.txt", Content: []byte(strings.Repeat("third ü\n", 50))},
+		)
+		b.contentEncoding = encoding
+		var buf bytes.Buffer
+		if err := b.Write(&buf); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	verify := func(t *testing.T, data []byte) []ShardProblem {
+		t.Helper()
+		problems, err := VerifyShard(NewIndexFileFromBytes("shard.zoekt", data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return problems
+	}
+
+	for _, encoding := range []string{"", ContentEncodingZstd} {
+		if problems := verify(t, build(t, encoding)); len(problems) != 0 {
+			t.Errorf("encoding %q: got problems %v", encoding, problems)
+		}
+	}
+
+	t.Run("content", func(t *testing.T) {
+		data := build(t, "")
+		i := bytes.Index(data, []byte("needle"))
+		if i < 0 {
+			t.Fatal("content not found")
+		}
+		data[i] = 'N'
+
+		problems := verify(t, data)
+		if len(problems) == 0 {
+			t.Fatal("corruption not detected")
+		}
+		var checksum, postings bool
+		for _, p := range problems {
+			if p.Doc != 1 || p.FileName != "b.txt" || p.Repository != "repo" {
+				t.Errorf("problem %s not attributed to b.txt", p)
+			}
+			checksum = checksum || strings.Contains(p.Err.Error(), "checksum")
+			postings = postings || strings.Contains(p.Err.Error(), "posting lists")
+		}
+		if !checksum || !postings {
+			t.Errorf("got problems %v, want checksum and posting list mismatches", problems)
+		}
+	})
+
+	t.Run("newline", func(t *testing.T) {
+		data := build(t, "")
+		i := bytes.Index(data, []byte("with some"))
+		if i < 0 {
+			t.Fatal("content not found")
+		}
+		data[i-1] = ' '
+
+		var newlines bool
+		for _, p := range verify(t, data) {
+			if p.Doc != 0 {
+				t.Errorf("problem %s not attributed to a.txt", p)
+			}
+			newlines = newlines || strings.Contains(p.Err.Error(), "newlines")
+		}
+		if !newlines {
+			t.Error("newline mismatch not detected")
+		}
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		data := build(t, "")
+		if _, err := VerifyShard(NewIndexFileFromBytes("shard.zoekt", data[:len(data)/2])); err == nil {
+			t.Error("want error for truncated shard")
+		}
+	})
+}
+
+func TestQuarantineShard(t *testing.T) {
+	dir := t.TempDir()
+	shard := filepath.Join(dir, "repo_v16.00000.zoekt")
+	for _, p := range []string{shard, shard + ".meta"} {
+		if err := os.WriteFile(p, []byte("x"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dst, err := QuarantineShard(shard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, CorruptDir, "repo_v16.00000.zoekt"); dst != want {
+		t.Errorf("got %s, want %s", dst, want)
+	}
+	for _, p := range []string{dst, dst + ".meta"} {
+		if _, err := os.Stat(p); err != nil {
+			t.Error(err)
+		}
+	}
+	if paths, _ := IndexFilePaths(shard); len(paths) != 0 {
+		t.Errorf("shard files left behind: %v", paths)
+	}
+}
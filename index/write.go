@@ -109,15 +109,25 @@ func writePostings(w *writer, s *postingsBuilder, ngramText *simpleSection,
 }
 
 func (b *ShardBuilder) Write(out io.Writer) error {
-	next := b.indexFormatVersion == NextIndexFormatVersion
+	// compound shards (multi repo) came with v17
+	next := b.indexFormatVersion >= 17
 
 	buffered := bufio.NewWriterSize(out, 1<<20)
 	defer buffered.Flush()
 
-	w := &writer{w: buffered}
+	w := &writer{w: buffered, offsets64: b.indexFormatVersion >= offsets64FormatVersion}
 	toc := indexTOC{}
 
-	toc.fileContents.writeStrings(w, b.contentStrings)
+	blockDocs := 0
+	switch b.contentEncoding {
+	case "":
+		toc.fileContents.writeStrings(w, b.contentStrings)
+	case ContentEncodingZstd:
+		blockDocs = defaultContentBlockDocs
+		writeContentBlocks(w, b.contentStrings, blockDocs, &toc.fileContentBlocks, &toc.fileContentOffsets)
+	default:
+		return fmt.Errorf("unknown content encoding %q", b.contentEncoding)
+	}
 	toc.newlines.start(w)
 	for _, f := range b.contentStrings {
 		toc.newlines.addItem(w, toSizedDeltas(newLinesIndices(f.data)))
@@ -177,6 +187,43 @@ func (b *ShardBuilder) Write(out io.Writer) error {
 	w.Write(marshalDocSections(b.runeDocSections))
 	toc.runeDocSections.end(w)
 
+	toc.fileSymbolScopes.start(w)
+	for _, s := range b.docSymbolScopes {
+		toc.fileSymbolScopes.addItem(w, marshalDocSections(s))
+	}
+	toc.fileSymbolScopes.end(w)
+
+	// Commit dates are optional. Shards without any leave the section empty.
+	toc.fileCommitDates.start(w)
+	if b.hasCommitDates {
+		for _, d := range b.commitDates {
+			w.U64(d)
+		}
+	}
+	toc.fileCommitDates.end(w)
+
+	// Like commit dates, commits are optional.
+	toc.fileCommits.start(w)
+	if b.hasCommits {
+		for _, c := range b.commits {
+			toc.fileCommits.addItem(w, c)
+		}
+	}
+	toc.fileCommits.end(w)
+
+	// Like commits, ACLs are optional.
+	toc.fileACLs.start(w)
+	if b.hasACLs {
+		for _, a := range b.acls {
+			toc.fileACLs.addItem(w, a)
+		}
+	}
+	toc.fileACLs.end(w)
+
+	if b.tokens != nil {
+		writeTokens(w, b.tokens, &toc.tokens, &toc.tokenPostings)
+	}
+
 	if next {
 		toc.repos.start(w)
 		w.Write(toSizedDeltas16(b.repos))
@@ -194,15 +241,28 @@ func (b *ShardBuilder) Write(out io.Writer) error {
 		indexTime = time.Now().UTC()
 	}
 
+	minReaderVersion := WriteMinFeatureVersion
+	if b.contentEncoding != "" {
+		// readers before feature version 16 expect raw contents.
+		minReaderVersion = 16
+	}
+	if b.hasACLs {
+		// readers before feature version 18 ignore ACLs, and would show the
+		// documents to everyone.
+		minReaderVersion = 18
+	}
+
 	if err := b.writeJSON(&zoekt.IndexMetadata{
 		IndexFormatVersion:    b.indexFormatVersion,
 		IndexTime:             indexTime,
 		IndexFeatureVersion:   b.featureVersion,
-		IndexMinReaderVersion: WriteMinFeatureVersion,
+		IndexMinReaderVersion: minReaderVersion,
 		PlainASCII:            b.contentPostings.isPlainASCII && b.namePostings.isPlainASCII,
 		LanguageMap:           b.languageMap,
 		ZoektVersion:          Version,
 		ID:                    b.ID,
+		ContentEncoding:       b.contentEncoding,
+		ContentBlockDocs:      blockDocs,
 	}, &toc.metaData, w); err != nil {
 		return err
 	}
@@ -259,3 +259,61 @@ func testLatestCommitDate(t *testing.T, format string) {
 	require.Len(t, repos, 1)
 	require.True(t, repos[0].LatestCommitDate.Equal(modTime))
 }
+
+func TestIndexDelta(t *testing.T) {
+	indexDir := t.TempDir()
+
+	build := func(commit string, files map[string]string) {
+		t.Helper()
+		archive := filepath.Join(t.TempDir(), "archive.tar")
+		f, err := os.Create(archive)
+		require.NoError(t, err)
+		require.NoError(t, writeArchive(f, "tar", files))
+		require.NoError(t, f.Close())
+
+		bopts := index.Options{
+			IndexDir:     indexDir,
+			DisableCTags: true,
+		}
+		opts := Options{
+			Delta:   true,
+			Archive: archive,
+			Name:    "repo",
+			Branch:  "master",
+			Commit:  commit,
+		}
+		require.NoError(t, Index(opts, bopts))
+	}
+
+	build("1111111111111111111111111111111111111111", map[string]string{
+		"a.txt": "needle one",
+		"b.txt": "needle two",
+		"c.txt": "needle three",
+	})
+	build("2222222222222222222222222222222222222222", map[string]string{
+		"a.txt": "needle one",
+		"b.txt": "needle 2",
+		"d.txt": "needle four",
+	})
+
+	shards, err := filepath.Glob(filepath.Join(indexDir, "*.zoekt"))
+	require.NoError(t, err)
+	require.Len(t, shards, 2, "want the first shard and a delta shard")
+
+	ss, err := search.NewDirectorySearcher(indexDir)
+	require.NoError(t, err)
+	defer ss.Close()
+
+	result, err := ss.Search(context.Background(), &query.Substring{Pattern: "needle"}, &zoekt.SearchOptions{Whole: true})
+	require.NoError(t, err)
+
+	got := map[string]string{}
+	for _, f := range result.Files {
+		got[f.FileName] = string(f.Content)
+	}
+	require.Equal(t, map[string]string{
+		"a.txt": "needle one",
+		"b.txt": "needle 2",
+		"d.txt": "needle four",
+	}, got)
+}
@@ -17,6 +17,10 @@ import (
 type Options struct {
 	Incremental bool
 
+	// Delta only indexes the files of the archive which changed since the
+	// last run, see index.Options.DeltaByChecksum.
+	Delta bool
+
 	Archive string
 	Name    string
 	RepoURL string
@@ -100,6 +104,8 @@ func Index(opts Options, bopts index.Options) error {
 		}
 	*/
 	bopts.SetDefaults()
+	bopts.IsDelta = opts.Delta
+	bopts.DeltaByChecksum = opts.Delta
 	bopts.RepositoryDescription.Branches = []zoekt.RepositoryBranch{{Name: opts.Branch, Version: opts.Commit}}
 	brs := []string{opts.Branch}
 
@@ -0,0 +1,276 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/grafana/regexp"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/sourcegraph/zoekt"
+	"github.com/sourcegraph/zoekt/internal/mockSearcher"
+	"github.com/sourcegraph/zoekt/query"
+)
+
+func hash(t *testing.T, password string) string {
+	t.Helper()
+	h, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(h)
+}
+
+func writeUsers(t *testing.T, path string, lines ...string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUsers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users")
+	writeUsers(t, path,
+		"# comment",
+		"alice:"+hash(t, "secret")+` ^github\.com/acme/ group:eng ^github\.com/shared$`,
+		"",
+		"bob:"+hash(t, "hunter2"),
+	)
+
+	u, err := LoadUsers(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := u.Authenticate("alice", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for repo, want := range map[string]bool{
+		"github.com/acme/a":      true,
+		"github.com/shared":      true,
+		"github.com/shared/fork": false,
+		"github.com/other/a":     false,
+	} {
+		if got := p.allowRepo(repo); got != want {
+			t.Errorf("alice allowed %s: got %v, want %v", repo, got, want)
+		}
+	}
+	if groups, all := Groups(WithPrincipal(context.Background(), p)); all || !cmp.Equal(groups, []string{"eng"}) {
+		t.Errorf("got groups %v, all %v, want [eng]", groups, all)
+	}
+	// The cached verification must not accept other passwords.
+	for i := 0; i < 2; i++ {
+		if _, err := u.Authenticate("alice", "secret"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := u.Authenticate("alice", "wrong"); err != ErrUnauthenticated {
+			t.Fatalf("got %v for a wrong password", err)
+		}
+	}
+	if _, err := u.Authenticate("carol", "secret"); err != ErrUnauthenticated {
+		t.Fatalf("got %v for an unknown user", err)
+	}
+
+	// Without patterns bob sees nothing.
+	p, err = u.Authenticate("bob", "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.allowRepo("github.com/acme/a") {
+		t.Error("bob may see a repository")
+	}
+	if p, ok := u.Principal("alice"); !ok || p.Name != "alice" || !p.allowRepo("github.com/acme/a") {
+		t.Errorf("got principal %+v for alice", p)
+	}
+	if _, ok := u.Principal("carol"); ok {
+		t.Error("got a principal for an unknown user")
+	}
+
+	// Changes to the file are only looked for once per interval.
+	writeUsers(t, path, "bob:"+hash(t, "hunter3")+" .*")
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := u.Authenticate("alice", "secret"); err != nil {
+		t.Fatalf("got %v before the users file was checked again", err)
+	}
+
+	// Changes to the file are picked up, and a broken file keeps the last
+	// good users.
+	u.interval = 0
+	if _, err := u.Authenticate("alice", "secret"); err != ErrUnauthenticated {
+		t.Fatalf("got %v for a removed user", err)
+	}
+	if _, err := u.Authenticate("bob", "hunter3"); err != nil {
+		t.Fatal(err)
+	}
+	writeUsers(t, path, "bob")
+	later = later.Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := u.Authenticate("bob", "hunter3"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadUsersErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users")
+	for _, content := range []string{
+		"alice",
+		"alice:plaintext",
+		"alice:" + hash(t, "a") + " (",
+		"alice:" + hash(t, "a") + " group:",
+		"alice:" + hash(t, "a") + "\nalice:" + hash(t, "b"),
+	} {
+		writeUsers(t, path, content)
+		if _, err := LoadUsers(path); err == nil {
+			t.Errorf("LoadUsers(%q) succeeded", content)
+		}
+	}
+}
+
+type mockStreamer struct {
+	*mockSearcher.MockSearcher
+}
+
+func (s mockStreamer) StreamSearch(ctx context.Context, q query.Q, opts *zoekt.SearchOptions, sender zoekt.Sender) error {
+	sr, err := s.Search(ctx, q, opts)
+	if err != nil {
+		return err
+	}
+	sender.Send(sr)
+	return nil
+}
+
+func TestSearcher(t *testing.T) {
+	q := &query.Substring{Pattern: "needle"}
+	repoQ := &query.Repo{Regexp: regexp.MustCompile("^acme/")}
+
+	newResult := func() *zoekt.SearchResult {
+		return &zoekt.SearchResult{
+			Files: []zoekt.FileMatch{
+				{Repository: "acme/a", FileName: "f1"},
+				{Repository: "other/b", FileName: "f2"},
+			},
+			RepoURLs:      map[string]string{"acme/a": "url-a", "other/b": "url-b"},
+			LineFragments: map[string]string{"acme/a": "frag-a", "other/b": "frag-b"},
+			Explain:       []zoekt.ShardExplain{{Shard: "other%2Fb_v16.00000.zoekt"}},
+		}
+	}
+	wantResult := &zoekt.SearchResult{
+		Files:         []zoekt.FileMatch{{Repository: "acme/a", FileName: "f1"}},
+		RepoURLs:      map[string]string{"acme/a": "url-a"},
+		LineFragments: map[string]string{"acme/a": "frag-a"},
+	}
+
+	mock := &mockSearcher.MockSearcher{
+		WantSearch: query.NewAnd(q, query.NewOr(&query.Repo{Regexp: regexp.MustCompile("^acme/")})),
+		WantList:   query.NewAnd(repoQ, query.NewOr(&query.Repo{Regexp: regexp.MustCompile("^acme/")})),
+		RepoList: &zoekt.RepoList{Repos: []*zoekt.RepoListEntry{
+			{Repository: zoekt.Repository{Name: "acme/a"}},
+			{Repository: zoekt.Repository{Name: "other/b"}},
+		}},
+	}
+	s := NewSearcher(mockStreamer{mock})
+
+	ctx := WithPrincipal(context.Background(), &Principal{Name: "alice", Repos: []*regexp.Regexp{regexp.MustCompile("^acme/")}})
+
+	mock.SearchResult = newResult()
+	sr, err := s.Search(ctx, q, &zoekt.SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff(wantResult, sr); d != "" {
+		t.Errorf("Search (-want +got):\n%s", d)
+	}
+
+	mock.SearchResult = newResult()
+	var got []*zoekt.SearchResult
+	if err := s.StreamSearch(ctx, q, &zoekt.SearchOptions{}, zoekt.SenderFunc(func(sr *zoekt.SearchResult) {
+		got = append(got, sr)
+	})); err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff([]*zoekt.SearchResult{wantResult}, got); d != "" {
+		t.Errorf("StreamSearch (-want +got):\n%s", d)
+	}
+
+	rl, err := s.List(ctx, repoQ, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rl.Repos) != 1 || rl.Repos[0].Repository.Name != "acme/a" {
+		t.Errorf("List returned %v", rl.Repos)
+	}
+
+	// Requests without a principal find nothing.
+	mock.WantSearch = &query.Const{Value: false}
+	mock.SearchResult = newResult()
+	sr, err = s.Search(context.Background(), q, &zoekt.SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sr.Files) != 0 {
+		t.Errorf("got files %v without a principal", sr.Files)
+	}
+
+	// Internal requests are not restricted.
+	mock.WantSearch = q
+	mock.SearchResult = newResult()
+	sr, err = s.Search(WithUnrestrictedContext(context.Background()), q, &zoekt.SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sr.Files) != 2 || len(sr.Explain) != 1 {
+		t.Errorf("got %d files and %d explained shards for an internal request, want 2 and 1", len(sr.Files), len(sr.Explain))
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users")
+	writeUsers(t, path, "alice:"+hash(t, "secret")+" .*")
+	u, err := LoadUsers(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := u.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, _ := FromContext(r.Context())
+		_, _ = w.Write([]byte(p.Name))
+	}))
+
+	for _, tc := range []struct {
+		user, password string
+		wantCode       int
+	}{
+		{"", "", http.StatusUnauthorized},
+		{"alice", "wrong", http.StatusUnauthorized},
+		{"alice", "secret", http.StatusOK},
+	} {
+		r := httptest.NewRequest("GET", "/search?q=needle", nil)
+		if tc.user != "" {
+			r.SetBasicAuth(tc.user, tc.password)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != tc.wantCode {
+			t.Errorf("%s:%s: got status %d, want %d", tc.user, tc.password, w.Code, tc.wantCode)
+		}
+		if w.Code == http.StatusOK && w.Body.String() != "alice" {
+			t.Errorf("got principal %q, want alice", w.Body.String())
+		}
+		if w.Code == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+			t.Error("missing WWW-Authenticate header")
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/zoekt/query"
+)
+
+// Principal is an authenticated user.
+type Principal struct {
+	Name string
+
+	// Repos are the patterns of the repository names the user may see.
+	Repos []*regexp.Regexp
+
+	// Groups are the ACL labels of the documents the user may see, see
+	// index.Document.ACL.
+	Groups []string
+
+	// all is set for the principal of WithUnrestrictedContext.
+	all bool
+}
+
+// allowRepo reports whether p may see the repository name.
+func (p *Principal) allowRepo(name string) bool {
+	if p.all {
+		return true
+	}
+	for _, re := range p.Repos {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// restrict returns q restricted to the repositories p may see.
+func (p *Principal) restrict(q query.Q) query.Q {
+	if p.all {
+		return q
+	}
+	if len(p.Repos) == 0 {
+		return &query.Const{Value: false}
+	}
+	repos := make([]query.Q, 0, len(p.Repos))
+	for _, re := range p.Repos {
+		repos = append(repos, &query.Repo{Regexp: re})
+	}
+	return query.NewAnd(q, query.NewOr(repos...))
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a context carrying p.
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// WithUnrestrictedContext returns a context which may see all repositories.
+// It is meant for internal requests, eg. health checks, which don't act on
+// behalf of a user.
+func WithUnrestrictedContext(ctx context.Context) context.Context {
+	return WithPrincipal(ctx, &Principal{all: true})
+}
+
+// FromContext returns the principal of ctx, if any.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
+
+// Allowed is the authorization hook: it reports whether the principal of ctx
+// may see the repository name. A context without a principal may see
+// nothing.
+func Allowed(ctx context.Context, name string) bool {
+	p, ok := FromContext(ctx)
+	return ok && p.allowRepo(name)
+}
+
+// Groups returns the groups of the principal of ctx, which decide the
+// documents with ACL labels it may see. all is set if it may see every
+// document. A context without a principal may only see documents without
+// labels.
+func Groups(ctx context.Context) (groups []string, all bool) {
+	p, ok := FromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	return p.Groups, p.all
+}
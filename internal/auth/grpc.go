@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// authenticateGRPC authenticates the basic auth credentials in the
+// "authorization" metadata of ctx, and returns ctx with the principal.
+func (u *Users) authenticateGRPC(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	vals := md.Get("authorization")
+	if len(vals) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing credentials")
+	}
+	// Reuse the parsing of net/http for the header value.
+	r := http.Request{Header: http.Header{"Authorization": vals[:1]}}
+	name, password, ok := r.BasicAuth()
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+	}
+	p, err := u.Authenticate(name, password)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return WithPrincipal(ctx, p), nil
+}
+
+// UnaryServerInterceptor is a grpc.UnaryServerInterceptor which authenticates
+// requests like Middleware.
+func (u *Users) UnaryServerInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := u.authenticateGRPC(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor is a grpc.StreamServerInterceptor which
+// authenticates requests like Middleware.
+func (u *Users) StreamServerInterceptor(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := u.authenticateGRPC(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &grpc_middleware.WrappedServerStream{
+		ServerStream:   ss,
+		WrappedContext: ctx,
+	})
+}
@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"net/http"
+)
+
+// Middleware authenticates the requests to next with HTTP basic auth. It
+// passes the principal to next in the request context, and rejects requests
+// without valid credentials.
+func (u *Users) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, password, ok := r.BasicAuth()
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Basic realm="zoekt"`)
+			http.Error(w, "Not authenticated.", http.StatusUnauthorized)
+			return
+		}
+		p, err := u.Authenticate(name, password)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Basic realm="zoekt"`)
+			http.Error(w, "Not authenticated.", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), p)))
+	})
+}
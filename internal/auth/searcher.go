@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"context"
+	"slices"
+
+	"github.com/sourcegraph/zoekt"
+	"github.com/sourcegraph/zoekt/query"
+)
+
+// NewSearcher returns a searcher which only finds and lists the
+// repositories the principal of the request context may see. Requests
+// without a principal find nothing.
+//
+// The query is restricted to the allowed repositories before it is evaluated,
+// so that limits, stats and facets only count allowed results. The results
+// are filtered through Allowed as well, so that nothing slips through.
+func NewSearcher(s zoekt.Streamer) zoekt.Streamer {
+	return &searcher{Streamer: s}
+}
+
+type searcher struct {
+	zoekt.Streamer
+}
+
+// restrict returns q restricted to the repositories the principal of ctx may
+// see.
+func restrict(ctx context.Context, q query.Q) query.Q {
+	p, ok := FromContext(ctx)
+	if !ok {
+		return &query.Const{Value: false}
+	}
+	return p.restrict(q)
+}
+
+func (s *searcher) Search(ctx context.Context, q query.Q, opts *zoekt.SearchOptions) (*zoekt.SearchResult, error) {
+	sr, err := s.Streamer.Search(ctx, restrict(ctx, q), opts)
+	if sr != nil {
+		FilterResult(ctx, sr)
+	}
+	return sr, err
+}
+
+func (s *searcher) StreamSearch(ctx context.Context, q query.Q, opts *zoekt.SearchOptions, sender zoekt.Sender) error {
+	return s.Streamer.StreamSearch(ctx, restrict(ctx, q), opts, zoekt.SenderFunc(func(sr *zoekt.SearchResult) {
+		FilterResult(ctx, sr)
+		sender.Send(sr)
+	}))
+}
+
+func (s *searcher) List(ctx context.Context, q query.Q, opts *zoekt.ListOptions) (*zoekt.RepoList, error) {
+	rl, err := s.Streamer.List(ctx, restrict(ctx, q), opts)
+	if rl != nil {
+		rl.Repos = slices.DeleteFunc(rl.Repos, func(r *zoekt.RepoListEntry) bool {
+			return !Allowed(ctx, r.Repository.Name)
+		})
+	}
+	return rl, err
+}
+
+func (s *searcher) String() string {
+	return "auth(" + s.Streamer.String() + ")"
+}
+
+// FilterResult removes the matches and the URL templates of the
+// repositories the principal of ctx may not see from sr. Only unrestricted
+// principals get the explanation of the search, since shards are named after
+// their repositories.
+func FilterResult(ctx context.Context, sr *zoekt.SearchResult) {
+	sr.Files = slices.DeleteFunc(sr.Files, func(f zoekt.FileMatch) bool {
+		return !Allowed(ctx, f.Repository)
+	})
+	sr.Commits = slices.DeleteFunc(sr.Commits, func(c zoekt.CommitMatch) bool {
+		return !Allowed(ctx, c.Repository)
+	})
+	// Shards add the templates of all their repositories.
+	for name := range sr.RepoURLs {
+		if !Allowed(ctx, name) {
+			delete(sr.RepoURLs, name)
+		}
+	}
+	for name := range sr.LineFragments {
+		if !Allowed(ctx, name) {
+			delete(sr.LineFragments, name)
+		}
+	}
+	if p, ok := FromContext(ctx); !ok || !p.all {
+		sr.Explain = nil
+	}
+}
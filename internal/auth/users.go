@@ -0,0 +1,188 @@
+// Package auth authenticates users against a users file and authorizes them
+// to see the repositories matching their patterns.
+//
+// The users file has one user per line:
+//
+//	<name>:<bcrypt hash> <repo pattern>... group:<group>...
+//
+// The first part is a line of an htpasswd file created with "htpasswd -B".
+// The repository patterns are regular expressions matched against repository
+// names like the r: query atom, eg. "^github\.com/acme/". A user without
+// patterns can't see any repository, ".*" allows all of them. The groups
+// decide which documents with ACL labels the user may see within those
+// repositories. Empty lines and lines starting with # are ignored.
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grafana/regexp"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrUnauthenticated is returned for unknown users and wrong passwords.
+var ErrUnauthenticated = errors.New("auth: invalid user name or password")
+
+// reloadInterval is how often Users checks whether the users file changed.
+const reloadInterval = time.Second
+
+// Users authenticates users against a users file. The file is read again
+// when it changes, checked at most once per reloadInterval.
+type Users struct {
+	path     string
+	interval time.Duration
+
+	// mu guards the fields below and the verified passwords of the users. It
+	// is not held while bcrypt runs, so that a slow check doesn't hold up
+	// other requests.
+	mu      sync.Mutex
+	checked time.Time
+	mtime   time.Time
+	users   map[string]*user
+}
+
+type user struct {
+	hash   []byte
+	repos  []*regexp.Regexp
+	groups []string
+
+	// verified is the SHA-256 sum of the last password which matched hash, so
+	// that bcrypt only runs once per password rather than on every request.
+	verified *[sha256.Size]byte
+}
+
+// LoadUsers reads the users file at path.
+func LoadUsers(path string) (*Users, error) {
+	u := &Users{path: path, interval: reloadInterval}
+	if err := u.reload(); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// reload reads the users file if it changed since it was last read, unless
+// it was checked within u.interval. It keeps the previous users if the file
+// can't be read. u.mu must be held.
+func (u *Users) reload() error {
+	now := time.Now()
+	if u.users != nil && now.Sub(u.checked) < u.interval {
+		return nil
+	}
+	u.checked = now
+	fi, err := os.Stat(u.path)
+	if err != nil {
+		return err
+	}
+	if u.users != nil && fi.ModTime().Equal(u.mtime) {
+		return nil
+	}
+
+	b, err := os.ReadFile(u.path)
+	if err != nil {
+		return err
+	}
+	users, err := parseUsers(b)
+	if err != nil {
+		return fmt.Errorf("%s: %w", u.path, err)
+	}
+	u.users = users
+	u.mtime = fi.ModTime()
+	return nil
+}
+
+func parseUsers(b []byte) (map[string]*user, error) {
+	users := map[string]*user{}
+	sc := bufio.NewScanner(bytes.NewReader(b))
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		name, hash, ok := strings.Cut(fields[0], ":")
+		if !ok || name == "" || hash == "" {
+			return nil, fmt.Errorf("line %d: want <name>:<bcrypt hash>", n)
+		}
+		if _, err := bcrypt.Cost([]byte(hash)); err != nil {
+			return nil, fmt.Errorf("line %d: user %s: %w", n, name, err)
+		}
+		if _, ok := users[name]; ok {
+			return nil, fmt.Errorf("line %d: duplicate user %s", n, name)
+		}
+
+		usr := &user{hash: []byte(hash)}
+		for _, p := range fields[1:] {
+			if g, ok := strings.CutPrefix(p, "group:"); ok {
+				if g == "" {
+					return nil, fmt.Errorf("line %d: user %s: empty group", n, name)
+				}
+				usr.groups = append(usr.groups, g)
+				continue
+			}
+			re, err := regexp.Compile(p)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: user %s: %w", n, name, err)
+			}
+			usr.repos = append(usr.repos, re)
+		}
+		users[name] = usr
+	}
+	return users, sc.Err()
+}
+
+// Authenticate returns the principal of the user with name and password.
+func (u *Users) Authenticate(name, password string) (*Principal, error) {
+	usr, verified, ok := u.lookup(name)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	sum := sha256.Sum256([]byte(password))
+	if verified == nil || *verified != sum {
+		if err := bcrypt.CompareHashAndPassword(usr.hash, []byte(password)); err != nil {
+			return nil, ErrUnauthenticated
+		}
+		u.mu.Lock()
+		usr.verified = &sum
+		u.mu.Unlock()
+	}
+	return usr.principal(name), nil
+}
+
+// Principal returns the principal of the user called name without
+// authenticating it, eg. to act on behalf of the user in the background.
+func (u *Users) Principal(name string) (*Principal, bool) {
+	usr, _, ok := u.lookup(name)
+	if !ok {
+		return nil, false
+	}
+	return usr.principal(name), true
+}
+
+// lookup returns the user called name and its last verified password.
+func (u *Users) lookup(name string) (usr *user, verified *[sha256.Size]byte, ok bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	// Keep serving the last good users if the file is broken or gone, rather
+	// than locking everyone out.
+	_ = u.reload()
+
+	usr, ok = u.users[name]
+	if !ok {
+		return nil, nil, false
+	}
+	return usr, usr.verified, true
+}
+
+func (usr *user) principal(name string) *Principal {
+	return &Principal{Name: name, Repos: usr.repos, Groups: usr.groups}
+}
@@ -0,0 +1,155 @@
+package gitindex
+
+import (
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+
+	"github.com/sourcegraph/zoekt"
+	"github.com/sourcegraph/zoekt/index"
+)
+
+// firstParentChanges returns the changes of c relative to its first parent,
+// or all files of c if it is a root commit.
+func firstParentChanges(c *object.Commit) (object.Changes, error) {
+	tree, err := c.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	var parentTree *object.Tree
+	if c.NumParents() > 0 {
+		parent, err := c.Parent(0)
+		if err != nil {
+			return nil, err
+		}
+		if parentTree, err = parent.Tree(); err != nil {
+			return nil, err
+		}
+	}
+
+	return object.DiffTree(parentTree, tree)
+}
+
+// lastCommits returns the newest commit reachable from heads which changed
+// each of the main repository files in repos. Files of subrepositories are
+// skipped.
+//
+// For every branch head, the history is walked from newest to oldest commit,
+// diffing each commit against its first parent, until all files have been
+// found.
+func lastCommits(repo *git.Repository, heads []plumbing.Hash, repos map[fileKey]BlobLocation) (map[fileKey]*object.Commit, error) {
+	want := 0
+	for key := range repos {
+		if key.SubRepoPath == "" {
+			want++
+		}
+	}
+
+	found := make(map[fileKey]*object.Commit, want)
+	for _, head := range heads {
+		if len(found) == want {
+			break
+		}
+
+		iter, err := repo.Log(&git.LogOptions{From: head, Order: git.LogOrderCommitterTime})
+		if err != nil {
+			return nil, fmt.Errorf("git log %s: %w", head, err)
+		}
+
+		err = iter.ForEach(func(c *object.Commit) error {
+			changes, err := firstParentChanges(c)
+			if err != nil {
+				return err
+			}
+
+			for _, change := range changes {
+				// Deletions have an empty destination.
+				if change.To.Name == "" {
+					continue
+				}
+
+				key := fileKey{Path: change.To.Name, ID: change.To.TreeEntry.Hash}
+				if _, ok := repos[key]; !ok {
+					continue
+				}
+				if _, ok := found[key]; !ok {
+					found[key] = c
+				}
+			}
+
+			if len(found) == want {
+				return storer.ErrStop
+			}
+			return nil
+		})
+		iter.Close()
+		if err != nil {
+			return nil, fmt.Errorf("git log %s: %w", head, err)
+		}
+	}
+
+	return found, nil
+}
+
+// indexCommits adds a commit document for every commit reachable from
+// branches to builder. The document holds the commit message and the paths
+// changed relative to the first parent, and is searchable with type:commit.
+func indexCommits(repo *git.Repository, branches []zoekt.RepositoryBranch, builder *index.Builder) error {
+	// hash => names of the branches containing the commit, in the order the
+	// commits were first seen.
+	var order []plumbing.Hash
+	commitBranches := map[plumbing.Hash][]string{}
+
+	for _, br := range branches {
+		head := plumbing.NewHash(br.Version)
+		iter, err := repo.Log(&git.LogOptions{From: head, Order: git.LogOrderCommitterTime})
+		if err != nil {
+			return fmt.Errorf("git log %s: %w", head, err)
+		}
+
+		err = iter.ForEach(func(c *object.Commit) error {
+			if _, ok := commitBranches[c.Hash]; !ok {
+				order = append(order, c.Hash)
+			}
+			commitBranches[c.Hash] = append(commitBranches[c.Hash], br.Name)
+			return nil
+		})
+		iter.Close()
+		if err != nil {
+			return fmt.Errorf("git log %s: %w", head, err)
+		}
+	}
+
+	for _, hash := range order {
+		c, err := repo.CommitObject(hash)
+		if err != nil {
+			return fmt.Errorf("commit %s: %w", hash, err)
+		}
+
+		changes, err := firstParentChanges(c)
+		if err != nil {
+			return fmt.Errorf("commit %s: %w", hash, err)
+		}
+
+		paths := make([]string, 0, len(changes))
+		for _, change := range changes {
+			// Deletions have an empty destination.
+			if change.To.Name != "" {
+				paths = append(paths, change.To.Name)
+			} else {
+				paths = append(paths, change.From.Name)
+			}
+		}
+
+		doc := index.CommitDocument(hash.String(), c.Author.Name, c.Committer.When, c.Message, paths, commitBranches[hash])
+		if err := builder.Add(doc); err != nil {
+			return fmt.Errorf("error adding commit %s: %w", hash, err)
+		}
+	}
+
+	return nil
+}
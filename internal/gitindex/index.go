@@ -330,6 +330,17 @@ type Options struct {
 	// If DeltaShardNumberFallbackThreshold is 0, then this fallback behavior is disabled:
 	// a delta build will always be performed regardless of the number of preexisting shards.
 	DeltaShardNumberFallbackThreshold uint64
+
+	// If set, record the last commit which changed each file by walking the
+	// history of the indexed branches. Results then carry the hash, author and
+	// date of that commit, and can be sorted by commit date.
+	FileCommits bool
+
+	// If set, index every commit reachable from the indexed branches as a
+	// document holding its message, author, date and changed paths. Commits
+	// are searched with type:commit queries. Delta builds are disabled, since
+	// they would index the history again.
+	Commits bool
 }
 
 func expandBranches(repo *git.Repository, bs []string, prefix string) ([]string, error) {
@@ -388,6 +399,18 @@ func IndexGitRepo(opts Options) (bool, error) {
 	return indexGitRepo(opts, gitIndexConfig{})
 }
 
+// IndexGitCommits indexes the commit history of the git repository, but none
+// of its files. See Options.Commits.
+func IndexGitCommits(opts Options) (bool, error) {
+	opts.Commits = true
+	opts.FileCommits = false
+	return indexGitRepo(opts, gitIndexConfig{
+		prepareNormalBuild: func(Options, *git.Repository) (map[fileKey]BlobLocation, map[string]map[string]plumbing.Hash, error) {
+			return nil, nil, nil
+		},
+	})
+}
+
 // indexGitRepo indexes the git repository as specified by the options and the provided gitIndexConfig.
 // The returned bool indicates whether the index was updated as a result. This
 // can be informative if doing incremental indexing.
@@ -470,6 +493,11 @@ func indexGitRepo(opts Options, config gitIndexConfig) (bool, error) {
 	// These only have an effect on delta builds
 	var changedOrRemovedFiles []string
 
+	if opts.BuildOptions.IsDelta && opts.Commits {
+		log.Printf("delta build: falling back to normal build since commits are indexed, repository=%q", opts.BuildOptions.RepositoryDescription.Name)
+		opts.BuildOptions.IsDelta = false
+	}
+
 	if opts.BuildOptions.IsDelta {
 		repos, branchVersions, changedOrRemovedFiles, err = prepareDeltaBuild(opts, repo)
 		if err != nil {
@@ -528,6 +556,18 @@ func indexGitRepo(opts Options, config gitIndexConfig) (bool, error) {
 		builder.MarkFileAsChangedOrRemoved(f)
 	}
 
+	var commits map[fileKey]*object.Commit
+	if opts.FileCommits {
+		var heads []plumbing.Hash
+		for _, br := range opts.BuildOptions.RepositoryDescription.Branches {
+			heads = append(heads, plumbing.NewHash(br.Version))
+		}
+		commits, err = lastCommits(repo, heads, repos)
+		if err != nil {
+			return false, fmt.Errorf("lastCommits: %w", err)
+		}
+	}
+
 	var names []string
 	fileKeys := map[string][]fileKey{}
 	totalFiles := 0
@@ -552,6 +592,12 @@ func indexGitRepo(opts Options, config gitIndexConfig) (bool, error) {
 				return false, err
 			}
 
+			if c, ok := commits[key]; ok {
+				doc.CommitDate = c.Committer.When
+				doc.CommitHash = c.Hash.String()
+				doc.CommitAuthor = c.Author.Name
+			}
+
 			if err := builder.Add(doc); err != nil {
 				return false, fmt.Errorf("error adding document with name %s: %w", key.FullPath(), err)
 			}
@@ -561,6 +607,12 @@ func indexGitRepo(opts Options, config gitIndexConfig) (bool, error) {
 			}
 		}
 	}
+
+	if opts.Commits {
+		if err := indexCommits(repo, opts.BuildOptions.RepositoryDescription.Branches, builder); err != nil {
+			return false, fmt.Errorf("indexCommits: %w", err)
+		}
+	}
 	return true, builder.Finish()
 }
 
@@ -27,6 +27,7 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
@@ -132,6 +133,186 @@ func TestIndexTinyRepo(t *testing.T) {
 	}
 }
 
+func TestIndexFileCommits(t *testing.T) {
+	dir := t.TempDir()
+	repoDir := filepath.Join(dir, "repo")
+
+	runScript(t, repoDir, "git init -b main")
+	runScript(t, repoDir, "git config user.email you@example.com && git config user.name 'Your Name'")
+
+	type commitInfo struct {
+		Hash, Author, Date string
+	}
+	commit := func(date, author, script string) commitInfo {
+		runScript(t, repoDir, fmt.Sprintf("%s && git add -A && GIT_COMMITTER_DATE=%s git commit --author '%s <%s@example.com>' -m %s", script, date, author, author, date))
+		out, err := exec.Command("git", "-C", repoDir, "rev-parse", "HEAD").Output()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return commitInfo{Hash: strings.TrimSpace(string(out)), Author: author, Date: date[:len(time.DateOnly)]}
+	}
+	first := commit("2024-01-01T00:00:00Z", "alice", "echo needle > a.go && echo needle > b.go")
+	second := commit("2024-01-02T00:00:00Z", "bob", "echo needle needle > b.go")
+	third := commit("2024-01-03T00:00:00Z", "carol", "echo needle > c.go")
+
+	opts := Options{
+		RepoDir:     repoDir,
+		Branches:    []string{"main"},
+		FileCommits: true,
+		BuildOptions: index.Options{
+			RepositoryDescription: zoekt.Repository{Name: "repo"},
+			IndexDir:              dir,
+		},
+	}
+	if _, err := IndexGitRepo(opts); err != nil {
+		t.Fatalf("IndexGitRepo: %v", err)
+	}
+
+	searcher, err := search.NewDirectorySearcher(dir)
+	if err != nil {
+		t.Fatal("NewDirectorySearcher", err)
+	}
+	defer searcher.Close()
+
+	results, err := searcher.Search(context.Background(), &query.Const{Value: true}, &zoekt.SearchOptions{})
+	if err != nil {
+		t.Fatal("search failed", err)
+	}
+
+	got := map[string]commitInfo{}
+	for _, f := range results.Files {
+		got[f.FileName] = commitInfo{
+			Hash:   f.CommitHash,
+			Author: f.CommitAuthor,
+			Date:   f.CommitDate.Format(time.DateOnly),
+		}
+	}
+	want := map[string]commitInfo{
+		"a.go": first,
+		"b.go": second,
+		"c.go": third,
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("mismatch (-want +got):\n%s", d)
+	}
+}
+
+func TestIndexCommits(t *testing.T) {
+	dir := t.TempDir()
+	repoDir := filepath.Join(dir, "repo")
+
+	runScript(t, repoDir, "git init -b main")
+	runScript(t, repoDir, "git config user.email you@example.com && git config user.name 'Your Name'")
+	runScript(t, repoDir, "echo hello > a.go && git add -A && GIT_COMMITTER_DATE=2024-01-01T00:00:00Z git commit --author 'alice <alice@example.com>' -m 'add greeting'")
+	runScript(t, repoDir, "echo bye > b.go && git rm -q a.go && git add -A && GIT_COMMITTER_DATE=2024-01-02T00:00:00Z git commit --author 'bob <bob@example.com>' -m 'replace greeting'")
+
+	opts := Options{
+		RepoDir:  repoDir,
+		Branches: []string{"main"},
+		Commits:  true,
+		BuildOptions: index.Options{
+			RepositoryDescription: zoekt.Repository{Name: "repo"},
+			IndexDir:              dir,
+		},
+	}
+	if _, err := IndexGitRepo(opts); err != nil {
+		t.Fatalf("IndexGitRepo: %v", err)
+	}
+
+	searcher, err := search.NewDirectorySearcher(dir)
+	if err != nil {
+		t.Fatal("NewDirectorySearcher", err)
+	}
+	defer searcher.Close()
+
+	search := func(q string) *zoekt.SearchResult {
+		t.Helper()
+		parsed, err := query.Parse(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := searcher.Search(context.Background(), parsed, &zoekt.SearchOptions{})
+		if err != nil {
+			t.Fatal("search failed", err)
+		}
+		return res
+	}
+
+	if res := search("greeting"); len(res.Files) != 0 || len(res.Commits) != 0 {
+		t.Errorf("got %d files and %d commits for a content search, want none", len(res.Files), len(res.Commits))
+	}
+
+	type commitInfo struct {
+		Author, Date, Message string
+		Paths, Branches       []string
+	}
+	var got []commitInfo
+	for _, c := range search("type:commit greeting").Commits {
+		got = append(got, commitInfo{
+			Author:   c.Author,
+			Date:     c.Date.UTC().Format(time.DateOnly),
+			Message:  c.Message,
+			Paths:    c.Paths,
+			Branches: c.Branches,
+		})
+	}
+	want := []commitInfo{
+		{Author: "bob", Date: "2024-01-02", Message: "replace greeting\n", Paths: []string{"a.go", "b.go"}, Branches: []string{"main"}},
+		{Author: "alice", Date: "2024-01-01", Message: "add greeting\n", Paths: []string{"a.go"}, Branches: []string{"main"}},
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("mismatch (-want +got):\n%s", d)
+	}
+
+	if res := search("type:commit author:alice"); len(res.Commits) != 1 || res.Commits[0].Message != "add greeting\n" {
+		t.Errorf("got %v for author:alice, want the first commit", res.Commits)
+	}
+}
+
+func TestIndexGitCommits(t *testing.T) {
+	dir := t.TempDir()
+	repoDir := filepath.Join(dir, "repo")
+	indexDir := filepath.Join(dir, "index")
+
+	runScript(t, repoDir, "git init -b main")
+	runScript(t, repoDir, "git config user.email you@example.com && git config user.name 'Your Name'")
+	runScript(t, repoDir, "echo hello > a.go && git add -A && git commit -m 'say hello'")
+
+	opts := Options{
+		RepoDir:  repoDir,
+		Branches: []string{"main"},
+		BuildOptions: index.Options{
+			RepositoryDescription: zoekt.Repository{Name: "repo"},
+			IndexDir:              indexDir,
+		},
+	}
+	if _, err := IndexGitCommits(opts); err != nil {
+		t.Fatalf("IndexGitCommits: %v", err)
+	}
+
+	searcher, err := search.NewDirectorySearcher(indexDir)
+	if err != nil {
+		t.Fatal("NewDirectorySearcher", err)
+	}
+	defer searcher.Close()
+
+	res, err := searcher.Search(context.Background(), &query.Substring{Pattern: "hello"}, &zoekt.SearchOptions{})
+	if err != nil {
+		t.Fatal("search failed", err)
+	}
+	if len(res.Files) != 0 {
+		t.Errorf("got %d files, want none", len(res.Files))
+	}
+
+	res, err = searcher.Search(context.Background(), &query.Type{Type: query.TypeCommit, Child: &query.Substring{Pattern: "hello"}}, &zoekt.SearchOptions{})
+	if err != nil {
+		t.Fatal("search failed", err)
+	}
+	if len(res.Commits) != 1 || res.Commits[0].Message != "say hello\n" {
+		t.Errorf("got commits %v, want the single commit", res.Commits)
+	}
+}
+
 func executeCommand(t *testing.T, dir string, cmd *exec.Cmd) *exec.Cmd {
 	cmd.Dir = dir
 	cmd.Env = []string{
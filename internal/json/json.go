@@ -18,6 +18,7 @@ func JSONServer(searcher zoekt.Searcher) http.Handler {
 	s := jsonSearcher{searcher}
 	mux := http.NewServeMux()
 	mux.HandleFunc("/search", s.jsonSearch)
+	mux.HandleFunc("/stream", s.jsonStream)
 	mux.HandleFunc("/list", s.jsonList)
 	return mux
 }
@@ -60,24 +61,11 @@ func (s *jsonSearcher) jsonSearch(w http.ResponseWriter, req *http.Request) {
 		jsonError(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	if searchArgs.Q == "" {
-		jsonError(w, http.StatusBadRequest, "missing query")
-		return
-	}
-	if searchArgs.Opts == nil {
-		searchArgs.Opts = &zoekt.SearchOptions{}
-	}
-
-	q, err := query.Parse(searchArgs.Q)
-	if err != nil {
-		jsonError(w, http.StatusBadRequest, err.Error())
+	q, ok := parseSearchArgs(w, &searchArgs)
+	if !ok {
 		return
 	}
 
-	if searchArgs.RepoIDs != nil {
-		q = query.NewAnd(q, query.NewRepoIDs(*searchArgs.RepoIDs...))
-	}
-
 	// Set a timeout if the user hasn't specified one.
 	if searchArgs.Opts.MaxWallTime == 0 {
 		var cancel context.CancelFunc
@@ -103,6 +91,29 @@ func (s *jsonSearcher) jsonSearch(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// parseSearchArgs returns the query of args, and defaults args.Opts. If the
+// arguments are invalid, it writes the error to w and returns false.
+func parseSearchArgs(w http.ResponseWriter, args *jsonSearchArgs) (query.Q, bool) {
+	if args.Q == "" {
+		jsonError(w, http.StatusBadRequest, "missing query")
+		return nil, false
+	}
+	if args.Opts == nil {
+		args.Opts = &zoekt.SearchOptions{}
+	}
+
+	q, err := query.Parse(args.Q)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+
+	if args.RepoIDs != nil {
+		q = query.NewAnd(q, query.NewRepoIDs(*args.RepoIDs...))
+	}
+	return q, true
+}
+
 func jsonError(w http.ResponseWriter, statusCode int, err string) {
 	w.WriteHeader(statusCode)
 	json.NewEncoder(w).Encode(struct{ Error string }{Error: err})
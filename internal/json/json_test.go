@@ -2,12 +2,14 @@ package json_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"math"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/sourcegraph/zoekt"
@@ -124,6 +126,43 @@ func TestClientServerWithRepoIDsProvided(t *testing.T) {
 	}
 }
 
+func TestClientServerWithCursor(t *testing.T) {
+	searchQuery := "hello"
+	mock := &mockSearcher.MockSearcher{
+		WantSearch: mustParse(searchQuery),
+		SearchResult: &zoekt.SearchResult{
+			Files: []zoekt.FileMatch{
+				{FileName: "bin.go"},
+			},
+			NextCursor: &zoekt.Cursor{Shard: "shard", Doc: 2, Score: 10},
+		},
+	}
+
+	ts := httptest.NewServer(zjson.JSONServer(mock))
+	defer ts.Close()
+
+	cursor := &zoekt.Cursor{Shard: "shard", Doc: 1}
+	searchBody := "{\"Q\":\"hello\",\"Opts\":{\"Cursor\":\"" + cursor.String() + "\"}}"
+
+	r, err := http.Post(ts.URL+"/search", "application/json", bytes.NewBufferString(searchBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.StatusCode != 200 {
+		body, _ := io.ReadAll(r.Body)
+		t.Fatalf("Got status code %d, err %s", r.StatusCode, string(body))
+	}
+
+	var searchResult struct{ Result *zoekt.SearchResult }
+	err = json.NewDecoder(r.Body).Decode(&searchResult)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(searchResult.Result, mock.SearchResult) {
+		t.Fatalf("\na %+v\nb %+v", searchResult.Result, mock.SearchResult)
+	}
+}
+
 func TestClientServerWithEmptyRepoIDsProvided(t *testing.T) {
 	searchQuery := "hello"
 	expectedSearch := mustParse(searchQuery)
@@ -193,6 +232,116 @@ func TestProgressNotEncodedInSearch(t *testing.T) {
 	}
 }
 
+// streamSearcher streams its chunks in order.
+type streamSearcher struct {
+	mockSearcher.MockSearcher
+	chunks []*zoekt.SearchResult
+
+	gotOpts *zoekt.SearchOptions
+}
+
+func (s *streamSearcher) StreamSearch(ctx context.Context, q query.Q, opts *zoekt.SearchOptions, sender zoekt.Sender) error {
+	s.gotOpts = opts
+	for _, sr := range s.chunks {
+		sender.Send(sr)
+	}
+	return nil
+}
+
+func TestStream(t *testing.T) {
+	mock := &streamSearcher{
+		// Search only estimates the documents for the default limits.
+		MockSearcher: mockSearcher.MockSearcher{
+			WantSearch:   mustParse("needle"),
+			SearchResult: &zoekt.SearchResult{},
+		},
+		chunks: []*zoekt.SearchResult{
+			{Files: []zoekt.FileMatch{{FileName: "a.go"}}, Stats: zoekt.Stats{FileCount: 1, ShardsScanned: 1}},
+			// Chunks without matches only add to the stats.
+			{Stats: zoekt.Stats{ShardsScanned: 1}},
+			{Files: []zoekt.FileMatch{{FileName: "b.go"}}, Stats: zoekt.Stats{FileCount: 1, ShardsScanned: 1}},
+		},
+	}
+
+	ts := httptest.NewServer(zjson.JSONServer(mock))
+	defer ts.Close()
+
+	r, err := http.Post(ts.URL+"/stream", "application/json", strings.NewReader(`{"Q":"needle"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Body.Close()
+	if r.StatusCode != 200 {
+		body, _ := io.ReadAll(r.Body)
+		t.Fatalf("Got status code %d, err %s", r.StatusCode, string(body))
+	}
+	if got := r.Header.Get("Content-Type"); got != "application/x-ndjson" {
+		t.Errorf("got Content-Type %q", got)
+	}
+
+	var files []string
+	var stats *zoekt.Stats
+	dec := json.NewDecoder(r.Body)
+	for dec.More() {
+		var ev struct {
+			Result *zoekt.SearchResult
+			Stats  *zoekt.Stats
+			Error  string
+		}
+		if err := dec.Decode(&ev); err != nil {
+			t.Fatal(err)
+		}
+		switch {
+		case ev.Error != "":
+			t.Fatal(ev.Error)
+		case stats != nil:
+			t.Fatal("event after the stats")
+		case ev.Result != nil:
+			for _, f := range ev.Result.Files {
+				files = append(files, f.FileName)
+			}
+		default:
+			stats = ev.Stats
+		}
+	}
+	if !reflect.DeepEqual(files, []string{"a.go", "b.go"}) {
+		t.Errorf("got files %v", files)
+	}
+	if stats == nil || stats.FileCount != 2 || stats.ShardsScanned != 3 {
+		t.Errorf("got stats %+v", stats)
+	}
+	if mock.gotOpts.FlushWallTime == 0 {
+		t.Error("FlushWallTime is not set")
+	}
+
+	req, err := http.NewRequest("GET", ts.URL+"/stream?q=needle&num=10", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	r, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var events []string
+	for _, line := range strings.Split(string(body), "\n") {
+		if name, ok := strings.CutPrefix(line, "event: "); ok {
+			events = append(events, name)
+		}
+	}
+	if !reflect.DeepEqual(events, []string{"result", "result", "done"}) {
+		t.Errorf("got events %v in\n%s", events, body)
+	}
+	if mock.gotOpts.MaxDocDisplayCount != 10 {
+		t.Errorf("got MaxDocDisplayCount %d, want 10", mock.gotOpts.MaxDocDisplayCount)
+	}
+}
+
 func mustParse(s string) query.Q {
 	q, err := query.Parse(s)
 	if err != nil {
@@ -0,0 +1,176 @@
+package json
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/zoekt"
+	"github.com/sourcegraph/zoekt/query"
+)
+
+// defaultFlushWallTime is how long /stream collects and ranks results before
+// it sends them as they are found, if the request doesn't set
+// Opts.FlushWallTime.
+const defaultFlushWallTime = 500 * time.Millisecond
+
+// jsonStreamEvent is an event of /stream. Exactly one field is set: Result
+// for every chunk of results, Stats for the final event and Error if the
+// search failed.
+type jsonStreamEvent struct {
+	Result *zoekt.SearchResult `json:",omitempty"`
+	Stats  *zoekt.Stats        `json:",omitempty"`
+	Error  string              `json:",omitempty"`
+}
+
+// jsonStream streams the results of a search as they are found, as
+// newline-delimited JSON events, or as server-sent events if the client
+// accepts text/event-stream. It takes the arguments of /search in a POST
+// body, or q and num (Opts.MaxDocDisplayCount) as GET parameters for
+// EventSource.
+//
+// Once streaming started, errors are sent as an event.
+func (s *jsonSearcher) jsonStream(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	w.Header().Add("Content-Type", "application/json")
+
+	searchArgs := jsonSearchArgs{}
+	switch req.Method {
+	case "GET":
+		qv := req.URL.Query()
+		searchArgs.Q = qv.Get("q")
+		if n := qv.Get("num"); n != "" {
+			num, err := strconv.Atoi(n)
+			if err != nil {
+				jsonError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			searchArgs.Opts = &zoekt.SearchOptions{MaxDocDisplayCount: num}
+		}
+	case "POST":
+		if err := json.NewDecoder(req.Body).Decode(&searchArgs); err != nil {
+			jsonError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	default:
+		jsonError(w, http.StatusMethodNotAllowed, "Only GET and POST are supported")
+		return
+	}
+	q, ok := parseSearchArgs(w, &searchArgs)
+	if !ok {
+		return
+	}
+	opts := searchArgs.Opts
+
+	// Set a timeout if the user hasn't specified one.
+	if opts.MaxWallTime == 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+	}
+
+	if err := CalculateDefaultSearchLimits(ctx, q, s.Searcher, opts); err != nil {
+		jsonError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// The searcher ranks what it found until FlushWallTime, and sends the
+	// later results as they come.
+	if opts.FlushWallTime == 0 {
+		opts.FlushWallTime = defaultFlushWallTime
+	}
+
+	sw := &streamWriter{
+		w:   w,
+		rc:  http.NewResponseController(w),
+		sse: strings.Contains(req.Header.Get("Accept"), "text/event-stream"),
+	}
+	if sw.sse {
+		w.Header().Set("Content-Type", "text/event-stream")
+	} else {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	sw.flush()
+
+	if err := streamSearch(ctx, s.Searcher, q, opts, sw); err != nil {
+		sw.write("error", jsonStreamEvent{Error: err.Error()})
+		return
+	}
+	sw.done()
+}
+
+// streamSearch streams the results of searcher to sender, or sends them at
+// once if searcher cannot stream.
+func streamSearch(ctx context.Context, searcher zoekt.Searcher, q query.Q, opts *zoekt.SearchOptions, sender zoekt.Sender) error {
+	if streamer, ok := searcher.(zoekt.Streamer); ok {
+		return streamer.StreamSearch(ctx, q, opts, sender)
+	}
+	sr, err := searcher.Search(ctx, q, opts)
+	if err != nil {
+		return err
+	}
+	sender.Send(sr)
+	return nil
+}
+
+// streamWriter writes the events of /stream and flushes each of them to the
+// client.
+type streamWriter struct {
+	w   http.ResponseWriter
+	rc  *http.ResponseController
+	sse bool
+
+	mu    sync.Mutex
+	stats zoekt.Stats
+}
+
+// Send writes a result event for sr if it has matches. The stats of all
+// results are added up for the final event.
+func (sw *streamWriter) Send(sr *zoekt.SearchResult) {
+	sw.mu.Lock()
+	sw.stats.Add(sr.Stats)
+	sw.mu.Unlock()
+	if len(sr.Files) == 0 && len(sr.Commits) == 0 {
+		return
+	}
+	sw.write("result", jsonStreamEvent{Result: sr})
+}
+
+// done writes the final event with the stats of the search.
+func (sw *streamWriter) done() {
+	sw.mu.Lock()
+	stats := sw.stats
+	sw.mu.Unlock()
+	sw.write("done", jsonStreamEvent{Stats: &stats})
+}
+
+func (sw *streamWriter) write(name string, ev jsonStreamEvent) {
+	b, err := json.Marshal(ev)
+	if err != nil {
+		name = "error"
+		b, _ = json.Marshal(jsonStreamEvent{Error: err.Error()})
+	}
+
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	if sw.sse {
+		fmt.Fprintf(sw.w, "event: %s\ndata: %s\n\n", name, b)
+	} else {
+		sw.w.Write(append(b, '\n'))
+	}
+	sw.flush()
+}
+
+// flush sends what was written to the client. It fails if the client is
+// gone, which cancels the search anyway, or if w cannot flush, in which case
+// the events arrive once the search is done.
+func (sw *streamWriter) flush() {
+	_ = sw.rc.Flush()
+}
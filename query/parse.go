@@ -18,7 +18,11 @@ import (
 	"bytes"
 	"fmt"
 	"log"
+	"maps"
 	"regexp/syntax"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/grafana/regexp"
 
@@ -76,7 +80,7 @@ func isSpace(c byte) bool {
 func Parse(qStr string) (Q, error) {
 	b := []byte(qStr)
 
-	qs, n, err := parseExprList(b)
+	qs, n, err := parseExprList(b, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -195,12 +199,42 @@ func parseExpr(in []byte) (Q, int, error) {
 		}
 
 		expr = &Symbol{q}
+	case tokInSym:
+		if text == "" {
+			return nil, 0, fmt.Errorf("the insym: atom must have an argument")
+		}
+		r, err := regexp.Compile(text)
+		if err != nil {
+			return nil, 0, err
+		}
+		// Later we will lift this into a root, like we do for Type
+		expr = &InSymbol{Symbol: r, Child: nil}
+	case tokAuthor:
+		if text == "" {
+			return nil, 0, fmt.Errorf("the author: atom must have an argument")
+		}
+		r, err := regexp.Compile(text)
+		if err != nil {
+			return nil, 0, err
+		}
+		expr = &Author{Regexp: r}
+	case tokFuzzy:
+		if text == "" {
+			return nil, 0, fmt.Errorf("the fuzzy: atom must have an argument")
+		}
+		expr = &Fuzzy{Pattern: text}
+	case tokBefore, tokAfter:
+		t, err := parseDate(text)
+		if err != nil {
+			return nil, 0, err
+		}
+		expr = &CommitDate{Time: t, Before: tok.Type == tokBefore}
 	case tokParenClose:
 		// Caller must consume paren.
 		expr = nil
 
 	case tokParenOpen:
-		qs, n, err := parseExprList(b)
+		qs, n, err := parseExprList(b, nil)
 		b = b[n:]
 		if err != nil {
 			return nil, 0, err
@@ -219,6 +253,42 @@ func parseExpr(in []byte) (Q, int, error) {
 		if err != nil {
 			return nil, 0, err
 		}
+	case tokNear:
+		if !bytes.HasSuffix(tok.Input, []byte("(")) {
+			return nil, 0, fmt.Errorf("query: near: wants a distance and parenthesized terms, eg. near:5(foo bar), got %q", tok.Input)
+		}
+		distance, err := strconv.Atoi(text)
+		if err != nil {
+			return nil, 0, fmt.Errorf("query: invalid near distance %q: %v", text, err)
+		}
+
+		// The terms are grouped into the near expression before insym:
+		// and type: lift, so that they apply to all of it.
+		qs, n, err := parseExprList(b, func(qs []Q) (Q, error) {
+			if len(qs) == 0 {
+				return nil, fmt.Errorf("query: near: needs at least one argument")
+			}
+			for _, q := range qs {
+				if _, ok := q.(*orOperator); ok {
+					return nil, fmt.Errorf("query: near: does not support or between its terms, use parentheses")
+				}
+			}
+			return &Near{Children: qs, Distance: distance}, nil
+		})
+		b = b[n:]
+		if err != nil {
+			return nil, 0, err
+		}
+
+		pTok, err := nextToken(b)
+		if err != nil {
+			return nil, 0, err
+		}
+		if pTok == nil || pTok.Type != tokParenClose {
+			return nil, 0, fmt.Errorf("query: missing close paren, got token %v", pTok)
+		}
+		b = b[len(pTok.Input):]
+		expr = qs[0]
 	case tokNegate:
 		subQ, n, err := parseExpr(b)
 		if err != nil {
@@ -227,6 +297,11 @@ func parseExpr(in []byte) (Q, int, error) {
 		if subQ == nil {
 			return nil, 0, fmt.Errorf("query: '-' operator needs an argument")
 		}
+		if s, ok := subQ.(*InSymbol); ok && s.Child == nil {
+			// insym: applies to the terms next to it, which the negation
+			// doesn't cover.
+			return nil, 0, fmt.Errorf("query: insym: cannot be negated, negate a group instead, eg. -(insym:%s foo)", s.Symbol)
+		}
 		b = b[n:]
 		expr = &Not{subQ}
 
@@ -239,8 +314,10 @@ func parseExpr(in []byte) (Q, int, error) {
 			t = TypeFileName
 		case "repo":
 			t = TypeRepo
+		case "commit":
+			t = TypeCommit
 		default:
-			return nil, 0, fmt.Errorf("query: unknown type argument %q, want {filematch,filename,repo}", text)
+			return nil, 0, fmt.Errorf("query: unknown type argument %q, want {filematch,filename,repo,commit}", text)
 		}
 		// Later we will lift this into a root, like we do for caseQ
 		expr = &Type{Type: t, Child: nil}
@@ -265,6 +342,19 @@ func parseExpr(in []byte) (Q, int, error) {
 	return expr, len(in) - len(b), nil
 }
 
+// parseDate parses the argument of before: and after:, either a date like
+// 2024-01-31 (UTC) or an RFC 3339 time.
+func parseDate(text string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, text); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, text)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("query: invalid date %q, want YYYY-MM-DD or RFC 3339", text)
+	}
+	return t, nil
+}
+
 const regexpFlags syntax.Flags = syntax.ClassNL | syntax.PerlX | syntax.UnicodeGroups
 
 // RegexpQuery parses an atom into either a regular expression, or a
@@ -335,7 +425,11 @@ func parseOperators(in []Q) (Q, error) {
 
 // parseExprList parses a list of query expressions. It is the
 // workhorse of the Parse function.
-func parseExprList(in []byte) ([]Q, int, error) {
+//
+// If group is set, it combines the list into a single expression before the
+// insym: and type: atoms of the list are lifted above it, and the result
+// has one element.
+func parseExprList(in []byte, group func([]Q) (Q, error)) ([]Q, int, error) {
 	b := in[:]
 	var qs []Q
 	for len(b) > 0 {
@@ -367,14 +461,24 @@ func parseExprList(in []byte) ([]Q, int, error) {
 	setCase := "auto"
 	newQS := qs[:0]
 	typeT := uint8(100)
+	var inSyms []*InSymbol
 	for _, q := range qs {
 		switch s := q.(type) {
 		case *caseQ:
 			setCase = s.Flavor
 		case *Type:
-			if s.Type < typeT {
+			if s.Child != nil {
+				// lifted already, eg. out of a near: group
+				newQS = append(newQS, q)
+			} else if s.Type < typeT {
 				typeT = s.Type
 			}
+		case *InSymbol:
+			if s.Child != nil {
+				newQS = append(newQS, q)
+			} else {
+				inSyms = append(inSyms, s)
+			}
 		default:
 			newQS = append(newQS, q)
 		}
@@ -385,6 +489,20 @@ func parseExprList(in []byte) ([]Q, int, error) {
 		}
 		return q
 	})
+	if group != nil {
+		g, err := group(qs)
+		if err != nil {
+			return nil, 0, err
+		}
+		qs = []Q{g}
+	}
+	for _, s := range inSyms {
+		child, err := parseOperators(qs)
+		if err != nil {
+			return nil, 0, err
+		}
+		qs = []Q{&InSymbol{Symbol: s.Symbol, Child: child}}
+	}
 	if typeT != 100 {
 		qs = []Q{&Type{Type: typeT, Child: NewAnd(qs...)}}
 	}
@@ -425,6 +543,12 @@ const (
 	tokPublic     = 16
 	tokFork       = 17
 	tokMeta       = 18
+	tokNear       = 19
+	tokInSym      = 20
+	tokAuthor     = 21
+	tokBefore     = 22
+	tokAfter      = 23
+	tokFuzzy      = 24
 )
 
 var tokNames = map[int]string{
@@ -446,6 +570,12 @@ var tokNames = map[int]string{
 	tokSym:        "Symbol",
 	tokType:       "Type",
 	tokMeta:       "Meta",
+	tokNear:       "Near",
+	tokInSym:      "InSymbol",
+	tokAuthor:     "Author",
+	tokBefore:     "Before",
+	tokAfter:      "After",
+	tokFuzzy:      "Fuzzy",
 }
 
 var prefixes = map[string]int{
@@ -464,9 +594,21 @@ var prefixes = map[string]int{
 	"repo:":     tokRepo,
 	"lang:":     tokLang,
 	"sym:":      tokSym,
+	"insym:":    tokInSym,
 	"t:":        tokType,
 	"type:":     tokType,
 	"meta.":     tokMeta,
+	"near:":     tokNear,
+	"author:":   tokAuthor,
+	"before:":   tokBefore,
+	"after:":    tokAfter,
+	"fuzzy:":    tokFuzzy,
+}
+
+// Fields returns the field prefixes of the query language, eg. "repo:",
+// sorted.
+func Fields() []string {
+	return slices.Sorted(maps.Keys(prefixes))
 }
 
 var reservedWords = map[string]int{
@@ -519,6 +661,10 @@ func nextToken(in []byte) (*token, error) {
 		}, nil
 	}
 
+	if tok := nextNearToken(in); tok != nil {
+		return tok, nil
+	}
+
 	foundSpace := false
 
 loop:
@@ -582,3 +728,26 @@ loop:
 	cur.setType()
 	return &cur, nil
 }
+
+// nextNearToken returns the opening token of a near:N(...) expression, which
+// consumes the distance and the open paren. The terms are parsed like a
+// parenthesized expression list. It returns nil if in doesn't start with a
+// well-formed near operator.
+func nextNearToken(in []byte) *token {
+	const pref = "near:"
+	if !bytes.HasPrefix(in, []byte(pref)) {
+		return nil
+	}
+	i := len(pref)
+	for i < len(in) && '0' <= in[i] && in[i] <= '9' {
+		i++
+	}
+	if i == len(pref) || i == len(in) || in[i] != '(' {
+		return nil
+	}
+	return &token{
+		Type:  tokNear,
+		Text:  in[len(pref):i],
+		Input: in[:i+1],
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"reflect"
 	"regexp/syntax"
 	"testing"
+	"time"
 
 	"github.com/grafana/regexp"
 )
@@ -113,6 +114,57 @@ func TestParseQuery(t *testing.T) {
 		{"type:repo abc", &Type{Type: TypeRepo, Child: &Substring{Pattern: "abc"}}},
 		{"type:file abc def", &Type{Type: TypeFileName, Child: NewAnd(&Substring{Pattern: "abc"}, &Substring{Pattern: "def"})}},
 		{"(type:repo abc) def", NewAnd(&Type{Type: TypeRepo, Child: &Substring{Pattern: "abc"}}, &Substring{Pattern: "def"})},
+		{"type:commit fix", &Type{Type: TypeCommit, Child: &Substring{Pattern: "fix"}}},
+		{"type:commit", &Type{Type: TypeCommit, Child: &Const{Value: true}}},
+
+		// commits
+		{"author:alice fix", NewAnd(&Author{Regexp: regexp.MustCompile("alice")}, &Substring{Pattern: "fix"})},
+		{"after:2024-01-02 before:2024-02-01T12:00:00Z", NewAnd(
+			&CommitDate{Time: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+			&CommitDate{Time: time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC), Before: true})},
+		{"author:", nil},
+		{"before:yesterday", nil},
+
+		// fuzzy
+		{"fuzzy:recieve", &Fuzzy{Pattern: "recieve"}},
+		{"fuzzy:Recieve lang:go", NewAnd(&Fuzzy{Pattern: "Recieve", CaseSensitive: true}, &Language{Language: "Go"})},
+		{"case:no fuzzy:Recieve", &Fuzzy{Pattern: "Recieve"}},
+		{"fuzzy:", nil},
+
+		// near
+		{"near:5(lock unlock)", &Near{Distance: 5, Children: []Q{&Substring{Pattern: "lock"}, &Substring{Pattern: "unlock"}}}},
+		{"near:0(foo (bar or baz)) qux", NewAnd(
+			&Near{Distance: 0, Children: []Q{
+				&Substring{Pattern: "foo"},
+				NewOr(&Substring{Pattern: "bar"}, &Substring{Pattern: "baz"}),
+			}},
+			&Substring{Pattern: "qux"},
+		)},
+		{"near:3(abc)", &Substring{Pattern: "abc"}},
+		{"near:3(Abc def)", &Near{Distance: 3, Children: []Q{&Substring{Pattern: "Abc", CaseSensitive: true}, &Substring{Pattern: "def"}}}},
+		{"near:(abc def)", nil},
+		{"near:x", nil},
+		{"near:2(abc", nil},
+		{"near:2(abc or def)", nil},
+		{"insym:HttpClient.* retry", &InSymbol{Symbol: regexp.MustCompile("HttpClient.*"), Child: &Substring{Pattern: "retry"}}},
+		{"retry insym:^Client$ file:java", &InSymbol{Symbol: regexp.MustCompile("^Client$"), Child: NewAnd(
+			&Substring{Pattern: "retry"},
+			&Substring{Pattern: "java", FileName: true})}},
+		{"insym:Client (retry or backoff)", &InSymbol{Symbol: regexp.MustCompile("Client"), Child: NewOr(
+			&Substring{Pattern: "retry"},
+			&Substring{Pattern: "backoff"})}},
+		{"insym:", nil},
+		{"insym:(", nil},
+		{"near:2(insym:X a b) c", NewAnd(
+			&InSymbol{Symbol: regexp.MustCompile("X"), Child: &Near{Distance: 2, Children: []Q{&Substring{Pattern: "a"}, &Substring{Pattern: "b"}}}},
+			&Substring{Pattern: "c"})},
+		{"insym:X near:2(a b)", &InSymbol{Symbol: regexp.MustCompile("X"), Child: &Near{Distance: 2, Children: []Q{&Substring{Pattern: "a"}, &Substring{Pattern: "b"}}}}},
+		{"near:2(a type:file b)", &Type{Type: TypeFileName, Child: &Near{Distance: 2, Children: []Q{&Substring{Pattern: "a"}, &Substring{Pattern: "b"}}}}},
+		{"-(insym:X a) b", NewAnd(
+			&Not{Child: &InSymbol{Symbol: regexp.MustCompile("X"), Child: &Substring{Pattern: "a"}}},
+			&Substring{Pattern: "b"})},
+		{"-insym:X a", nil},
+		{"near:2(insym:X)", nil},
 
 		// errors.
 		{"--", nil},
@@ -23,6 +23,8 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/RoaringBitmap/roaring"
 	"github.com/grafana/regexp"
@@ -145,6 +147,33 @@ func (l *Language) String() string {
 	return "lang:" + l.Language
 }
 
+// Author matches documents whose last commit was authored by someone whose
+// name matches Regexp. Under type:commit, it matches the author of the commit.
+type Author struct {
+	Regexp *regexp.Regexp
+}
+
+func (q *Author) String() string {
+	return fmt.Sprintf("author:%q", q.Regexp.String())
+}
+
+// CommitDate matches documents whose last commit was made before or after
+// Time. Under type:commit, it matches the date of the commit. Documents
+// without a commit date never match.
+type CommitDate struct {
+	Time time.Time
+
+	// If set, match commits before Time, otherwise from Time on.
+	Before bool
+}
+
+func (q *CommitDate) String() string {
+	if q.Before {
+		return "before:" + q.Time.Format(time.RFC3339)
+	}
+	return "after:" + q.Time.Format(time.RFC3339)
+}
+
 type Const struct {
 	Value bool
 }
@@ -353,6 +382,7 @@ const (
 	TypeFileMatch uint8 = iota
 	TypeFileName
 	TypeRepo
+	TypeCommit
 )
 
 // Type changes the result type returned.
@@ -369,6 +399,8 @@ func (q *Type) String() string {
 		return fmt.Sprintf("(type:filename %s)", q.Child)
 	case TypeRepo:
 		return fmt.Sprintf("(type:repo %s)", q.Child)
+	case TypeCommit:
+		return fmt.Sprintf("(type:commit %s)", q.Child)
 	default:
 		return fmt.Sprintf("(type:UNKNOWN %s)", q.Child)
 	}
@@ -387,6 +419,19 @@ func (q *Boost) String() string {
 	return fmt.Sprintf("(boost %0.2f %s)", q.Boost, q.Child)
 }
 
+// InSymbol restricts the content matches of Child to the scopes of symbols
+// whose qualified name matches Symbol. The qualified name is the symbol name
+// prefixed by its parent scope, eg. "HttpClient.retry" for the method retry of
+// the class HttpClient.
+type InSymbol struct {
+	Symbol *regexp.Regexp
+	Child  Q
+}
+
+func (q *InSymbol) String() string {
+	return fmt.Sprintf("(insym:%q %s)", q.Symbol.String(), q.Child)
+}
+
 // Substring is the most basic query: a query for a substring.
 type Substring struct {
 	Pattern       string
@@ -416,6 +461,90 @@ func (q *Substring) String() string {
 	return s
 }
 
+// Fuzzy matches Pattern like a Substring, and also the terms of the index
+// within edit distance MaxDistance of Pattern, with a lower score. It finds
+// misspelled identifiers, eg. "recieve" for "receive".
+type Fuzzy struct {
+	Pattern       string
+	CaseSensitive bool
+
+	// Match only filename
+	FileName bool
+
+	// Match only content
+	Content bool
+
+	// MaxDist is the maximum edit distance of the expansions of Pattern. If
+	// zero, it depends on the length of Pattern.
+	MaxDist int
+}
+
+func (q *Fuzzy) String() string {
+	t := ""
+	if q.FileName {
+		t = "file_"
+	} else if q.Content {
+		t = "content_"
+	}
+
+	s := fmt.Sprintf("%sfuzzy:%q", t, q.Pattern)
+	if q.MaxDist > 0 {
+		s = fmt.Sprintf("%sfuzzy%d:%q", t, q.MaxDist, q.Pattern)
+	}
+	if q.CaseSensitive {
+		s = "case_" + s
+	}
+	return s
+}
+
+// MaxDistance returns the maximum edit distance of the expansions of the
+// pattern. Short patterns are not expanded, since most short terms are
+// within a couple of edits of each other.
+func (q *Fuzzy) MaxDistance() int {
+	if q.MaxDist > 0 {
+		return q.MaxDist
+	}
+	switch n := utf8.RuneCountInString(q.Pattern); {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// EditDistance returns the number of single rune insertions, deletions,
+// substitutions and transpositions of adjacent runes which turn a into b
+// (the optimal string alignment distance).
+func EditDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	// Three rows of the distance matrix: the current one and the two above,
+	// for transpositions.
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(rb)]
+}
+
 type setCaser interface {
 	setCase(string)
 }
@@ -432,6 +561,17 @@ func (q *Substring) setCase(k string) {
 	}
 }
 
+func (q *Fuzzy) setCase(k string) {
+	switch k {
+	case "yes":
+		q.CaseSensitive = true
+	case "no":
+		q.CaseSensitive = false
+	case "auto":
+		q.CaseSensitive = (q.Pattern != string(toLower([]byte(q.Pattern))))
+	}
+}
+
 func (q *Symbol) setCase(k string) {
 	if sc, ok := q.Expr.(setCaser); ok {
 		sc.setCase(k)
@@ -484,6 +624,22 @@ func (q *And) String() string {
 	return fmt.Sprintf("(and %s)", strings.Join(sub, " "))
 }
 
+// Near is matched when all its children have content matches within Distance
+// lines of each other. Children without content matches (eg. lang:go) only
+// need to match the document.
+type Near struct {
+	Children []Q
+	Distance int
+}
+
+func (q *Near) String() string {
+	var sub []string
+	for _, ch := range q.Children {
+		sub = append(sub, ch.String())
+	}
+	return fmt.Sprintf("(near:%d %s)", q.Distance, strings.Join(sub, " "))
+}
+
 // NewAnd is syntactic sugar for constructing And queries.
 func NewAnd(qs ...Q) Q {
 	return &And{Children: qs}
@@ -565,6 +721,18 @@ func flatten(q Q) (Q, bool) {
 		}
 		flatChildren, changed := flattenAndOr(s.Children, s)
 		return &Or{flatChildren}, changed
+	case *Near:
+		if len(s.Children) == 1 {
+			return s.Children[0], true
+		}
+		var flatChildren []Q
+		changed := false
+		for _, ch := range s.Children {
+			ch, subChanged := flatten(ch)
+			changed = changed || subChanged
+			flatChildren = append(flatChildren, ch)
+		}
+		return &Near{Children: flatChildren, Distance: s.Distance}, changed
 	case *Not:
 		child, changed := flatten(s.Child)
 		return &Not{child}, changed
@@ -574,6 +742,9 @@ func flatten(q Q) (Q, bool) {
 	case *Boost:
 		child, changed := flatten(s.Child)
 		return &Boost{Child: child, Boost: s.Boost}, changed
+	case *InSymbol:
+		child, changed := flatten(s.Child)
+		return &InSymbol{Symbol: s.Symbol, Child: child}, changed
 	default:
 		return q, false
 	}
@@ -627,6 +798,24 @@ func evalConstants(q Q) Q {
 		return evalAndOrConstants(q, s.Children)
 	case *Or:
 		return evalAndOrConstants(q, s.Children)
+	case *Near:
+		// Constants carry no position, so they behave like they would in an
+		// And.
+		children := mapQueryList(s.Children, evalConstants)
+		newCH := children[:0]
+		for _, ch := range children {
+			if c, ok := ch.(*Const); ok {
+				if !c.Value {
+					return ch
+				}
+				continue
+			}
+			newCH = append(newCH, ch)
+		}
+		if len(newCH) == 0 {
+			return &Const{true}
+		}
+		return &Near{Children: newCH, Distance: s.Distance}
 	case *Not:
 		ch := evalConstants(s.Child)
 		if _, ok := ch.(*Const); ok {
@@ -635,13 +824,16 @@ func evalConstants(q Q) Q {
 		return &Not{ch}
 	case *Type:
 		ch := evalConstants(s.Child)
-		if _, ok := ch.(*Const); ok {
+		if c, ok := ch.(*Const); ok && (s.Type != TypeCommit || !c.Value) {
 			// If q is the root query, then evaluating this to a const changes
 			// the type of result we will return. However, the only case this
 			// makes sense is `type:repo TRUE` to return all repos or
 			// `type:file TRUE` to return all filenames. For other cases we
 			// want to do this constant folding though, so we allow the
 			// unexpected behaviour mentioned previously.
+			//
+			// type:commit TRUE is kept, since it restricts the documents to
+			// commits rather than changing the result type.
 			return ch
 		}
 		return &Type{Child: ch, Type: s.Type}
@@ -651,10 +843,21 @@ func evalConstants(q Q) Q {
 			return ch
 		}
 		return &Boost{Boost: s.Boost, Child: ch}
+	case *InSymbol:
+		ch := evalConstants(s.Child)
+		if c, ok := ch.(*Const); ok && !c.Value {
+			return ch
+		}
+		// A true child still requires a matching symbol scope.
+		return &InSymbol{Symbol: s.Symbol, Child: ch}
 	case *Substring:
 		if len(s.Pattern) == 0 {
 			return &Const{true}
 		}
+	case *Fuzzy:
+		if len(s.Pattern) == 0 {
+			return &Const{true}
+		}
 	case *Regexp:
 		if s.Regexp.Op == syntax.OpEmptyMatch {
 			return &Const{true}
@@ -706,12 +909,16 @@ func Map(q Q, f func(q Q) Q) Q {
 		q = &And{Children: mapQueryList(s.Children, f)}
 	case *Or:
 		q = &Or{Children: mapQueryList(s.Children, f)}
+	case *Near:
+		q = &Near{Children: mapQueryList(s.Children, f), Distance: s.Distance}
 	case *Not:
 		q = &Not{Child: Map(s.Child, f)}
 	case *Type:
 		q = &Type{Type: s.Type, Child: Map(s.Child, f)}
 	case *Boost:
 		q = &Boost{Boost: s.Boost, Child: Map(s.Child, f)}
+	case *InSymbol:
+		q = &InSymbol{Symbol: s.Symbol, Child: Map(s.Child, f)}
 	}
 	return f(q)
 }
@@ -730,6 +937,16 @@ func ExpandFileContent(q Q) Q {
 			c.Content = true
 			return NewOr(&f, &c)
 		}
+	case *Fuzzy:
+		if s.FileName == s.Content {
+			f := *s
+			f.FileName = true
+			f.Content = false
+			c := *s
+			c.FileName = false
+			c.Content = true
+			return NewOr(&f, &c)
+		}
 	case *Regexp:
 		if s.FileName == s.Content {
 			f := *s
@@ -750,9 +967,11 @@ func VisitAtoms(q Q, v func(q Q)) {
 		switch iQ.(type) {
 		case *And:
 		case *Or:
+		case *Near:
 		case *Not:
 		case *Type:
 		case *Boost:
+		case *InSymbol:
 		default:
 			v(iQ)
 		}
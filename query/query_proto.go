@@ -6,6 +6,7 @@ import (
 
 	"github.com/RoaringBitmap/roaring"
 	"github.com/grafana/regexp"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	webserverv1 "github.com/sourcegraph/zoekt/grpc/protos/zoekt/webserver/v1"
 )
@@ -42,12 +43,22 @@ func QToProto(q Q) *webserverv1.Q {
 		return &webserverv1.Q{Query: &webserverv1.Q_And{And: v.ToProto()}}
 	case *Or:
 		return &webserverv1.Q{Query: &webserverv1.Q_Or{Or: v.ToProto()}}
+	case *Near:
+		return &webserverv1.Q{Query: &webserverv1.Q_Near{Near: v.ToProto()}}
 	case *Not:
 		return &webserverv1.Q{Query: &webserverv1.Q_Not{Not: v.ToProto()}}
 	case *Branch:
 		return &webserverv1.Q{Query: &webserverv1.Q_Branch{Branch: v.ToProto()}}
 	case *Boost:
 		return &webserverv1.Q{Query: &webserverv1.Q_Boost{Boost: v.ToProto()}}
+	case *InSymbol:
+		return &webserverv1.Q{Query: &webserverv1.Q_InSymbol{InSymbol: v.ToProto()}}
+	case *Author:
+		return &webserverv1.Q{Query: &webserverv1.Q_Author{Author: v.ToProto()}}
+	case *CommitDate:
+		return &webserverv1.Q{Query: &webserverv1.Q_CommitDate{CommitDate: v.ToProto()}}
+	case *Fuzzy:
+		return &webserverv1.Q{Query: &webserverv1.Q_Fuzzy{Fuzzy: v.ToProto()}}
 	default:
 		// The following nodes do not have a proto representation:
 		// - caseQ: only used internally, not by the RPC layer
@@ -87,6 +98,8 @@ func QFromProto(p *webserverv1.Q) (Q, error) {
 		return AndFromProto(v.And)
 	case *webserverv1.Q_Or:
 		return OrFromProto(v.Or)
+	case *webserverv1.Q_Near:
+		return NearFromProto(v.Near)
 	case *webserverv1.Q_Not:
 		return NotFromProto(v.Not)
 	case *webserverv1.Q_Branch:
@@ -95,6 +108,14 @@ func QFromProto(p *webserverv1.Q) (Q, error) {
 		return BoostFromProto(v.Boost)
 	case *webserverv1.Q_Meta:
 		return MetaFromProto(v.Meta)
+	case *webserverv1.Q_InSymbol:
+		return InSymbolFromProto(v.InSymbol)
+	case *webserverv1.Q_Author:
+		return AuthorFromProto(v.Author)
+	case *webserverv1.Q_CommitDate:
+		return CommitDateFromProto(v.CommitDate), nil
+	case *webserverv1.Q_Fuzzy:
+		return FuzzyFromProto(v.Fuzzy), nil
 	default:
 		panic(fmt.Sprintf("unknown query node %T", p.Query))
 	}
@@ -298,6 +319,8 @@ func TypeFromProto(p *webserverv1.Type) (*Type, error) {
 		kind = TypeFileName
 	case webserverv1.Type_KIND_REPO:
 		kind = TypeRepo
+	case webserverv1.Type_KIND_COMMIT:
+		kind = TypeCommit
 	}
 
 	return &Type{
@@ -316,6 +339,8 @@ func (q *Type) ToProto() *webserverv1.Type {
 		kind = webserverv1.Type_KIND_FILE_NAME
 	case TypeRepo:
 		kind = webserverv1.Type_KIND_REPO
+	case TypeCommit:
+		kind = webserverv1.Type_KIND_COMMIT
 	}
 
 	return &webserverv1.Type{
@@ -342,6 +367,52 @@ func (q *Substring) ToProto() *webserverv1.Substring {
 	}
 }
 
+func FuzzyFromProto(p *webserverv1.Fuzzy) *Fuzzy {
+	return &Fuzzy{
+		Pattern:       p.GetPattern(),
+		CaseSensitive: p.GetCaseSensitive(),
+		FileName:      p.GetFileName(),
+		Content:       p.GetContent(),
+		MaxDist:       int(p.GetMaxDist()),
+	}
+}
+
+func (q *Fuzzy) ToProto() *webserverv1.Fuzzy {
+	return &webserverv1.Fuzzy{
+		Pattern:       q.Pattern,
+		CaseSensitive: q.CaseSensitive,
+		FileName:      q.FileName,
+		Content:       q.Content,
+		MaxDist:       int32(q.MaxDist),
+	}
+}
+
+func NearFromProto(p *webserverv1.Near) (*Near, error) {
+	children := make([]Q, len(p.GetChildren()))
+	for i, child := range p.GetChildren() {
+		c, err := QFromProto(child)
+		if err != nil {
+			return nil, err
+		}
+		children[i] = c
+	}
+	return &Near{
+		Children: children,
+		Distance: int(p.GetDistance()),
+	}, nil
+}
+
+func (q *Near) ToProto() *webserverv1.Near {
+	children := make([]*webserverv1.Q, len(q.Children))
+	for i, child := range q.Children {
+		children[i] = QToProto(child)
+	}
+	return &webserverv1.Near{
+		Children: children,
+		Distance: int64(q.Distance),
+	}
+}
+
 func OrFromProto(p *webserverv1.Or) (*Or, error) {
 	children := make([]Q, len(p.GetChildren()))
 	for i, child := range p.GetChildren() {
@@ -491,3 +562,53 @@ func (r RawConfig) ToProto() *webserverv1.RawConfig {
 	}
 	return &webserverv1.RawConfig{Flags: flags}
 }
+
+func InSymbolFromProto(p *webserverv1.InSymbol) (*InSymbol, error) {
+	symbol, err := regexp.Compile(p.GetSymbol())
+	if err != nil {
+		return nil, err
+	}
+
+	child, err := QFromProto(p.GetChild())
+	if err != nil {
+		return nil, err
+	}
+
+	return &InSymbol{
+		Symbol: symbol,
+		Child:  child,
+	}, nil
+}
+
+func (q *InSymbol) ToProto() *webserverv1.InSymbol {
+	return &webserverv1.InSymbol{
+		Symbol: q.Symbol.String(),
+		Child:  QToProto(q.Child),
+	}
+}
+
+func AuthorFromProto(p *webserverv1.Author) (*Author, error) {
+	r, err := regexp.Compile(p.GetRegexp())
+	if err != nil {
+		return nil, err
+	}
+	return &Author{Regexp: r}, nil
+}
+
+func (q *Author) ToProto() *webserverv1.Author {
+	return &webserverv1.Author{Regexp: q.Regexp.String()}
+}
+
+func CommitDateFromProto(p *webserverv1.CommitDate) *CommitDate {
+	return &CommitDate{
+		Time:   p.GetTime().AsTime(),
+		Before: p.GetBefore(),
+	}
+}
+
+func (q *CommitDate) ToProto() *webserverv1.CommitDate {
+	return &webserverv1.CommitDate{
+		Time:   timestamppb.New(q.Time),
+		Before: q.Before,
+	}
+}
@@ -3,6 +3,7 @@ package query
 import (
 	"regexp/syntax"
 	"testing"
+	"time"
 
 	"github.com/RoaringBitmap/roaring"
 	"github.com/google/go-cmp/cmp"
@@ -70,9 +71,37 @@ func TestQueryRoundtrip(t *testing.T) {
 				},
 			},
 		},
+		&Near{
+			Children: []Q{
+				&Substring{Pattern: "lock"},
+				&Regexp{Regexp: &syntax.Regexp{Op: syntax.OpLiteral, Rune: []rune("unlock")}},
+			},
+			Distance: 5,
+		},
+		&InSymbol{
+			Symbol: regexp.MustCompile("^HttpClient$"),
+			Child:  &Substring{Pattern: "retry"},
+		},
 		&Not{
 			Child: &Language{Language: "go"},
 		},
+		&Type{
+			Child: &Substring{Pattern: "fix"},
+			Type:  TypeCommit,
+		},
+		&Author{
+			Regexp: regexp.MustCompile("alice"),
+		},
+		&CommitDate{
+			Time:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			Before: true,
+		},
+		&Fuzzy{
+			Pattern:       "recieve",
+			CaseSensitive: true,
+			Content:       true,
+			MaxDist:       2,
+		},
 		&Boost{
 			Child: &Or{
 				Children: []Q{
@@ -168,6 +168,10 @@ func TestExpandFileContent(t *testing.T) {
 			q:    &Regexp{Regexp: re, FileName: false, Content: true},
 			want: "regex:\"foo\"",
 		},
+		{
+			q:    &Fuzzy{Pattern: "foo"},
+			want: "(or file_fuzzy:\"foo\" content_fuzzy:\"foo\")",
+		},
 	}
 
 	for _, tt := range cases {
@@ -178,3 +182,40 @@ func TestExpandFileContent(t *testing.T) {
 		})
 	}
 }
+
+func TestEditDistance(t *testing.T) {
+	for _, tc := range []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"recieve", "receive", 1},
+		{"lenght", "length", 1},
+		{"kitten", "sitting", 3},
+		{"größe", "grösse", 2},
+	} {
+		if got := EditDistance(tc.a, tc.b); got != tc.want {
+			t.Errorf("EditDistance(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+		if got := EditDistance(tc.b, tc.a); got != tc.want {
+			t.Errorf("EditDistance(%q, %q) = %d, want %d", tc.b, tc.a, got, tc.want)
+		}
+	}
+}
+
+func TestFuzzyMaxDistance(t *testing.T) {
+	for _, tc := range []struct {
+		q    Fuzzy
+		want int
+	}{
+		{Fuzzy{Pattern: "foo"}, 0},
+		{Fuzzy{Pattern: "recieve"}, 1},
+		{Fuzzy{Pattern: "recieveMessage"}, 2},
+		{Fuzzy{Pattern: "foo", MaxDist: 2}, 2},
+	} {
+		if got := tc.q.MaxDistance(); got != tc.want {
+			t.Errorf("%s: got %d, want %d", &tc.q, got, tc.want)
+		}
+	}
+}
@@ -44,6 +44,13 @@ func (c *collectSender) Send(r *zoekt.SearchResult) {
 	}
 
 	c.aggregate.Stats.Add(r.Stats)
+	c.aggregate.Facets.Add(r.Facets)
+
+	if r.NextCursor != nil {
+		c.aggregate.NextCursor = r.NextCursor
+	}
+
+	c.aggregate.Explain = append(c.aggregate.Explain, r.Explain...)
 
 	if len(r.Files) > 0 {
 		c.aggregate.Files = append(c.aggregate.Files, r.Files...)
@@ -54,6 +61,11 @@ func (c *collectSender) Send(r *zoekt.SearchResult) {
 		maps.Copy(c.aggregate.LineFragments, r.LineFragments)
 	}
 
+	if len(r.Commits) > 0 {
+		c.aggregate.Commits = append(c.aggregate.Commits, r.Commits...)
+		c.aggregate.Commits = index.SortAndTruncateCommits(c.aggregate.Commits, c.opts)
+	}
+
 	// The priority of our aggregate is the largest priority we collect.
 	if c.aggregate.Priority < r.Priority {
 		c.aggregate.Priority = r.Priority
@@ -79,10 +91,15 @@ func (c *collectSender) Done() (_ *zoekt.SearchResult, ok bool) {
 // newFlushCollectSender creates a sender which will collect and rank results
 // until opts.FlushWallTime. After that it will stream each result as it is
 // sent.
+//
+// If opts.SortBy is not zoekt.SortByScore, results are collected until the
+// final flush, since a later result can sort before everything sent so far.
 func newFlushCollectSender(opts *zoekt.SearchOptions, sender zoekt.Sender) (zoekt.Sender, func()) {
+	collectAll := opts.SortBy != zoekt.SortByScore
+
 	// We don't need to do any collecting, so just pass back the sender to use
 	// directly.
-	if opts.FlushWallTime == 0 {
+	if opts.FlushWallTime == 0 && !collectAll {
 		return sender, func() {}
 	}
 
@@ -121,15 +138,17 @@ func newFlushCollectSender(opts *zoekt.SearchOptions, sender zoekt.Sender) (zoek
 	}
 
 	// Wait FlushWallTime to call stopCollecting.
-	go func() {
-		timer := time.NewTimer(opts.FlushWallTime)
-		select {
-		case <-timerCancel:
-			timer.Stop()
-		case <-timer.C:
-			stopCollectingAndFlush(zoekt.FlushReasonTimerExpired)
-		}
-	}()
+	if !collectAll {
+		go func() {
+			timer := time.NewTimer(opts.FlushWallTime)
+			select {
+			case <-timerCancel:
+				timer.Stop()
+			case <-timer.C:
+				stopCollectingAndFlush(zoekt.FlushReasonTimerExpired)
+			}
+		}()
+	}
 
 	finalFlush := func() {
 		stopCollectingAndFlush(zoekt.FlushReasonFinalFlush)
@@ -0,0 +1,183 @@
+package search
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/sourcegraph/zoekt"
+)
+
+// shardsAfterCursor returns the shards which still need to be searched to
+// resume at c. shards must be in ranked order.
+func shardsAfterCursor(shards []*rankedShard, c *zoekt.Cursor) ([]*rankedShard, error) {
+	if c.Shard == "" {
+		return shards, nil
+	}
+	for i, s := range shards {
+		if s.name == c.Shard {
+			return shards[i:], nil
+		}
+	}
+	return nil, fmt.Errorf("cursor shard %q is not loaded", c.Shard)
+}
+
+// pageCollector collects the results of a paginated search. Shards are
+// searched concurrently, but a page is filled in the order described by
+// zoekt.Cursor: pageCollector buffers results until all the shards before
+// them are done and truncates them once the page is full.
+type pageCollector struct {
+	shards []*rankedShard
+	index  map[*rankedShard]int
+
+	// firstOpts are the search options for the shard the cursor points into,
+	// restOpts for all other shards.
+	firstOpts, restOpts *zoekt.SearchOptions
+	firstDoc            uint32
+
+	// left is the number of files left to fill the page. It is negative if
+	// there is no limit.
+	left int
+
+	results []*zoekt.SearchResult // indexed like shards
+	next    int                   // the next shard to release
+
+	// The position of the last file released.
+	lastShard int
+	lastDoc   uint32
+	lastScore float64
+
+	// cursor is set once the page has ended before the last shard.
+	cursor *zoekt.Cursor
+}
+
+func newPageCollector(shards []*rankedShard, opts *zoekt.SearchOptions) *pageCollector {
+	p := &pageCollector{
+		shards:    shards,
+		index:     make(map[*rankedShard]int, len(shards)),
+		results:   make([]*zoekt.SearchResult, len(shards)),
+		left:      -1,
+		lastShard: -1,
+		lastScore: opts.Cursor.Score,
+	}
+	for i, s := range shards {
+		p.index[s] = i
+	}
+	if opts.MaxDocDisplayCount > 0 {
+		p.left = opts.MaxDocDisplayCount
+	}
+
+	restOpts := *opts
+	restOpts.Cursor = &zoekt.Cursor{}
+	p.restOpts = &restOpts
+	p.firstOpts = p.restOpts
+	if opts.Cursor.Shard != "" {
+		firstOpts := *opts
+		firstOpts.Cursor = &zoekt.Cursor{Doc: opts.Cursor.Doc}
+		p.firstOpts = &firstOpts
+		p.firstDoc = opts.Cursor.Doc
+	}
+
+	return p
+}
+
+// opts returns the search options for shard s.
+func (p *pageCollector) opts(s *rankedShard) *zoekt.SearchOptions {
+	if p.index[s] == 0 {
+		return p.firstOpts
+	}
+	return p.restOpts
+}
+
+// add buffers the result of shard s. It returns the results which are ready
+// to be sent, in order.
+func (p *pageCollector) add(s *rankedShard, sr *zoekt.SearchResult) []*zoekt.SearchResult {
+	p.results[p.index[s]] = sr
+
+	var ready []*zoekt.SearchResult
+	for p.next < len(p.shards) && p.results[p.next] != nil {
+		sr := p.results[p.next]
+		p.results[p.next] = nil
+
+		if p.ended() {
+			// Keep the stats, but the files belong to a later page.
+			sr.Files = nil
+			sr.Facets = nil
+		} else {
+			p.fill(p.next, sr)
+		}
+
+		sr.Priority = p.shards[p.next].priority
+		p.next++
+		ready = append(ready, sr)
+	}
+	return ready
+}
+
+// fill adds the files of the i-th shard to the page.
+func (p *pageCollector) fill(i int, sr *zoekt.SearchResult) {
+	// If the shard did not search all of its documents, the next page has to
+	// continue in this shard.
+	incomplete := sr.Stats.FilesSkipped > 0 || sr.Stats.ShardsSkipped > 0
+
+	if p.left >= 0 {
+		if len(sr.Files) >= p.left {
+			sr.Files = sr.Files[:p.left]
+			incomplete = true
+		}
+		p.left -= len(sr.Files)
+	}
+
+	if n := len(sr.Files); n > 0 {
+		p.lastShard = i
+		p.lastDoc = sr.Files[n-1].DocID
+		p.lastScore = sr.Files[n-1].Score
+	}
+
+	if incomplete {
+		p.cursor = p.resumeAt(i)
+	}
+}
+
+// pendingPriority returns the priority of the next shard to release, or -Inf
+// if there is none.
+func (p *pageCollector) pendingPriority() float64 {
+	if p.ended() || p.next == len(p.shards) {
+		return math.Inf(-1)
+	}
+	return p.shards[p.next].priority
+}
+
+// ended returns true once the page is complete. No more shards need to be
+// searched.
+func (p *pageCollector) ended() bool {
+	return p.cursor != nil
+}
+
+// resumeAt returns the cursor which continues the search in the i-th shard.
+func (p *pageCollector) resumeAt(i int) *zoekt.Cursor {
+	c := &zoekt.Cursor{
+		Shard: p.shards[i].name,
+		Score: p.lastScore,
+	}
+	switch {
+	case i == p.lastShard:
+		c.Doc = p.lastDoc + 1
+	case i == 0:
+		c.Doc = p.firstDoc
+	}
+	return c
+}
+
+// nextCursor returns the cursor for the following page, or nil if there are
+// no more results.
+func (p *pageCollector) nextCursor() *zoekt.Cursor {
+	if p.cursor != nil {
+		return p.cursor
+	}
+	if p.next < len(p.shards) {
+		// We stopped before searching all shards, eg. because we hit
+		// TotalMaxMatchCount.
+		return p.resumeAt(p.next)
+	}
+	return nil
+}
@@ -53,6 +53,10 @@ var (
 		Name: "zoekt_shards_load_failed_total",
 		Help: "The total number of shard loads that failed",
 	})
+	metricShardsCorruptTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "zoekt_shards_corrupt_total",
+		Help: "The total number of shards which failed verification and were quarantined",
+	})
 
 	metricSearchRunning = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "zoekt_search_running",
@@ -176,6 +180,10 @@ var (
 type rankedShard struct {
 	zoekt.Searcher
 
+	// name is the key the shard was loaded under. It identifies the shard in a
+	// zoekt.Cursor.
+	name string
+
 	priority float64 // maximum priority across all repos in the shard
 
 	// We have out of band ranking on compound shards which can change even if
@@ -220,10 +228,54 @@ func newShardedSearcher(n int64) *shardedSearcher {
 	return ss
 }
 
+// DirectorySearcherOption configures the searcher returned by
+// NewDirectorySearcher and NewDirectorySearcherFast.
+type DirectorySearcherOption func(*directorySearcherOptions)
+
+type directorySearcherOptions struct {
+	loadHooks     []func(shards []string)
+	openIndexFile index.IndexFileOpener
+	verifyShards  bool
+}
+
+// WithLoadHook calls fn with the paths of the shards each time the directory
+// watcher has loaded new or updated shards. When fn is called, the shards are
+// visible to searches. fn is called from the watcher goroutine, so it should
+// not block.
+func WithLoadHook(fn func(shards []string)) DirectorySearcherOption {
+	return func(o *directorySearcherOptions) {
+		o.loadHooks = append(o.loadHooks, fn)
+	}
+}
+
+// WithIndexFileOpener sets how shard files are opened. The default is
+// index.NewIndexFile, which maps shards into memory where supported. Use
+// index.NewPreadIndexFile for environments where mmap is unavailable or
+// undesirable, for example:
+//
+//	WithIndexFileOpener(func(f *os.File) (index.IndexFile, error) {
+//		return index.NewPreadIndexFile(f, index.DefaultPreadCacheSize)
+//	})
+func WithIndexFileOpener(open index.IndexFileOpener) DirectorySearcherOption {
+	return func(o *directorySearcherOptions) {
+		o.openIndexFile = open
+	}
+}
+
+// WithShardVerification fully validates each shard with index.VerifyShard
+// before loading it. Corrupt shards are not loaded, but moved into the
+// index.CorruptDir directory of the index. Verification reads every shard
+// completely, so it slows down loading considerably.
+func WithShardVerification() DirectorySearcherOption {
+	return func(o *directorySearcherOptions) {
+		o.verifyShards = true
+	}
+}
+
 // NewDirectorySearcher returns a searcher instance that loads all
 // shards corresponding to a glob into memory.
-func NewDirectorySearcher(dir string) (zoekt.Streamer, error) {
-	return newDirectorySearcher(dir, true)
+func NewDirectorySearcher(dir string, opts ...DirectorySearcherOption) (zoekt.Streamer, error) {
+	return newDirectorySearcher(dir, true, opts)
 }
 
 // NewDirectorySearcherFast is like NewDirectorySearcher, but does not block
@@ -232,14 +284,22 @@ func NewDirectorySearcher(dir string) (zoekt.Streamer, error) {
 // This exists since in the case of zoekt-webserver we are happy with having
 // partial availability since that is better than no availability on large
 // instances.
-func NewDirectorySearcherFast(dir string) (zoekt.Streamer, error) {
-	return newDirectorySearcher(dir, false)
+func NewDirectorySearcherFast(dir string, opts ...DirectorySearcherOption) (zoekt.Streamer, error) {
+	return newDirectorySearcher(dir, false, opts)
 }
 
-func newDirectorySearcher(dir string, waitUntilReady bool) (zoekt.Streamer, error) {
+func newDirectorySearcher(dir string, waitUntilReady bool, opts []DirectorySearcherOption) (zoekt.Streamer, error) {
+	o := directorySearcherOptions{openIndexFile: index.NewIndexFile}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	ss := newShardedSearcher(int64(runtime.GOMAXPROCS(0)))
 	tl := &loader{
-		ss: ss,
+		ss:            ss,
+		loadHooks:     o.loadHooks,
+		openIndexFile: o.openIndexFile,
+		verifyShards:  o.verifyShards,
 	}
 	dw, err := newDirectoryWatcher(dir, tl)
 	if err != nil {
@@ -275,6 +335,16 @@ func (s *directorySearcher) Close() {
 
 type loader struct {
 	ss *shardedSearcher
+
+	// loadHooks are called with the keys of each non-empty load.
+	loadHooks []func(keys []string)
+
+	// openIndexFile opens shard files; index.NewIndexFile if nil.
+	openIndexFile index.IndexFileOpener
+
+	// verifyShards quarantines shards which fail index.VerifyShard instead
+	// of loading them.
+	verifyShards bool
 }
 
 func (tl *loader) load(keys ...string) {
@@ -322,7 +392,7 @@ func (tl *loader) load(keys ...string) {
 			defer sem.Release(1)
 			defer wg.Done()
 
-			shard, err := loadShard(key)
+			shard, err := loadShard(key, tl.openIndexFile, tl.verifyShards)
 			if err != nil {
 				metricShardsLoadFailedTotal.Inc()
 				log.Printf("[ERROR] reloading: %s, err %v ", key, err)
@@ -339,6 +409,10 @@ func (tl *loader) load(keys ...string) {
 	wg.Wait()
 
 	publishLoaded()
+
+	for _, hook := range tl.loadHooks {
+		hook(keys)
+	}
 }
 
 func (tl *loader) drop(keys ...string) {
@@ -674,6 +748,16 @@ func streamSearch(ctx context.Context, proc *process, q query.Q, opts *zoekt.Sea
 		tr.LazyPrintf("selectRepoSet shards=%d->%d q=%s->%s", beforeLen, len(shards), beforeQ, q)
 	}
 
+	// When paginating, the shards before the cursor have already been sent.
+	var page *pageCollector
+	if opts != nil && opts.Cursor != nil {
+		shards, err = shardsAfterCursor(shards, opts.Cursor)
+		if err != nil {
+			return func() {}, err
+		}
+		page = newPageCollector(shards, opts)
+	}
+
 	if len(shards) == 0 {
 		return func() {}, nil
 	}
@@ -692,6 +776,7 @@ func streamSearch(ctx context.Context, proc *process, q query.Q, opts *zoekt.Sea
 	workers := min(runtime.GOMAXPROCS(0), len(shards))
 
 	type result struct {
+		shard    *rankedShard
 		priority float64
 		*zoekt.SearchResult
 		err error
@@ -716,8 +801,12 @@ func streamSearch(ctx context.Context, proc *process, q query.Q, opts *zoekt.Sea
 		go func() {
 			defer wg.Done()
 			for s := range search {
-				sr, err := searchOneShard(ctx, s, q, opts)
-				r := &result{priority: s.priority, SearchResult: sr, err: err}
+				shardOpts := opts
+				if page != nil {
+					shardOpts = page.opts(s)
+				}
+				sr, err := searchOneShard(ctx, s, q, shardOpts)
+				r := &result{shard: s, priority: s.priority, SearchResult: sr, err: err}
 				results <- r
 			}
 		}()
@@ -791,13 +880,40 @@ search:
 
 			observeMetrics(r.SearchResult)
 
-			r.Priority = r.priority
-			r.MaxPendingPriority = pending.max()
+			if page == nil {
+				r.Priority = r.priority
+				r.MaxPendingPriority = pending.max()
+
+				sendByRepository(r.SearchResult, opts, sender) // send the result back to the client
+				continue
+			}
+
+			ready := page.add(r.shard, r.SearchResult)
 
-			sendByRepository(r.SearchResult, opts, sender) // send the result back to the client
+			// Results of later shards are held back until the earlier shards are
+			// done, so they count as pending as well.
+			maxPendingPriority := math.Max(pending.max(), page.pendingPriority())
+			for _, sr := range ready {
+				sr.MaxPendingPriority = maxPendingPriority
+				sendByRepository(sr, opts, sender)
+			}
+			if page.ended() {
+				stop()
+				cancel()
+			}
 		}
 	}
 
+	if page != nil && err == nil {
+		sender.Send(&zoekt.SearchResult{
+			Progress: zoekt.Progress{
+				Priority:           math.Inf(-1),
+				MaxPendingPriority: math.Inf(-1),
+			},
+			NextCursor: page.nextCursor(),
+		})
+	}
+
 	return func() { runtime.KeepAlive(shards) }, err
 }
 
@@ -809,15 +925,14 @@ search:
 // RepoURLs and LineFragments in zoekt.SearchResult.
 func sendByRepository(result *zoekt.SearchResult, opts *zoekt.SearchOptions, sender zoekt.Sender) {
 	if len(result.RepoURLs) <= 1 || len(result.Files) == 0 {
-		index.SortFiles(result.Files)
+		index.SortFilesBy(result.Files, opts.SortBy)
 		sender.Send(result)
 		return
 	}
 
-	send := func(repoName string, a, b int, stats zoekt.Stats) {
-		index.SortFiles(result.Files[a:b])
-		sender.Send(&zoekt.SearchResult{
-			Stats: stats,
+	send := func(repoName string, a, b int, last bool) {
+		index.SortFilesBy(result.Files[a:b], opts.SortBy)
+		sr := &zoekt.SearchResult{
 			Progress: zoekt.Progress{
 				Priority:           result.Files[a].RepositoryPriority,
 				MaxPendingPriority: result.MaxPendingPriority,
@@ -825,7 +940,14 @@ func sendByRepository(result *zoekt.SearchResult, opts *zoekt.SearchOptions, sen
 			Files:         result.Files[a:b],
 			RepoURLs:      map[string]string{repoName: result.RepoURLs[repoName]},
 			LineFragments: map[string]string{repoName: result.LineFragments[repoName]},
-		})
+		}
+		if last {
+			sr.Stats = result.Stats
+			sr.Facets = result.Facets
+			sr.Commits = result.Commits
+			sr.Explain = result.Explain
+		}
+		sender.Send(sr)
 	}
 
 	var startIndex, endIndex int
@@ -835,9 +957,10 @@ func sendByRepository(result *zoekt.SearchResult, opts *zoekt.SearchOptions, sen
 	fm := zoekt.FileMatch{}
 	for endIndex, fm = range result.Files {
 		if curRepoID != fm.RepositoryID {
-			// Stats must stay aggregate-able, hence we sent the aggregate stats with the
-			// last event.
-			send(curRepoName, startIndex, endIndex, zoekt.Stats{})
+			// Stats and facets must stay aggregate-able, hence we sent the aggregate
+			// stats and facets with the last event. The same goes for commits and
+			// explanations.
+			send(curRepoName, startIndex, endIndex, false)
 
 			startIndex = endIndex
 			curRepoID = fm.RepositoryID
@@ -845,7 +968,7 @@ func sendByRepository(result *zoekt.SearchResult, opts *zoekt.SearchOptions, sen
 		}
 	}
 
-	send(curRepoName, startIndex, endIndex+1, result.Stats)
+	send(curRepoName, startIndex, endIndex+1, true)
 }
 
 func observeMetrics(sr *zoekt.SearchResult) {
@@ -886,6 +1009,16 @@ func copyFiles(sr *zoekt.SearchResult) {
 			copySlice(&sr.Files[i].ChunkMatches[c].Content)
 		}
 	}
+	for i := range sr.Commits {
+		for l := range sr.Commits[i].LineMatches {
+			copySlice(&sr.Commits[i].LineMatches[l].Line)
+			copySlice(&sr.Commits[i].LineMatches[l].Before)
+			copySlice(&sr.Commits[i].LineMatches[l].After)
+		}
+		for c := range sr.Commits[i].ChunkMatches {
+			copySlice(&sr.Commits[i].ChunkMatches[c].Content)
+		}
+	}
 }
 
 func searchOneShard(ctx context.Context, s zoekt.Searcher, q query.Q, opts *zoekt.SearchOptions) (sr *zoekt.SearchResult, err error) {
@@ -1018,6 +1151,14 @@ func (ss *shardedSearcher) List(ctx context.Context, q query.Q, opts *zoekt.List
 				uniq[r.Repository.Name] = &cp
 			} else {
 				prev.Stats.Add(&r.Stats)
+				// The newest shard of a repository, such as its latest delta
+				// shard, describes it. This keeps the entry independent of the
+				// order in which shards are listed.
+				if r.IndexMetadata.IndexTime.After(prev.IndexMetadata.IndexTime) {
+					stats := prev.Stats
+					*prev = *r
+					prev.Stats = stats
+				}
 			}
 		}
 
@@ -1134,6 +1275,7 @@ func (s *shardedSearcher) replace(shards map[string]zoekt.Searcher) {
 		var r *rankedShard
 		if shard != nil {
 			r = mkRankedShard(shard)
+			r.name = key
 		}
 
 		old := s.shards[key]
@@ -1184,9 +1326,14 @@ func (s *shardedSearcher) replace(shards map[string]zoekt.Searcher) {
 		if len(ranked[i].repos) == 0 || len(ranked[j].repos) == 0 {
 			// Protect against empty names which can happen if we fail to List or
 			// the shard is full of tombstones. Prefer the shard which has names.
-			return len(ranked[i].repos) >= len(ranked[j].repos)
+			if len(ranked[i].repos) != len(ranked[j].repos) {
+				return len(ranked[i].repos) > len(ranked[j].repos)
+			}
+		} else if a, b := ranked[i].repos[0].Name, ranked[j].repos[0].Name; a != b {
+			return a < b
 		}
-		return ranked[i].repos[0].Name < ranked[j].repos[0].Name
+		// Break ties by shard name so that pagination sees a stable order.
+		return ranked[i].name < ranked[j].name
 	})
 
 	s.ranked.Store(ranked)
@@ -1194,16 +1341,25 @@ func (s *shardedSearcher) replace(shards map[string]zoekt.Searcher) {
 	metricShardsLoaded.Set(float64(len(ranked)))
 }
 
-func loadShard(fn string) (zoekt.Searcher, error) {
+func loadShard(fn string, open index.IndexFileOpener, verify bool) (zoekt.Searcher, error) {
 	f, err := os.Open(fn)
 	if err != nil {
 		return nil, err
 	}
 
-	iFile, err := index.NewIndexFile(f)
+	if open == nil {
+		open = index.NewIndexFile
+	}
+	iFile, err := open(f)
 	if err != nil {
 		return nil, err
 	}
+	if verify {
+		if err := verifyShard(fn, iFile); err != nil {
+			iFile.Close()
+			return nil, err
+		}
+	}
 	s, err := index.NewSearcher(iFile)
 	if err != nil {
 		iFile.Close()
@@ -1213,6 +1369,31 @@ func loadShard(fn string) (zoekt.Searcher, error) {
 	return s, nil
 }
 
+// verifyShard runs index.VerifyShard on the shard fn and quarantines it if
+// it is corrupt.
+func verifyShard(fn string, iFile index.IndexFile) error {
+	problems, err := index.VerifyShard(iFile)
+	if err == nil && len(problems) == 0 {
+		return nil
+	}
+	if err == nil {
+		for i, p := range problems {
+			if i == 10 {
+				log.Printf("[ERROR] %s: %d more problems", fn, len(problems)-i)
+				break
+			}
+			log.Printf("[ERROR] %s: %s", fn, p)
+		}
+		err = fmt.Errorf("%d problems", len(problems))
+	}
+	metricShardsCorruptTotal.Inc()
+	dst, qErr := index.QuarantineShard(fn)
+	if qErr != nil {
+		return fmt.Errorf("corrupt shard %s: %v; quarantine failed: %v", fn, err, qErr)
+	}
+	return fmt.Errorf("corrupt shard %s moved to %s: %v", fn, dst, err)
+}
+
 // prioritySlice is a trivial implementation of an array that provides three
 // things: appending a value, removing a value, and getting the array's max.
 // Operations take O(n) time, which is acceptable because N is restricted to
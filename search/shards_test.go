@@ -24,8 +24,10 @@ import (
 	"log"
 	"math"
 	"os"
+	"path/filepath"
 	"reflect"
 	"runtime"
+	"slices"
 	"sort"
 	"strconv"
 	"testing"
@@ -239,6 +241,193 @@ func TestShardedSearcher_Ranking(t *testing.T) {
 	}
 }
 
+func TestShardedSearcher_Facets(t *testing.T) {
+	ss := newShardedSearcher(1)
+
+	var nextShardNum int
+	addShard := func(repo string, docs ...index.Document) {
+		r := &zoekt.Repository{ID: hash(repo), Name: repo}
+		b := testShardBuilder(t, r, docs...)
+		ss.replace(map[string]zoekt.Searcher{
+			fmt.Sprintf("key-%d", nextShardNum): searcherForTest(t, b),
+		})
+		nextShardNum++
+	}
+
+	addShard("repo1",
+		index.Document{Name: "a/f1.go", Content: []byte("needle")},
+		index.Document{Name: "b/f2.go", Content: []byte("needle")})
+	addShard("repo2",
+		index.Document{Name: "a/f3.py", Content: []byte("needle")},
+		index.Document{Name: "a/f4.py", Content: []byte("haystack")})
+
+	want := zoekt.Facets{
+		zoekt.FacetRepository: {"repo1": 2, "repo2": 1},
+		zoekt.FacetDirectory:  {"a/": 2, "b/": 1},
+	}
+
+	for _, flush := range []time.Duration{0, time.Hour} {
+		opts := &zoekt.SearchOptions{
+			Facets:             []string{zoekt.FacetRepository, zoekt.FacetDirectory},
+			FlushWallTime:      flush,
+			MaxDocDisplayCount: 1,
+		}
+		res, err := ss.Search(context.Background(), &query.Substring{Pattern: "needle"}, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Facets count all matching files, not only the displayed ones.
+		if len(res.Files) != 1 {
+			t.Errorf("got %d files, want 1", len(res.Files))
+		}
+		if d := cmp.Diff(want, res.Facets); d != "" {
+			t.Errorf("FlushWallTime=%s: mismatch (-want +got):\n%s", flush, d)
+		}
+	}
+}
+
+func TestShardedSearcher_SortBy(t *testing.T) {
+	ss := newShardedSearcher(1)
+
+	day := func(d int) time.Time {
+		return time.Date(2024, time.January, d, 0, 0, 0, 0, time.UTC)
+	}
+	for i, repo := range []string{"repo2", "repo1"} {
+		r := &zoekt.Repository{ID: hash(repo), Name: repo}
+		b := testShardBuilder(t, r,
+			index.Document{Name: "b.go", Content: []byte("needle"), CommitDate: day(1 + i)},
+			index.Document{Name: "a.go", Content: []byte("needle needle needle"), CommitDate: day(3 + i)})
+		ss.replace(map[string]zoekt.Searcher{"key-" + repo: searcherForTest(t, b)})
+	}
+
+	for _, tc := range []struct {
+		sortBy zoekt.SortBy
+		want   []string
+	}{
+		{sortBy: zoekt.SortByPath, want: []string{"repo1/a.go", "repo2/a.go", "repo1/b.go"}},
+		{sortBy: zoekt.SortByRepo, want: []string{"repo1/a.go", "repo1/b.go", "repo2/a.go"}},
+		{sortBy: zoekt.SortByCommitDate, want: []string{"repo1/a.go", "repo2/a.go", "repo1/b.go"}},
+	} {
+		for _, flush := range []time.Duration{0, time.Hour} {
+			opts := &zoekt.SearchOptions{
+				SortBy:             tc.sortBy,
+				FlushWallTime:      flush,
+				MaxDocDisplayCount: 3,
+			}
+			res, err := ss.Search(context.Background(), &query.Substring{Pattern: "needle"}, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, f := range res.Files {
+				got = append(got, f.Repository+"/"+f.FileName)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("%s FlushWallTime=%s: mismatch (-want +got):\n%s", tc.sortBy, flush, d)
+			}
+		}
+	}
+}
+
+func TestShardedSearcher_Pagination(t *testing.T) {
+	ss := newShardedSearcher(1)
+
+	var want []string
+	for _, repo := range []string{"repo1", "repo2", "repo3"} {
+		r := &zoekt.Repository{ID: hash(repo), Name: repo}
+		b := testShardBuilder(t, r,
+			index.Document{Name: "f1", Content: []byte("needle")},
+			index.Document{Name: "f2", Content: []byte("haystack")},
+			index.Document{Name: "f3", Content: []byte("needle")},
+			index.Document{Name: "f4", Content: []byte("needle needle")})
+		ss.replace(map[string]zoekt.Searcher{"key-" + repo: searcherForTest(t, b)})
+		want = append(want, repo+"/f1", repo+"/f3", repo+"/f4")
+	}
+
+	q := &query.Substring{Pattern: "needle"}
+
+	search := func(t *testing.T, stream bool, opts *zoekt.SearchOptions) *zoekt.SearchResult {
+		if !stream {
+			res, err := ss.Search(context.Background(), q, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return res
+		}
+		sender := newCollectSender(opts)
+		if err := ss.StreamSearch(context.Background(), q, opts, sender); err != nil {
+			t.Fatal(err)
+		}
+		res, _ := sender.Done()
+		return res
+	}
+
+	for _, stream := range []bool{false, true} {
+		t.Run(fmt.Sprintf("stream=%t", stream), func(t *testing.T) {
+			var got []string
+			var pages int
+			cursor := &zoekt.Cursor{}
+			for cursor != nil {
+				if pages++; pages > 10 {
+					t.Fatal("pagination does not terminate")
+				}
+
+				// Round trip the cursor like a client would.
+				c, err := zoekt.ParseCursor(cursor.String())
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				res := search(t, stream, &zoekt.SearchOptions{
+					Cursor:             c,
+					MaxDocDisplayCount: 2,
+				})
+				if len(res.Files) > 2 {
+					t.Fatalf("page has %d files, want at most 2", len(res.Files))
+				}
+				for _, f := range res.Files {
+					got = append(got, f.Repository+"/"+f.FileName)
+				}
+				cursor = res.NextCursor
+			}
+
+			slices.Sort(got)
+			if d := cmp.Diff(want, got); d != "" {
+				t.Errorf("mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+
+	t.Run("resume", func(t *testing.T) {
+		res := search(t, false, &zoekt.SearchOptions{
+			Cursor:             &zoekt.Cursor{Shard: "key-repo2", Doc: 1},
+			MaxDocDisplayCount: 3,
+		})
+		var got []string
+		for _, f := range res.Files {
+			got = append(got, f.Repository+"/"+f.FileName)
+		}
+		slices.Sort(got)
+		if d := cmp.Diff([]string{"repo2/f3", "repo2/f4", "repo3/f1"}, got); d != "" {
+			t.Errorf("mismatch (-want +got):\n%s", d)
+		}
+		if want := (&zoekt.Cursor{Shard: "key-repo3", Doc: 1}); res.NextCursor == nil || res.NextCursor.Shard != want.Shard || res.NextCursor.Doc != want.Doc {
+			t.Errorf("got next cursor %+v, want %+v", res.NextCursor, want)
+		}
+	})
+
+	t.Run("unknown shard", func(t *testing.T) {
+		_, err := ss.Search(context.Background(), q, &zoekt.SearchOptions{
+			Cursor: &zoekt.Cursor{Shard: "key-gone"},
+		})
+		if err == nil {
+			t.Fatal("expected an error for a cursor into an unloaded shard")
+		}
+	})
+}
+
 func TestShardedSearcher_DocumentRanking(t *testing.T) {
 	ss := newShardedSearcher(1)
 
@@ -402,12 +591,12 @@ func (s *memSeeker) Name() string {
 }
 
 func (s *memSeeker) Close() {}
-func (s *memSeeker) Read(off, sz uint32) ([]byte, error) {
+func (s *memSeeker) Read(off, sz uint64) ([]byte, error) {
 	return s.data[off : off+sz], nil
 }
 
-func (s *memSeeker) Size() (uint32, error) {
-	return uint32(len(s.data)), nil
+func (s *memSeeker) Size() (uint64, error) {
+	return uint64(len(s.data)), nil
 }
 
 func TestUnloadIndex(t *testing.T) {
@@ -889,10 +1078,15 @@ func TestSendByRepository(t *testing.T) {
 	wantStats := zoekt.Stats{}
 	wantStats.ShardsScanned = 1
 
+	wantFacets := zoekt.Facets{zoekt.FacetLanguage: {"Go": 3}}
+	wantExplain := []zoekt.ShardExplain{{Shard: "shard", Query: "TRUE"}}
+
 	// n1, n2, n3 are the number of file matches for each of the 3 repositories in this
 	// test.
 	f := func(n1, n2, n3 uint8) bool {
 		sr := createMockSearchResult(n1, n2, n3, wantStats)
+		sr.Facets = wantFacets
+		sr.Explain = wantExplain
 
 		mock := &mockSender{}
 		sendByRepository(sr, &zoekt.SearchOptions{}, mock)
@@ -902,6 +1096,16 @@ func TestSendByRepository(t *testing.T) {
 			return false
 		}
 
+		if diff := cmp.Diff(wantFacets, mock.facets); diff != "" {
+			t.Logf("-want,+got\n%s", diff)
+			return false
+		}
+
+		if diff := cmp.Diff(wantExplain, mock.explain); diff != "" {
+			t.Logf("-want,+got\n%s", diff)
+			return false
+		}
+
 		nonZero := 0
 		for _, l := range []uint8{n1, n2, n3} {
 			if l > 0 {
@@ -938,12 +1142,16 @@ func TestSendByRepository(t *testing.T) {
 }
 
 type mockSender struct {
-	stats zoekt.Stats
-	files [][]zoekt.FileMatch
+	stats   zoekt.Stats
+	facets  zoekt.Facets
+	explain []zoekt.ShardExplain
+	files   [][]zoekt.FileMatch
 }
 
 func (s *mockSender) Send(sr *zoekt.SearchResult) {
 	s.stats.Add(sr.Stats)
+	s.facets.Add(sr.Facets)
+	s.explain = append(s.explain, sr.Explain...)
 	if len(sr.Files) == 0 {
 		return
 	}
@@ -1227,6 +1435,121 @@ func TestNewDirectorySearcher_empty(t *testing.T) {
 	})
 }
 
+func TestNewDirectorySearcher_loadHook(t *testing.T) {
+	dir := t.TempDir()
+
+	b := testShardBuilder(t, &zoekt.Repository{Name: "repo"}, index.Document{Name: "f1", Content: []byte("needle")})
+	var buf bytes.Buffer
+	if err := b.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	shard := filepath.Join(dir, fmt.Sprintf("repo_v%d.00000.zoekt", index.IndexFormatVersion))
+	if err := os.WriteFile(shard, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := make(chan []string, 1)
+	ss, err := NewDirectorySearcher(dir, WithLoadHook(func(shards []string) {
+		loaded <- shards
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(ss.Close)
+
+	select {
+	case got := <-loaded:
+		if d := cmp.Diff([]string{shard}, got); d != "" {
+			t.Fatalf("loaded shards mismatch (-want +got):\n%s", d)
+		}
+	default:
+		t.Fatal("load hook was not called before NewDirectorySearcher returned")
+	}
+
+	res, err := ss.Search(context.Background(), &query.Substring{Pattern: "needle"}, &zoekt.SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Files) != 1 {
+		t.Fatalf("got %d files, want 1", len(res.Files))
+	}
+}
+
+func TestNewDirectorySearcher_indexFileOpener(t *testing.T) {
+	dir := t.TempDir()
+
+	b := testShardBuilder(t, &zoekt.Repository{Name: "repo"}, index.Document{Name: "f1", Content: []byte("needle")})
+	var buf bytes.Buffer
+	if err := b.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	shard := filepath.Join(dir, fmt.Sprintf("repo_v%d.00000.zoekt", index.IndexFormatVersion))
+	if err := os.WriteFile(shard, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var opened []string
+	ss, err := NewDirectorySearcher(dir, WithIndexFileOpener(func(f *os.File) (index.IndexFile, error) {
+		opened = append(opened, f.Name())
+		return index.NewPreadIndexFile(f, 0)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(ss.Close)
+
+	if d := cmp.Diff([]string{shard}, opened); d != "" {
+		t.Fatalf("opened shards mismatch (-want +got):\n%s", d)
+	}
+
+	res, err := ss.Search(context.Background(), &query.Substring{Pattern: "needle"}, &zoekt.SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Files) != 1 {
+		t.Fatalf("got %d files, want 1", len(res.Files))
+	}
+}
+
+func TestNewDirectorySearcher_shardVerification(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{"good", "bad"} {
+		b := testShardBuilder(t, &zoekt.Repository{Name: name}, index.Document{Name: "f1", Content: []byte("needle in " + name)})
+		var buf bytes.Buffer
+		if err := b.Write(&buf); err != nil {
+			t.Fatal(err)
+		}
+		data := buf.Bytes()
+		if name == "bad" {
+			data[bytes.Index(data, []byte("needle"))] = 'N'
+		}
+		shard := filepath.Join(dir, fmt.Sprintf("%s_v%d.00000.zoekt", name, index.IndexFormatVersion))
+		if err := os.WriteFile(shard, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ss, err := NewDirectorySearcher(dir, WithShardVerification())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(ss.Close)
+
+	res, err := ss.Search(context.Background(), &query.Substring{Pattern: "in"}, &zoekt.SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Files) != 1 || res.Files[0].Repository != "good" {
+		t.Fatalf("got %v, want only the file of good", res.Files)
+	}
+
+	corrupt := filepath.Join(dir, index.CorruptDir, fmt.Sprintf("bad_v%d.00000.zoekt", index.IndexFormatVersion))
+	if _, err := os.Stat(corrupt); err != nil {
+		t.Fatalf("corrupt shard was not quarantined: %v", err)
+	}
+}
+
 // testDeadline returns the deadline for t, but ensures it is no longer than
 // maxTimeout away.
 func testDeadline(t *testing.T, maxTimeout time.Duration) time.Time {
@@ -2,6 +2,11 @@
 
 set -ex
 
+# The shards in shards/ were written by older versions of zoekt and stay as
+# they are, to check that we can still read them. This script writes shards
+# of the current format to shards/current/, which the tests comparing the
+# output of the builder use.
+
 # generate repo17.v17.0000.zoekt
 cp -r repo repo17
 
@@ -11,9 +16,9 @@ mv compound*zoekt repo17_v17.00000.zoekt
 
 rm -rf repo17 repo17_v16.00000.zoekt zoekt-builder-shard-log.tsv
 
-mv ./*.zoekt shards/
+mv ./*.zoekt shards/current/
 
 # generate repo2.v16.0000.zoekt
 go run ../cmd/zoekt-index repo2
 rm zoekt-builder-shard-log.tsv
-mv ./*.zoekt shards/
+mv ./*.zoekt shards/current/
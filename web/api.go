@@ -30,6 +30,9 @@ type LastInput struct {
 	Num   int
 	Ctx   int
 
+	// Sort is the name of the result order, see zoekt.SortBy.
+	Sort string
+
 	// If set, focus on the search box.
 	AutoFocus bool
 
@@ -45,6 +48,42 @@ type ResultInput struct {
 	Stats       zoekt.Stats
 	Duration    time.Duration
 	FileMatches []*FileMatch
+	Commits     []*CommitMatch `json:",omitempty"`
+	Facets      []Facet        `json:",omitempty"`
+
+	// NextCursor is the cursor parameter for the next page of results. It is
+	// only set when paging through results.
+	NextCursor string `json:",omitempty"`
+
+	// DidYouMean is the query with the fuzzy atoms replaced by the terms they
+	// matched, if their own pattern did not match.
+	DidYouMean string `json:",omitempty"`
+
+	// Explain describes how each shard evaluated the query, slowest first. It
+	// is only set in debug mode.
+	Explain []zoekt.ShardExplain `json:",omitempty"`
+
+	// Don't expose to caller of JSON API
+	Paginated bool `json:"-"`
+}
+
+// Facet holds the most common values of a facet for the search results
+// template.
+type Facet struct {
+	Name   string
+	Values []FacetValue
+
+	// Don't expose to caller of JSON API
+	Title string `json:"-"`
+}
+
+// FacetValue holds the number of matching files with a facet value.
+type FacetValue struct {
+	Value string
+	Count int
+
+	// Atom is the query atom restricting the search to Value, if any.
+	Atom string `json:",omitempty"`
 }
 
 // FileMatch holds the per file data provided to search results template
@@ -61,11 +100,32 @@ type FileMatch struct {
 	Matches  []Match
 	URL      string
 
+	// The last commit that touched the file, if recorded at index time.
+	CommitHash   string     `json:",omitempty"`
+	CommitAuthor string     `json:",omitempty"`
+	CommitDate   *time.Time `json:",omitempty"`
+
 	// Don't expose to caller of JSON API
 	Score      float64 `json:"-"`
 	ScoreDebug string  `json:"-"`
 }
 
+// CommitMatch holds the data of a matching commit for the search results
+// template.
+type CommitMatch struct {
+	Repo     string
+	Hash     string
+	Author   string
+	Date     time.Time
+	Message  string
+	Paths    []string `json:",omitempty"`
+	Branches []string `json:",omitempty"`
+
+	// Don't expose to caller of JSON API
+	ShortHash string `json:"-"`
+	Title     string `json:"-"`
+}
+
 // Match holds the per line data provided to the search results template
 type Match struct {
 	URL      string
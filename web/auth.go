@@ -0,0 +1,22 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/sourcegraph/zoekt/internal/auth"
+)
+
+// authenticated returns h behind the authentication of s.Users, if set.
+func (s *Server) authenticated(h http.HandlerFunc) http.Handler {
+	if s.Users == nil {
+		return h
+	}
+	return s.Users.Middleware(h)
+}
+
+// allowRepo reports whether the user of r may see the repository name. It is
+// for the handlers which serve repository contents without going through
+// the searcher, which authorizes requests itself.
+func (s *Server) allowRepo(r *http.Request, name string) bool {
+	return s.Users == nil || auth.Allowed(r.Context(), name)
+}
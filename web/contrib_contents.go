@@ -1,6 +1,7 @@
 package web
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 	"path/filepath"
@@ -12,6 +13,7 @@ import (
 	"log"
 	"bytes"
 	"encoding/json"
+	"slices"
 	"strconv"
 	"sync"
 
@@ -31,16 +33,23 @@ func (s *Server) initContribHandlers(mux *http.ServeMux) {
 		if isDirectory(s.SourceBaseDir) != 1 {
 			log.Fatal(fmt.Sprintf("source code base directory of \"%s\" is not a directory or inaccessible", s.SourceBaseDir))
 		}
-		mux.HandleFunc("/fsprint", s.serveFSPrint)
-		mux.HandleFunc("/scmprint", s.serveScmPrint)
+		mux.Handle("/fsprint", s.authenticated(s.serveFSPrint))
+		mux.Handle("/scmprint", s.authenticated(s.serveScmPrint))
+	}
+	if s.SavedSearches != nil {
+		mux.Handle("/api/saved/", s.authenticated(s.serveSaved))
 	}
 	if keyval.IsKeyvalFSEnabled() {
 		log.Printf("[kv] key-value service is running ...,")
-		mux.HandleFunc("/keyval", s.serveKeyval)
+		mux.Handle("/keyval", s.authenticated(s.serveKeyval))
 	}
 }
 
 func (s *Server) checkAuth(w http.ResponseWriter, r *http.Request) bool {
+	// The handlers are behind the authentication of s.Users.
+	if s.Users != nil {
+		return true
+	}
 	if !s.BasicAuth.checkAuth(r) {
 		w.WriteHeader(401)
 		w.Write(bytes.NewBufferString("Not authenticated.").Bytes())
@@ -146,9 +155,7 @@ func (a *ServerAuthBasic) checkModified() bool {
 }
 
 func (s *Server) serveFSPrint(w http.ResponseWriter, r *http.Request) {
-	if !s.BasicAuth.checkAuth(r) {
-		w.WriteHeader(401)
-		w.Write(bytes.NewBufferString("Not authenticated.").Bytes())
+	if !s.checkAuth(w, r) {
 		return
 	}
 	err := s.serveFSPrintErr(w, r)
@@ -167,9 +174,20 @@ func (s *Server) serveFSPrintErr(w http.ResponseWriter, r *http.Request) error {
 		w.Write([]byte(`{"error":403, "reason": "hacking detcted"}`))
 		return nil
 	}
+	// The first directory below the base directory is the repository; the
+	// base directory lists the allowed ones only.
+	repo, _, _ := strings.Cut(strings.TrimLeft(repoStr+fileStr, "/"), "/")
+	if repo != "" && !s.allowRepo(r, repo) {
+		w.Write([]byte(`{"error":403, "reason": "forbidden"}`))
+		return nil
+	}
 	result := isDirectory(path)
 	if result == 1 {
-		return sendDirectoryContents(w, path)
+		var allow func(string) bool
+		if repo == "" {
+			allow = func(name string) bool { return s.allowRepo(r, name) }
+		}
+		return sendDirectoryContents(w, path, allow)
 	} else if result == 0 {
 		return sendFileContents(w, path)
 	} // else r == -1: err / not exists
@@ -191,7 +209,9 @@ func combileOneItemDirectory(dirname string, basename string) string {
 	return combileOneItemDirectory(dirname, filepath.Join(basename, files[0].Name()))
 }
 
-func sendDirectoryContents(w http.ResponseWriter, path string) error {
+// sendDirectoryContents lists the directory at path, or only the entries
+// for which allow returns true if it is set.
+func sendDirectoryContents(w http.ResponseWriter, path string, allow func(name string) bool) error {
 	files, err := ioutil.ReadDir(path)
 	if err != nil {
 		w.Write([]byte(`{"error":500}`))
@@ -201,6 +221,9 @@ func sendDirectoryContents(w http.ResponseWriter, path string) error {
 	item_tpl := `{"name":"%s"},`
 	for _, file := range files {
 		name := file.Name()
+		if allow != nil && !allow(name) {
+			continue
+		}
 		subpath := filepath.Join(path, name)
 		if isDirectory(subpath) == 1 {
 			name = fmt.Sprintf("%s/", combileOneItemDirectory(path, name))
@@ -299,14 +322,7 @@ func isDirectory(path string) int {
 }
 
 func (s *Server) serveScmPrint(w http.ResponseWriter, r *http.Request) {
-	if !s.BasicAuth.checkAuth(r) {
-		w.WriteHeader(401)
-		w.Write(bytes.NewBufferString("Not authenticated.").Bytes())
-		return
-	}
-
-	if analysis.P4_BIN == "" || analysis.GIT_BIN == "" {
-		utilErrorStr(w, "git/p4 not found", 500)
+	if !s.checkAuth(w, r) {
 		return
 	}
 
@@ -320,19 +336,14 @@ func (s *Server) serveScmPrint(w http.ResponseWriter, r *http.Request) {
 		s.contribListProjects(w, r)
 		return
 	}
+	if !s.allowRepo(r, strings.Trim(repoStr, "/")) {
+		utilErrorStr(w, fmt.Sprintf("'%s' not supported nor found", repoStr), 400)
+		return
+	}
 
 	baseDir  := fmt.Sprintf("%s/%s", s.SourceBaseDir, repoStr)
 	project  := analysis.NewProject(repoStr, baseDir)
-	noProject := false
-	switch v := project.(type) {
-	case *analysis.P4Project:
-		if v == nil { noProject = true }
-	case *analysis.GitProject:
-		if v == nil { noProject = true }
-	default:
-		noProject = true
-	}
-	if noProject {
+	if project == nil {
 		utilErrorStr(w, fmt.Sprintf("'%s' not supported nor found", repoStr), 400)
 		return
 	}
@@ -531,6 +542,7 @@ func (s *Server) contribRenderSearchResult(result *zoekt.SearchResult, q string,
 		Query:       q,
 		QueryStr:    q,
 		FileMatches: fileMatches,
+		Commits:     formatCommits(result.Commits),
 	}
 	if res.Stats.Wait < res.Stats.Duration/10 {
 		// Suppress queueing stats if they are neglible.
@@ -553,6 +565,7 @@ func (s *Server) contribListProjects(w http.ResponseWriter, r *http.Request) {
 		utilErrorStr(w, "internal error", 500)
 		return
 	}
+	list = slices.DeleteFunc(list, func(name string) bool { return !s.allowRepo(r, name) })
 	b, err := json.Marshal(list)
 	if err != nil {
 		log.Printf("failed to parse project list data: %v", err)
@@ -564,6 +577,9 @@ func (s *Server) contribListProjects(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) contribGetLinkInProject(p analysis.IProject, keyval url.Values, w http.ResponseWriter, r *http.Request) {
 	f := keyval.Get("f")
+	if goGitProject, ok := p.(*analysis.GoGitProject); ok {
+		p = goGitProject.GitProject
+	}
 	switch p.(type) {
 	case *analysis.P4Project:
 		p4Project := p.(*analysis.P4Project)
@@ -581,25 +597,85 @@ var INDEX_INGORE_DIRS = []string{".git", ".p4", ".hg", ".zoekt"}
 func (s *Server) contribReindexProject(p analysis.IProject, keyval url.Values, w http.ResponseWriter, r *http.Request) {
 	sourceDir := p.GetBaseDir()
 	projectName := filepath.Base(sourceDir)
-	err := contrib.Index(s.IndexDir, sourceDir, INDEX_INGORE_DIRS)
+	// only changed files are indexed again, on top of the existing shards,
+	// which are compacted once there are too many of them
+	err := contrib.IndexDelta(s.IndexDir, sourceDir, INDEX_INGORE_DIRS)
 	if err != nil {
 		log.Printf("failed to index [%s]: %v", projectName, err)
 		utilErrorStr(w, "internal error", 500)
+		return
+	}
+	// e.g. git commit history for type:commit search
+	if err := p.Compile(); err != nil {
+		log.Printf("failed to compile [%s]: %v", projectName, err)
 	}
 	w.Write([]byte(`{"ok":1}`))
 }
 
 type genReportCtrl struct {
 	m sync.Mutex
+	// progress of reports being generated or failed, by project and report
+	statusM sync.Mutex
+	status map[string]map[string]analysis.OccurrenceStatus
 }
-func (g *genReportCtrl) GenOccurrenceReport(p analysis.IProject, api string, items []string) {
+func (g *genReportCtrl) GenOccurrenceReport(p analysis.IProject, searcher zoekt.Searcher, api string, items []string) {
 	g.m.Lock()
 	defer g.m.Unlock()
-	p.GenOccurrenceReport(api, items)
+	projectName := p.GetName()
+	opts := analysis.OccurrenceReportOptions{
+		Searcher: searcher,
+		Progress: func (status analysis.OccurrenceStatus) {
+			g.setStatus(projectName, api, &status)
+		},
+	}
+	err := p.GenOccurrenceReport(context.Background(), api, items, opts)
+	if err != nil {
+		log.Printf("failed to generate occurrence report [%s/%s]: %v", projectName, api, err)
+		g.setStatus(projectName, api, &analysis.OccurrenceStatus{Error: err.Error()})
+		return
+	}
+	g.setStatus(projectName, api, nil)
+}
+// setStatus records the progress of a report; nil once the report is done
+func (g *genReportCtrl) setStatus(projectName, api string, status *analysis.OccurrenceStatus) {
+	g.statusM.Lock()
+	defer g.statusM.Unlock()
+	g.putStatus(projectName, api, status)
+}
+func (g *genReportCtrl) putStatus(projectName, api string, status *analysis.OccurrenceStatus) {
+	if status == nil {
+		delete(g.status[projectName], api)
+		if len(g.status[projectName]) == 0 {
+			delete(g.status, projectName)
+		}
+		return
+	}
+	if g.status == nil {
+		g.status = make(map[string]map[string]analysis.OccurrenceStatus)
+	}
+	if _, ok := g.status[projectName]; !ok {
+		g.status[projectName] = make(map[string]analysis.OccurrenceStatus)
+	}
+	g.status[projectName][api] = *status
+}
+func (g *genReportCtrl) getStatus(projectName, api string) (analysis.OccurrenceStatus, bool) {
+	g.statusM.Lock()
+	defer g.statusM.Unlock()
+	status, ok := g.status[projectName][api]
+	return status, ok
+}
+// start marks a report as generating unless it already is
+func (g *genReportCtrl) start(projectName, api string) bool {
+	g.statusM.Lock()
+	defer g.statusM.Unlock()
+	if status, ok := g.status[projectName][api]; ok && status.Generating {
+		return false
+	}
+	g.putStatus(projectName, api, &analysis.OccurrenceStatus{Generating: true})
+	return true
 }
 var occurrenceReportCtrl genReportCtrl
 
-var occurrenceReportStatus map[string]map[string]int = make(map[string]map[string]int)
 func (s *Server) contribOccurrenceReport(p analysis.IProject, keyval url.Values, w http.ResponseWriter, r *http.Request) {
 	f := keyval.Get("f")
 	projectName := p.GetName()
@@ -609,10 +685,10 @@ func (s *Server) contribOccurrenceReport(p analysis.IProject, keyval url.Values,
 			report = &analysis.OccurrenceReport{}
 			report.GenTime = 0
 		}
-		// if in progress, set flag in report.Status
-		_, ok := occurrenceReportStatus[projectName]
-		if ok { _, ok = occurrenceReportStatus[projectName][f] }
-		if ok { report.Status, _ = occurrenceReportStatus[projectName][f] }
+		// if in progress or failed, report it in report.Status
+		if status, ok := occurrenceReportCtrl.getStatus(projectName, f); ok {
+			report.Status = status
+		}
 		b, err := json.Marshal(report)
 		if err != nil {
 			utilErrorStr(w, "internal error", 500)
@@ -627,20 +703,11 @@ func (s *Server) contribOccurrenceReport(p analysis.IProject, keyval url.Values,
 			utilErrorStr(w, "bad request", 400)
 			return
 		}
-		if _, ok := occurrenceReportStatus[projectName]; !ok {
-			occurrenceReportStatus[projectName] = make(map[string]int)
-		}
-		i, ok := occurrenceReportStatus[projectName][f]
-		if ok && i != 0 {
+		if !occurrenceReportCtrl.start(projectName, f) {
 			w.Write([]byte(`{"ok":1,"processing":true}`))
 			return
 		}
-		occurrenceReportStatus[projectName][f] = 1
-		go func () {
-			occurrenceReportCtrl.GenOccurrenceReport(p, f, items)
-			// TODO garbage collect, remove key if value = 0
-			occurrenceReportStatus[projectName][f] = 0
-		}()
+		go occurrenceReportCtrl.GenOccurrenceReport(p, s.Searcher, f, items)
 		w.Write([]byte(`{"ok":1}`))
 	} else {
 		// TODO: support CORS option
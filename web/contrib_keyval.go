@@ -3,18 +3,15 @@ package web
 import (
 	"net/http"
 	"net/url"
-	"bytes"
-	"path/filepath"
 	"strconv"
 
 	"github.com/sourcegraph/zoekt/contrib"
 	"github.com/sourcegraph/zoekt/contrib/keyval"
+	"github.com/sourcegraph/zoekt/internal/auth"
 )
 
 func (s *Server) serveKeyval(w http.ResponseWriter, r *http.Request) {
-	if !s.BasicAuth.checkAuth(r) {
-		w.WriteHeader(401)
-		w.Write(bytes.NewBufferString("Not authenticated.").Bytes())
+	if !s.checkAuth(w, r) {
 		return
 	}
 
@@ -22,9 +19,20 @@ func (s *Server) serveKeyval(w http.ResponseWriter, r *http.Request) {
 	cmd := qv.Get("a")
 	switch cmd {
 	case "search":
-		// put keyval index into /storage/.zoekt/index
-		// XXX: pretent as a p4 repo? easy to ignore .p4 folder
-		indexPath := filepath.Join(keyval.GetBaseDir(), ".p4", ".zoekt", "index")
+		storage, err := keyval.DefaultStorage()
+		if err != nil {
+			utilError(w, err, 500)
+			return
+		}
+		indexPath := keyval.IndexDir(keyval.GetBaseDir())
+		if si, ok := storage.(*keyval.StorageIndexed); ok {
+			// Index the values written so far, so that they are found.
+			if err := si.Flush(); err != nil {
+				utilError(w, err, 500)
+				return
+			}
+			indexPath = si.IndexDir()
+		}
 		s.contribSearchKeyval(indexPath, qv, w, r)
 	default:
 		keyval.ServeBasic(w, r)
@@ -48,6 +56,9 @@ func (s *Server) contribSearchKeyval(indexPath string, keyval url.Values, w http
 		utilError(w, err, 500)
 		return
 	}
+	if s.Users != nil {
+		auth.FilterResult(r.Context(), result)
+	}
 
 	s.contribRenderSearchResult(result, q, num, w, r)
 }
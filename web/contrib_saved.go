@@ -0,0 +1,115 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/sourcegraph/zoekt/contrib/savedsearch"
+	"github.com/sourcegraph/zoekt/internal/auth"
+)
+
+// serveSaved serves saved searches:
+//
+//	GET    /api/saved/               list saved searches
+//	GET    /api/saved/<name>         get a saved search
+//	PUT    /api/saved/<name>         save {"query": ..., "webhook": ...}
+//	DELETE /api/saved/<name>         delete a saved search
+//	GET    /api/saved/<name>/changes latest changes of the result set
+//
+// With authentication, each user only sees the searches they saved.
+func (s *Server) serveSaved(w http.ResponseWriter, r *http.Request) {
+	if !s.checkAuth(w, r) {
+		return
+	}
+
+	name, item, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/api/saved/"), "/")
+	owner, ok := s.savedSearchOwner(r)
+	if !ok {
+		http.Error(w, "Not authenticated.", http.StatusUnauthorized)
+		return
+	}
+	var (
+		v   any
+		err error
+	)
+	switch {
+	case name == "" && r.Method == "GET":
+		v, err = s.SavedSearches.List(owner)
+	case name == "":
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	case item == "changes" && r.Method == "GET":
+		var c *savedsearch.Changes
+		if c, err = s.SavedSearches.Changes(owner, name); err == nil {
+			s.filterChanges(r, c)
+		}
+		v = c
+	case item != "":
+		http.NotFound(w, r)
+		return
+	case r.Method == "GET":
+		v, err = s.SavedSearches.Get(owner, name)
+	case r.Method == "PUT" || r.Method == "POST":
+		search := &savedsearch.Search{}
+		body, readErr := io.ReadAll(http.MaxBytesReader(w, r.Body, 64*1024))
+		if readErr == nil {
+			readErr = json.Unmarshal(body, search)
+		}
+		search.Name = name
+		search.Owner = owner
+		if readErr == nil {
+			readErr = search.Validate()
+		}
+		if readErr == nil {
+			readErr = s.SavedSearches.CheckWebhook(search.Webhook)
+		}
+		if readErr != nil {
+			http.Error(w, readErr.Error(), http.StatusBadRequest)
+			return
+		}
+		v, err = search, s.SavedSearches.Save(search)
+	case r.Method == "DELETE":
+		v, err = map[string]string{"name": name}, s.SavedSearches.Delete(owner, name)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if errors.Is(err, savedsearch.ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// savedSearchOwner returns the user whose saved searches r accesses, empty
+// without authentication.
+func (s *Server) savedSearchOwner(r *http.Request) (string, bool) {
+	if s.Users == nil {
+		return "", true
+	}
+	p, ok := auth.FromContext(r.Context())
+	if !ok || p.Name == "" {
+		return "", false
+	}
+	return p.Name, true
+}
+
+// filterChanges removes the hits in repositories the user of r may not see
+// from c. Saved searches run as their owner, but the owner's permissions may
+// have changed since.
+func (s *Server) filterChanges(r *http.Request, c *savedsearch.Changes) {
+	hidden := func(h savedsearch.Hit) bool { return !s.allowRepo(r, h.Repository) }
+	c.Added = slices.DeleteFunc(c.Added, hidden)
+	c.Removed = slices.DeleteFunc(c.Removed, hidden)
+	c.Modified = slices.DeleteFunc(c.Modified, hidden)
+}
@@ -67,29 +67,26 @@ func (s *Server) contribTrack(p analysis.IProject, keyval url.Values, w http.Res
 }
 
 func contribTrackAdd(t *analysis.TrackFile, line int) (string, error) {
-	err := t.Sync()
-	if err != nil { return "", err }
-	tpId, err := t.Add(line)
-	if err != nil { return "", err }
-	err = t.Save()
-	// TODO: if fail, rollback?
+	var tpId int
+	err := t.Update(func() error {
+		var err error
+		tpId, err = t.Add(line)
+		return err
+	})
 	if err != nil { return "", err }
 	j := fmt.Sprintf(`{"id":%d}`, tpId)
 	return j, nil
 }
 
 func contribTrackDel(t *analysis.TrackFile, line int) (string, error) {
-	err := t.Load()
-	if err != nil { return "", err }
-	err = t.Sync()
+	var tp *analysis.TrackPoint
+	err := t.Update(func() error {
+		tp = t.GetByLine(line)
+		if tp == nil { return nil }
+		return t.Del(tp.Id)
+	})
 	if err != nil { return "", err }
-	tp := t.GetByLine(line)
 	if tp == nil { return `{"ok":1}`, nil }
-	err = t.Del(tp.Id)
-	if err != nil { return "", err }
-	err = t.Save()
-	// TODO: if fail, rollback?
-	if err != nil { return "", err }
 	j := fmt.Sprintf(`{"ok":1, "id":%d}`, tp.Id)
 	return j, nil
 }
@@ -23,16 +23,26 @@ import (
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
 	"reflect"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"golang.org/x/crypto/bcrypt"
 
 	"github.com/sourcegraph/zoekt"
+	"github.com/sourcegraph/zoekt/contrib/analysis"
+	"github.com/sourcegraph/zoekt/contrib/keyval"
+	"github.com/sourcegraph/zoekt/contrib/savedsearch"
 	"github.com/sourcegraph/zoekt/index"
+	"github.com/sourcegraph/zoekt/internal/auth"
 	"github.com/sourcegraph/zoekt/query"
 )
 
@@ -43,12 +53,12 @@ type memSeeker struct {
 }
 
 func (s *memSeeker) Close() {}
-func (s *memSeeker) Read(off, sz uint32) ([]byte, error) {
+func (s *memSeeker) Read(off, sz uint64) ([]byte, error) {
 	return s.data[off : off+sz], nil
 }
 
-func (s *memSeeker) Size() (uint32, error) {
-	return uint32(len(s.data)), nil
+func (s *memSeeker) Size() (uint64, error) {
+	return uint64(len(s.data)), nil
 }
 
 func (s *memSeeker) Name() string {
@@ -127,6 +137,8 @@ func TestBasic(t *testing.T) {
 		"/search?q=water": {
 			`href="https://github.com/org/repo/blob/1234/foo/bar%2Bbaz"`,
 			"carry <b>water</b>",
+			"<h6>Directories</h6>",
+			`zoektAddQ('f:\u0022^foo\/\u0022')`,
 		},
 		"/search?q=r:": {
 			"1234\">master",
@@ -848,6 +860,396 @@ func TestDupResult(t *testing.T) {
 	}
 }
 
+func TestPagination(t *testing.T) {
+	b, err := index.NewShardBuilder(&zoekt.Repository{
+		Name: "name",
+	})
+	if err != nil {
+		t.Fatalf("NewShardBuilder: %v", err)
+	}
+
+	for i := range 3 {
+		if err := b.Add(index.Document{
+			Name:    fmt.Sprintf("file%d", i),
+			Content: []byte("bla"),
+		}); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+	s := searcherForTest(t, b)
+	srv := Server{
+		Searcher: s,
+		Top:      Top,
+		HTML:     true,
+	}
+
+	mux, err := NewMux(&srv)
+	if err != nil {
+		t.Fatalf("NewMux: %v", err)
+	}
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	// An empty cursor starts paging at the first file.
+	checkNeedles(t, ts, "/search?q=bla&num=1&cursor=", []string{
+		"file0",
+		"Found 1 results in 1 files",
+	})
+}
+
+func TestLastCommit(t *testing.T) {
+	b, err := index.NewShardBuilder(&zoekt.Repository{
+		Name: "name",
+	})
+	if err != nil {
+		t.Fatalf("NewShardBuilder: %v", err)
+	}
+
+	if err := b.Add(index.Document{
+		Name:         "file",
+		Content:      []byte("bla"),
+		CommitHash:   "abc123",
+		CommitAuthor: "Alice",
+		CommitDate:   time.Now().Add(-3 * 24 * time.Hour),
+	}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	s := searcherForTest(t, b)
+	srv := Server{
+		Searcher: s,
+		Top:      Top,
+		HTML:     true,
+	}
+
+	mux, err := NewMux(&srv)
+	if err != nil {
+		t.Fatalf("NewMux: %v", err)
+	}
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	checkNeedles(t, ts, "/search?q=bla", []string{
+		`title="abc123"`,
+		"last changed by Alice, 3 days ago",
+	})
+}
+
+func TestDidYouMeanResults(t *testing.T) {
+	b, err := index.NewShardBuilder(&zoekt.Repository{
+		Name: "name",
+	})
+	if err != nil {
+		t.Fatalf("NewShardBuilder: %v", err)
+	}
+
+	if err := b.Add(index.Document{
+		Name:    "file",
+		Content: []byte("func receive(msg string) {}"),
+	}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	s := searcherForTest(t, b)
+	srv := Server{
+		Searcher: s,
+		Top:      Top,
+		HTML:     true,
+	}
+
+	mux, err := NewMux(&srv)
+	if err != nil {
+		t.Fatalf("NewMux: %v", err)
+	}
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	checkNeedles(t, ts, "/search?q=fuzzy:recieve", []string{
+		"Found 1 results in 1 files",
+		`Did you mean <a rel="nofollow" href="search?q=receive&num=50&sort=score">receive</a>?`,
+	})
+}
+
+func TestExplainDebug(t *testing.T) {
+	b, err := index.NewShardBuilder(&zoekt.Repository{
+		Name: "name",
+	})
+	if err != nil {
+		t.Fatalf("NewShardBuilder: %v", err)
+	}
+
+	if err := b.Add(index.Document{
+		Name:    "file",
+		Content: []byte("needle in a haystack"),
+	}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	s := searcherForTest(t, b)
+	srv := Server{
+		Searcher: s,
+		Top:      Top,
+		HTML:     true,
+	}
+
+	mux, err := NewMux(&srv)
+	if err != nil {
+		t.Fatalf("NewMux: %v", err)
+	}
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	checkNeedles(t, ts, "/search?q=needle&debug=1", []string{
+		"Query plan: 1 shards searched",
+		"query <code>substr:&#34;needle&#34;</code>",
+		"<code>nee</code> &times;1 <code>dle</code> &times;1",
+	})
+	checkNeedles(t, ts, "/search?q=needle", []string{
+		"Found 1 results in 1 files",
+	})
+}
+
+func TestUsersAuth(t *testing.T) {
+	b, err := index.NewShardBuilder(&zoekt.Repository{
+		Name: "github.com/acme/app",
+	})
+	if err != nil {
+		t.Fatalf("NewShardBuilder: %v", err)
+	}
+	if err := b.Add(index.Document{
+		Name:    "main.go",
+		Content: []byte("needle in a haystack"),
+	}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	usersFile := filepath.Join(t.TempDir(), "users")
+	if err := os.WriteFile(usersFile, []byte(
+		"alice:"+string(hash)+` ^github\.com/acme/`+"\n"+
+			"bob:"+string(hash)+` ^github\.com/other/`+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	users, err := auth.LoadUsers(usersFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storage, err := keyval.Open(keyval.Config{Backend: keyval.BackendBolt, BaseDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer storage.Close()
+
+	srv := Server{
+		Searcher:      auth.NewSearcher(searcherForTest(t, b)),
+		Top:           Top,
+		HTML:          true,
+		RPC:           true,
+		Users:         users,
+		SavedSearches: savedsearch.NewManager(storage, savedsearch.WithOwners(users)),
+	}
+	mux, err := NewMux(&srv)
+	if err != nil {
+		t.Fatalf("NewMux: %v", err)
+	}
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	do := func(method, user, path, body string) (int, string) {
+		req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if user != "" {
+			req.SetBasicAuth(user, "secret")
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		b, err := io.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.StatusCode, string(b)
+	}
+	get := func(user, path string) (int, string) {
+		return do("GET", user, path, "")
+	}
+
+	for _, path := range []string{"/search?q=needle", "/print?r=github.com/acme/app&f=main.go", "/api/suggest?q=mai"} {
+		if code, _ := get("", path); code != http.StatusUnauthorized {
+			t.Errorf("%s without credentials: got status %d", path, code)
+		}
+	}
+	if code, _ := get("", "/healthz"); code != http.StatusOK {
+		t.Errorf("/healthz: got status %d", code)
+	}
+
+	if _, body := get("alice", "/search?q=needle"); !strings.Contains(body, "Found 1 results in 1 files") {
+		t.Errorf("alice does not find the file: %s", body)
+	}
+	if _, body := get("bob", "/search?q=needle"); !strings.Contains(body, "Found 0 results in 0 files") {
+		t.Errorf("bob finds the file: %s", body)
+	}
+	if _, body := get("bob", "/print?r=github.com/acme/app&f=main.go"); strings.Contains(body, "haystack") {
+		t.Errorf("bob can print the file: %s", body)
+	}
+	if _, body := get("bob", "/search?q=r:acme"); strings.Contains(body, "github.com/acme/app") {
+		t.Errorf("bob lists the repository: %s", body)
+	}
+
+	// Saved searches belong to the user who saved them.
+	if code, body := do("PUT", "alice", "/api/saved/hay", `{"query": "haystack", "owner": "bob"}`); code != http.StatusOK {
+		t.Fatalf("alice saves a search: %d %s", code, body)
+	}
+	if code, body := get("alice", "/api/saved/"); code != http.StatusOK || !strings.Contains(body, `"owner":"alice"`) {
+		t.Errorf("alice lists her saved searches: %d %s", code, body)
+	}
+	if _, body := get("bob", "/api/saved/"); body != "[]\n" {
+		t.Errorf("bob lists saved searches of alice: %s", body)
+	}
+	for _, path := range []string{"/api/saved/hay", "/api/saved/hay/changes"} {
+		if code, _ := get("bob", path); code != http.StatusNotFound {
+			t.Errorf("%s of bob: got status %d, want %d", path, code, http.StatusNotFound)
+		}
+	}
+	if code, _ := do("DELETE", "bob", "/api/saved/hay", ""); code != http.StatusNotFound {
+		t.Errorf("bob deletes the saved search of alice: got status %d", code)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	b, err := index.NewShardBuilder(&zoekt.Repository{
+		Name: "github.com/acme/handlers",
+	})
+	if err != nil {
+		t.Fatalf("NewShardBuilder: %v", err)
+	}
+
+	content := "func HandleRequest() {}\nfunc rehandle() {}\n"
+	for _, d := range []index.Document{
+		{
+			Name:    "cmd/handler/main.go",
+			Content: []byte(content),
+			Symbols: []index.DocumentSection{{Start: 5, End: 18}, {Start: 29, End: 37}},
+		},
+		{Name: "cmd/handler/util.go", Content: []byte("package main")},
+		{Name: "README.md", Content: []byte("handlers")},
+	} {
+		if err := b.Add(d); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+	s := searcherForTest(t, b)
+	srv := Server{
+		Searcher: s,
+		Top:      Top,
+		RPC:      true,
+	}
+
+	mux, err := NewMux(&srv)
+	if err != nil {
+		t.Fatalf("NewMux: %v", err)
+	}
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	suggest := func(q string) []Suggestion {
+		t.Helper()
+		res, err := http.Get(ts.URL + "/api/suggest?q=" + url.QueryEscape(q))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		var reply SuggestResult
+		if err := json.NewDecoder(res.Body).Decode(&reply); err != nil {
+			t.Fatal(err)
+		}
+		return reply.Suggestions
+	}
+
+	for _, tc := range []struct {
+		q    string
+		want []Suggestion
+	}{
+		{"", []Suggestion{}},
+		{"lang:go ty", []Suggestion{
+			{Kind: SuggestField, Text: "type:", Query: "lang:go type:"},
+		}},
+		{"r:hand", []Suggestion{
+			{Kind: SuggestRepo, Text: "github.com/acme/handlers", Query: `r:"^github\\.com/acme/handlers$"`},
+		}},
+		{"lang:go f:hand", []Suggestion{
+			{Kind: SuggestFile, Text: "cmd/handler/", Query: `lang:go f:"^cmd/handler/"`},
+		}},
+		{"-sym:handle", []Suggestion{
+			{Kind: SuggestSymbol, Text: "HandleRequest", Query: `-sym:"HandleRequest"`},
+			{Kind: SuggestSymbol, Text: "rehandle", Query: `-sym:"rehandle"`},
+		}},
+		{"handle", []Suggestion{
+			{Kind: SuggestRepo, Text: "github.com/acme/handlers", Query: `r:"^github\\.com/acme/handlers$"`},
+			{Kind: SuggestFile, Text: "cmd/handler/", Query: `f:"^cmd/handler/"`},
+			{Kind: SuggestSymbol, Text: "HandleRequest", Query: `sym:"HandleRequest"`},
+			{Kind: SuggestSymbol, Text: "rehandle", Query: `sym:"rehandle"`},
+		}},
+	} {
+		got := suggest(tc.q)
+		if d := cmp.Diff(tc.want, got, cmpopts.IgnoreUnexported(Suggestion{})); d != "" {
+			t.Errorf("suggest(%q) (-want +got):\n%s", tc.q, d)
+		}
+	}
+}
+
+func TestCommitResults(t *testing.T) {
+	b, err := index.NewShardBuilder(&zoekt.Repository{
+		Name: "name",
+	})
+	if err != nil {
+		t.Fatalf("NewShardBuilder: %v", err)
+	}
+
+	for _, doc := range []index.Document{
+		{Name: "file", Content: []byte("bla")},
+		index.CommitDocument("0123456789abcdef", "Alice", time.Now().Add(-3*24*time.Hour), "fix bla\n\nlonger text", []string{"file"}, nil),
+	} {
+		if err := b.Add(doc); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+	s := searcherForTest(t, b)
+	srv := Server{
+		Searcher: s,
+		Top:      Top,
+		HTML:     true,
+	}
+
+	mux, err := NewMux(&srv)
+	if err != nil {
+		t.Fatalf("NewMux: %v", err)
+	}
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	checkNeedles(t, ts, "/search?q=type:commit+bla", []string{
+		"in 0 files and 1 commits",
+		`<tt title="0123456789abcdef">0123456789</tt>`,
+		"<b>fix bla</b>",
+		"Alice, 3 days ago",
+	})
+	checkNeedles(t, ts, "/search?q=bla", []string{
+		"in 1 files.",
+	})
+}
+
 func TestTruncateLine(t *testing.T) {
 	b, err := index.NewShardBuilder(&zoekt.Repository{
 		Name: "name",
@@ -980,3 +1382,329 @@ func assertResults(t *testing.T, files []zoekt.FileMatch, want string) {
 		t.Fatalf("unexpected results (-want, +got):\n%s", d)
 	}
 }
+
+// stubBackend is registered once, since backends cannot be unregistered.
+var stubBackend = &analysis.StubBackend{
+	Projects: map[string]*analysis.StubProject{
+		"stubproject": {
+			Files: map[string][]byte{
+				"/README.md":   []byte("hello\nworld\n"),
+				"/src/main.go": []byte("package main\n"),
+			},
+			Blame: map[string][]*analysis.BlameDetails{
+				"/README.md": {
+					{Author: "a@example.com", Commit: "c1", Timestamp: 1},
+					{Author: "^", Commit: "^"},
+				},
+			},
+		},
+	},
+}
+
+func TestScmPrintBackend(t *testing.T) {
+	if analysis.GetBackend("web-test") == nil {
+		analysis.RegisterBackend("web-test", stubBackend)
+	}
+
+	sourceDir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(sourceDir, "stubproject"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := index.NewShardBuilder(&zoekt.Repository{Name: "name"})
+	if err != nil {
+		t.Fatalf("NewShardBuilder: %v", err)
+	}
+	srv := Server{
+		Searcher:      searcherForTest(t, b),
+		Top:           Top,
+		HTML:          true,
+		SourceBaseDir: sourceDir,
+	}
+	mux, err := NewMux(&srv)
+	if err != nil {
+		t.Fatalf("NewMux: %v", err)
+	}
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	for req, want := range map[string]string{
+		"/scmprint?r=stubproject&a=get&f=/README.md":         `{"file":true, "contents":"hello\nworld\n"}`,
+		"/scmprint?r=stubproject&a=get&f=/":                  `{"directory":true, "contents":[{"name":"README.md"},{"name":"src/"},null]}`,
+		"/scmprint?r=stubproject&a=blame&f=/README.md&l=1,2": `[{"author":"a@example.com","commit":"c1","timestamp":1},{"author":"^","commit":"^","timestamp":0}]`,
+	} {
+		checkNeedles(t, ts, req, []string{want})
+	}
+
+	checkNeedles(t, ts, "/scmprint?r=missing&a=get&f=/", []string{`{"error":400, "reason": "'missing' not supported nor found"}`})
+}
+
+func TestOccurrenceReport(t *testing.T) {
+	if analysis.GetBackend("web-test") == nil {
+		analysis.RegisterBackend("web-test", stubBackend)
+	}
+
+	sourceDir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(sourceDir, "stubproject"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := index.NewShardBuilder(&zoekt.Repository{
+		Name:     "stubproject",
+		Branches: []zoekt.RepositoryBranch{{Name: "HEAD", Version: "v1"}},
+	})
+	if err != nil {
+		t.Fatalf("NewShardBuilder: %v", err)
+	}
+	for _, d := range []index.Document{
+		{Name: "a.go", Content: []byte("Foo(Foo)\nFooBar\n")},
+		{Name: "b/c.go", Content: []byte("x.Foo()\nBar\n")},
+	} {
+		if err := b.Add(d); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+	srv := Server{
+		Searcher:      searcherForTest(t, b),
+		Top:           Top,
+		HTML:          true,
+		SourceBaseDir: sourceDir,
+	}
+	mux, err := NewMux(&srv)
+	if err != nil {
+		t.Fatalf("NewMux: %v", err)
+	}
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	reportURL := ts.URL + "/scmprint?r=stubproject&a=report_occurrence&f=rename"
+	generate := func() *analysis.OccurrenceReport {
+		res, err := http.Post(reportURL, "application/json", strings.NewReader(`["A.Foo", "B.Foo", "Bar"]`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		deadline := time.Now().Add(10 * time.Second)
+		for {
+			res, err := http.Get(reportURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var report analysis.OccurrenceReport
+			err = json.NewDecoder(res.Body).Decode(&report)
+			res.Body.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if report.Status.Error != "" {
+				t.Fatalf("generating report: %s", report.Status.Error)
+			}
+			if !report.Status.Generating {
+				return &report
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("report still generating: %+v", report.Status)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	report := generate()
+	want := []*analysis.OccurrenceGroup{
+		{Items: []string{"A.Foo", "B.Foo"}, Count: 3, Cases: []string{"/a.go#L1", "/b/c.go#L1"}},
+		{Items: []string{"Bar"}, Count: 1, Cases: []string{"/b/c.go#L2"}},
+	}
+	if d := cmp.Diff(want, report.Groups); d != "" {
+		t.Errorf("groups mismatch (-want +got):\n%s", d)
+	}
+	if want := (analysis.OccurrenceStatus{Done: 2, Total: 2}); report.Status != want {
+		t.Errorf("got status %+v, want %+v", report.Status, want)
+	}
+
+	// The index did not change, so the stored report is kept.
+	storage, err := analysis.MetadataStorage(analysis.NewProject("stubproject", filepath.Join(sourceDir, "stubproject")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	report.GenTime = 1
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := storage.Put(storage.WithSubkey(storage.UrlToKey("report://occurrence"), "rename"), data); err != nil {
+		t.Fatal(err)
+	}
+	if got := generate().GenTime; got != 1 {
+		t.Errorf("report was regenerated for an unchanged index: gentime %d", got)
+	}
+}
+
+// swapSearcher forwards to a searcher which can be replaced, like a
+// directory searcher loading new shards.
+type swapSearcher struct {
+	mu sync.Mutex
+	s  zoekt.Streamer
+}
+
+func (s *swapSearcher) get() zoekt.Streamer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s
+}
+
+func (s *swapSearcher) set(ss zoekt.Streamer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s = ss
+}
+
+func (s *swapSearcher) Search(ctx context.Context, q query.Q, opts *zoekt.SearchOptions) (*zoekt.SearchResult, error) {
+	return s.get().Search(ctx, q, opts)
+}
+
+func (s *swapSearcher) StreamSearch(ctx context.Context, q query.Q, opts *zoekt.SearchOptions, sender zoekt.Sender) error {
+	return s.get().StreamSearch(ctx, q, opts, sender)
+}
+
+func (s *swapSearcher) List(ctx context.Context, q query.Q, opts *zoekt.ListOptions) (*zoekt.RepoList, error) {
+	return s.get().List(ctx, q, opts)
+}
+
+func (s *swapSearcher) Close()         {}
+func (s *swapSearcher) String() string { return "swapSearcher" }
+
+func TestSavedSearchChanges(t *testing.T) {
+	shard := func(docs ...index.Document) zoekt.Streamer {
+		b, err := index.NewShardBuilder(&zoekt.Repository{Name: "repo"})
+		if err != nil {
+			t.Fatalf("NewShardBuilder: %v", err)
+		}
+		for _, d := range docs {
+			if err := b.Add(d); err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+		}
+		return searcherForTest(t, b)
+	}
+
+	hooks := make(chan savedsearch.Changes, 1)
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var c savedsearch.Changes
+		if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+			t.Errorf("webhook: %v", err)
+		}
+		hooks <- c
+	}))
+	defer hook.Close()
+
+	storage, err := keyval.Open(keyval.Config{Backend: keyval.BackendBolt, BaseDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer storage.Close()
+	resultsKey := storage.WithSubkey(storage.UrlToKey("savedsearch://searches"), "dep/results")
+	searcher := &swapSearcher{s: shard(
+		index.Document{Name: "a.go", Content: []byte("deprecatedApi()")},
+		index.Document{Name: "c.go", Content: []byte("deprecatedApi()\n")},
+	)}
+	saved := savedsearch.NewManager(storage, savedsearch.WithWebhookHosts("127.0.0.1"))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go saved.Run(ctx, searcher)
+
+	srv := Server{
+		Searcher:      searcher,
+		Top:           Top,
+		SavedSearches: saved,
+	}
+	mux, err := NewMux(&srv)
+	if err != nil {
+		t.Fatalf("NewMux: %v", err)
+	}
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	do := func(method, path, body string) (int, string) {
+		req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		b, err := io.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.StatusCode, string(b)
+	}
+
+	if code, body := do("PUT", "/api/saved/dep", `{"query": "deprecatedApi", "webhook": "`+hook.URL+`"}`); code != http.StatusOK {
+		t.Fatalf("save: %d %s", code, body)
+	}
+	if code, _ := do("PUT", "/api/saved/bad", `{"query": "("}`); code != http.StatusBadRequest {
+		t.Errorf("saving an invalid query: got %d, want %d", code, http.StatusBadRequest)
+	}
+	if code, _ := do("PUT", "/api/saved/bad", `{"query": "x", "webhook": "http://10.0.0.1/"}`); code != http.StatusBadRequest {
+		t.Errorf("saving a webhook to a host which is not allowed: got %d, want %d", code, http.StatusBadRequest)
+	}
+
+	// The first run records the results without reporting changes.
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		if _, _, err := storage.Get(resultsKey); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("saved search did not run")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	searcher.set(shard(
+		index.Document{Name: "a.go", Content: []byte("deprecatedApi(1)")},
+		index.Document{Name: "b.go", Content: []byte("deprecatedApi()")},
+	))
+	saved.ShardsLoaded(nil)
+
+	var got savedsearch.Changes
+	select {
+	case got = <-hooks:
+	case <-time.After(10 * time.Second):
+		t.Fatal("webhook was not called")
+	}
+	want := savedsearch.Changes{
+		Name:     "dep",
+		Query:    "deprecatedApi",
+		Added:    []savedsearch.Hit{{Repository: "repo", FileName: "b.go"}},
+		Removed:  []savedsearch.Hit{{Repository: "repo", FileName: "c.go"}},
+		Modified: []savedsearch.Hit{{Repository: "repo", FileName: "a.go"}},
+	}
+	ignore := cmpopts.IgnoreFields(savedsearch.Changes{}, "Time")
+	ignoreChecksum := cmpopts.IgnoreFields(savedsearch.Hit{}, "Checksum")
+	if d := cmp.Diff(want, got, ignore, ignoreChecksum); d != "" {
+		t.Errorf("webhook changes mismatch (-want +got):\n%s", d)
+	}
+
+	code, body := do("GET", "/api/saved/dep/changes", "")
+	var stored savedsearch.Changes
+	if err := json.Unmarshal([]byte(body), &stored); code != http.StatusOK || err != nil {
+		t.Fatalf("changes: %d %s", code, body)
+	}
+	if d := cmp.Diff(got, stored, cmpopts.EquateApproxTime(time.Second)); d != "" {
+		t.Errorf("stored changes mismatch (-webhook +stored):\n%s", d)
+	}
+
+	if code, body := do("GET", "/api/saved/", ""); code != http.StatusOK || !strings.Contains(body, `"name":"dep"`) {
+		t.Errorf("list: %d %s", code, body)
+	}
+	if code, body := do("DELETE", "/api/saved/dep", ""); code != http.StatusOK {
+		t.Errorf("delete: %d %s", code, body)
+	}
+	if code, _ := do("GET", "/api/saved/dep/changes", ""); code != http.StatusNotFound {
+		t.Errorf("changes after delete: got %d, want %d", code, http.StatusNotFound)
+	}
+}
@@ -37,6 +37,8 @@ import (
 	zjson "github.com/sourcegraph/zoekt/internal/json"
 
 	"github.com/sourcegraph/zoekt"
+	"github.com/sourcegraph/zoekt/contrib/savedsearch"
+	"github.com/sourcegraph/zoekt/internal/auth"
 	"github.com/sourcegraph/zoekt/internal/tenant/systemtenant"
 	"github.com/sourcegraph/zoekt/query"
 )
@@ -83,6 +85,33 @@ var Funcmap = template.FuncMap{
 		return strings.TrimSuffix(s, "\n")
 	},
 	"JsonText": jsonTextForTemplate,
+	"TimeAgo": func(t time.Time) string {
+		return timeAgo(t, time.Now())
+	},
+}
+
+// timeAgo describes how long before now t was, eg. "3 days ago".
+func timeAgo(t, now time.Time) string {
+	d := now.Sub(t)
+	if d < time.Minute {
+		return "just now"
+	}
+
+	n, unit := int(d/time.Minute), "minute"
+	switch {
+	case d >= 365*24*time.Hour:
+		n, unit = int(d/(365*24*time.Hour)), "year"
+	case d >= 30*24*time.Hour:
+		n, unit = int(d/(30*24*time.Hour)), "month"
+	case d >= 24*time.Hour:
+		n, unit = int(d/(24*time.Hour)), "day"
+	case d >= time.Hour:
+		n, unit = int(d/time.Hour), "hour"
+	}
+	if n != 1 {
+		unit += "s"
+	}
+	return fmt.Sprintf("%d %s ago", n, unit)
 }
 
 // lineMatch represents a line of content with its associated line number
@@ -172,6 +201,13 @@ type Server struct {
 	SourceBaseDir string
 	IndexDir string
 	BasicAuth ServerAuthBasic
+	// Users, if set, authenticates all requests but health checks, robots.txt
+	// and static assets with HTTP basic auth, and supersedes BasicAuth.
+	// Searcher must then be wrapped with auth.NewSearcher to restrict users to
+	// their repositories.
+	Users *auth.Users
+	// SavedSearches, if set, is served under /api/saved/.
+	SavedSearches *savedsearch.Manager
 }
 
 func (s *Server) getTemplate(str string) *template.Template {
@@ -237,13 +273,17 @@ func NewMux(s *Server) (*http.ServeMux, error) {
 	if s.HTML {
 		GenStaticFileServe(mux)
 		mux.HandleFunc("/robots.txt", s.serveRobots)
-		mux.HandleFunc("/search", s.serveSearch)
-		mux.HandleFunc("/", s.serveSearchBox)
-		mux.HandleFunc("/about", s.serveAbout)
-		mux.HandleFunc("/print", s.servePrint)
+		mux.Handle("/search", s.authenticated(s.serveSearch))
+		mux.Handle("/", s.authenticated(s.serveSearchBox))
+		mux.Handle("/about", s.authenticated(s.serveAbout))
+		mux.Handle("/print", s.authenticated(s.servePrint))
 	}
 	if s.RPC {
-		mux.Handle("/api/", http.StripPrefix("/api", zjson.JSONServer(traceAwareSearcher{s.Searcher})))
+		mux.Handle("/api/", s.authenticated(http.StripPrefix("/api", zjson.JSONServer(traceAwareSearcher{s.Searcher})).ServeHTTP))
+	}
+
+	if s.HTML || s.RPC {
+		mux.Handle("/api/suggest", s.authenticated(s.serveSuggest))
 	}
 
 	mux.HandleFunc("/healthz", s.serveHealthz)
@@ -259,7 +299,7 @@ func (s *Server) serveHealthz(w http.ResponseWriter, r *http.Request) {
 	// We need to use WithUnsafeContext here because we want to perform a full
 	// search returning results. The result of this search is not used for anything
 	// other than determining if the server is healthy.
-	result, err := s.Searcher.Search(systemtenant.WithUnsafeContext(r.Context()), q, opts)
+	result, err := s.Searcher.Search(auth.WithUnrestrictedContext(systemtenant.WithUnsafeContext(r.Context())), q, opts)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("not ready: %v", err), http.StatusInternalServerError)
 		return
@@ -358,9 +398,27 @@ func (s *Server) serveSearchErr(w http.ResponseWriter, r *http.Request) (*ApiSea
 	}
 	sOpts.NumContextLines = numCtxLines
 
+	sortBy, err := zoekt.ParseSortBy(qvals.Get("sort"))
+	if err != nil {
+		return nil, err
+	}
+
 	sOpts.SetDefaults()
 	sOpts.MaxDocDisplayCount = num
 	sOpts.DebugScore = debugScore
+	sOpts.Explain = debugScore
+	sOpts.SortBy = sortBy
+	sOpts.Facets = []string{zoekt.FacetRepository, zoekt.FacetLanguage, zoekt.FacetDirectory, zoekt.FacetCategory}
+
+	// A cursor parameter, even an empty one, pages through all results instead
+	// of showing the top num files.
+	paginated := qvals.Has("cursor")
+	if paginated {
+		sOpts.Cursor, err = zoekt.ParseCursor(qvals.Get("cursor"))
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	ctx := r.Context()
 	if err := zjson.CalculateDefaultSearchLimits(ctx, q, s.Searcher, &sOpts); err != nil {
@@ -382,13 +440,22 @@ func (s *Server) serveSearchErr(w http.ResponseWriter, r *http.Request) (*ApiSea
 			Query:     queryStr,
 			Num:       num,
 			Ctx:       numCtxLines,
+			Sort:      sortBy.String(),
 			AutoFocus: true,
 		},
 		Stats:       result.Stats,
 		Query:       q.String(),
 		QueryStr:    queryStr,
 		FileMatches: fileMatches,
+		Commits:     formatCommits(result.Commits),
+		Facets:      formatFacets(result.Facets),
+		Paginated:   paginated,
+	}
+	if result.NextCursor != nil {
+		res.NextCursor = result.NextCursor.String()
 	}
+	res.DidYouMean = didYouMean(q, queryStr, fileMatches)
+	res.Explain = formatExplain(result.Explain)
 	if res.Stats.Wait < res.Stats.Duration/10 {
 		// Suppress queueing stats if they are neglible.
 		res.Stats.Wait = 0
@@ -2,8 +2,11 @@ package web
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/zoekt/query"
 )
 
 func TestAddLineNumbers(t *testing.T) {
@@ -94,3 +97,53 @@ func TestAddLineNumbers(t *testing.T) {
 		})
 	}
 }
+
+func TestTimeAgo(t *testing.T) {
+	now := time.Date(2024, time.June, 15, 12, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		ago  time.Duration
+		want string
+	}{
+		{ago: 10 * time.Second, want: "just now"},
+		{ago: time.Minute, want: "1 minute ago"},
+		{ago: 59 * time.Minute, want: "59 minutes ago"},
+		{ago: 2 * time.Hour, want: "2 hours ago"},
+		{ago: 3 * 24 * time.Hour, want: "3 days ago"},
+		{ago: 45 * 24 * time.Hour, want: "1 month ago"},
+		{ago: 800 * 24 * time.Hour, want: "2 years ago"},
+	} {
+		if got := timeAgo(now.Add(-tc.ago), now); got != tc.want {
+			t.Errorf("timeAgo(-%s) = %q, want %q", tc.ago, got, tc.want)
+		}
+	}
+}
+
+func TestDidYouMean(t *testing.T) {
+	files := func(matches ...string) []*FileMatch {
+		var ms []Match
+		for _, m := range matches {
+			ms = append(ms, Match{Fragments: []Fragment{{Match: m}}})
+		}
+		return []*FileMatch{{Matches: ms}}
+	}
+
+	for _, tc := range []struct {
+		queryStr string
+		matches  []string
+		want     string
+	}{
+		{"fuzzy:recieve lang:go", []string{"receive", "receive", "Recieve"}, ""},
+		{"fuzzy:recieve lang:go", []string{"receive", "receive", "deceive"}, "receive lang:go"},
+		{"fuzzy:lenght", []string{"length", "x"}, "length"},
+		{"lenght", []string{"length"}, ""},
+		{"fuzzy:lenght", nil, ""},
+	} {
+		q, err := query.Parse(tc.queryStr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := didYouMean(q, tc.queryStr, files(tc.matches...)); got != tc.want {
+			t.Errorf("didYouMean(%q, %q) = %q, want %q", tc.queryStr, tc.matches, got, tc.want)
+		}
+	}
+}
@@ -18,13 +18,167 @@ import (
 	"bytes"
 	"log"
 	"net/url"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"text/template"
 
 	"github.com/sourcegraph/zoekt"
+	"github.com/sourcegraph/zoekt/query"
 )
 
+// maxFacetValues is the number of values shown for each facet.
+const maxFacetValues = 10
+
+// facetOrder lists the facets in the order they are shown, and their titles.
+var facetOrder = []struct{ name, title string }{
+	{zoekt.FacetRepository, "Repositories"},
+	{zoekt.FacetLanguage, "Languages"},
+	{zoekt.FacetDirectory, "Directories"},
+	{zoekt.FacetCategory, "Categories"},
+}
+
+// formatFacets returns the most common values of each facet, ordered by count.
+func formatFacets(facets zoekt.Facets) []Facet {
+	var out []Facet
+	for _, f := range facetOrder {
+		counts := facets[f.name]
+		if len(counts) == 0 {
+			continue
+		}
+
+		values := make([]FacetValue, 0, len(counts))
+		for v, n := range counts {
+			values = append(values, FacetValue{Value: v, Count: n, Atom: facetAtom(f.name, v)})
+		}
+		sort.Slice(values, func(i, j int) bool {
+			if values[i].Count != values[j].Count {
+				return values[i].Count > values[j].Count
+			}
+			return values[i].Value < values[j].Value
+		})
+		if len(values) > maxFacetValues {
+			values = values[:maxFacetValues]
+		}
+
+		out = append(out, Facet{Name: f.name, Title: f.title, Values: values})
+	}
+	return out
+}
+
+// formatExplain orders the shard explanations by the time spent in the
+// shard, slowest first.
+func formatExplain(explain []zoekt.ShardExplain) []zoekt.ShardExplain {
+	sort.SliceStable(explain, func(i, j int) bool {
+		return explain[i].MatchTreeConstruction+explain[i].MatchTreeSearch >
+			explain[j].MatchTreeConstruction+explain[j].MatchTreeSearch
+	})
+	return explain
+}
+
+// formatCommits converts the matching commits for the search results
+// template.
+func formatCommits(commits []zoekt.CommitMatch) []*CommitMatch {
+	out := make([]*CommitMatch, 0, len(commits))
+	for _, c := range commits {
+		title, _, _ := strings.Cut(c.Message, "\n")
+		out = append(out, &CommitMatch{
+			Repo:      c.Repository,
+			Hash:      c.Hash,
+			Author:    c.Author,
+			Date:      c.Date,
+			Message:   c.Message,
+			Paths:     c.Paths,
+			Branches:  c.Branches,
+			ShortHash: c.Hash[:min(len(c.Hash), 10)],
+			Title:     title,
+		})
+	}
+	return out
+}
+
+// didYouMean returns queryStr with the fuzzy atoms of q replaced by the term
+// they matched most often, for the atoms whose pattern did not match
+// itself. It returns "" if there is nothing to suggest.
+func didYouMean(q query.Q, queryStr string, files []*FileMatch) string {
+	counts := map[string]int{}
+	for _, f := range files {
+		for _, m := range f.Matches {
+			for _, fr := range m.Fragments {
+				counts[fr.Match]++
+			}
+		}
+	}
+
+	suggestion := queryStr
+	query.VisitAtoms(q, func(q query.Q) {
+		fz, ok := q.(*query.Fuzzy)
+		if !ok {
+			return
+		}
+		norm := strings.ToLower
+		if fz.CaseSensitive {
+			norm = func(s string) string { return s }
+		}
+		pattern := norm(fz.Pattern)
+
+		best := ""
+		for term, n := range counts {
+			dist := query.EditDistance(pattern, norm(term))
+			if dist == 0 {
+				// The pattern matched, so it is not misspelled.
+				return
+			}
+			if dist > fz.MaxDistance() {
+				continue
+			}
+			if best == "" || n > counts[best] || n == counts[best] && term < best {
+				best = term
+			}
+		}
+		if best == "" {
+			return
+		}
+
+		if strings.ContainsAny(best, " \"()") {
+			best = quoteAtom(best)
+		}
+		suggestion = strings.NewReplacer(
+			"fuzzy:"+quoteAtom(fz.Pattern), best,
+			"fuzzy:"+fz.Pattern, best,
+		).Replace(suggestion)
+	})
+
+	if suggestion == queryStr {
+		return ""
+	}
+	return suggestion
+}
+
+// facetAtom returns a query atom which restricts a search to files with the
+// facet value, or "" if there is no such atom.
+func facetAtom(name, value string) string {
+	switch name {
+	case zoekt.FacetRepository:
+		return "r:" + quoteAtom("^"+regexp.QuoteMeta(value)+"$")
+	case zoekt.FacetLanguage:
+		return "lang:" + quoteAtom(value)
+	case zoekt.FacetDirectory:
+		if value == "/" {
+			return "f:" + quoteAtom("^[^/]*$")
+		}
+		return "f:" + quoteAtom("^"+regexp.QuoteMeta(value))
+	}
+	return ""
+}
+
+// quoteAtom quotes s as a query string literal.
+func quoteAtom(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + r.Replace(s) + `"`
+}
+
 func (s *Server) formatResults(result *zoekt.SearchResult, query string, localPrint bool) ([]*FileMatch, error) {
 	var fmatches []*FileMatch
 
@@ -99,6 +253,12 @@ func (s *Server) formatResults(result *zoekt.SearchResult, query string, localPr
 			Language:   f.Language,
 			Score:      f.Score,
 			ScoreDebug: f.Debug,
+
+			CommitHash:   f.CommitHash,
+			CommitAuthor: f.CommitAuthor,
+		}
+		if !f.CommitDate.IsZero() {
+			fMatch.CommitDate = &f.CommitDate
 		}
 
 		if dup, ok := seenFiles[string(f.Checksum)]; ok {
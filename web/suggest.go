@@ -0,0 +1,285 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp/syntax"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/zoekt"
+	"github.com/sourcegraph/zoekt/query"
+)
+
+const (
+	// suggestBudget is the latency budget of a suggest request, including
+	// the wait for the search scheduler. The suggestions found within the
+	// budget are returned.
+	suggestBudget = 250 * time.Millisecond
+
+	// defaultNumSuggestions is the default maximum number of suggestions.
+	defaultNumSuggestions = 10
+
+	// maxNumSuggestions caps the num parameter of suggest requests.
+	maxNumSuggestions = 50
+
+	// minSuggestSearchLength is the minimum length of a term for which
+	// repositories, files and symbols are searched.
+	minSuggestSearchLength = 2
+)
+
+// The kinds of suggestions, in the order they are ranked.
+const (
+	SuggestField  = "field"
+	SuggestRepo   = "repo"
+	SuggestFile   = "file"
+	SuggestSymbol = "symbol"
+)
+
+var suggestKindOrder = map[string]int{
+	SuggestField:  0,
+	SuggestRepo:   1,
+	SuggestFile:   2,
+	SuggestSymbol: 3,
+}
+
+// Suggestion is a completion of the last term of a query.
+type Suggestion struct {
+	// Kind is one of SuggestField, SuggestRepo, SuggestFile or
+	// SuggestSymbol.
+	Kind string
+
+	// Text is the completed value, eg. a repository name.
+	Text string
+
+	// Query is the query with its last term replaced by the completion.
+	Query string
+
+	// Don't expose to caller of JSON API
+	prefix bool
+	count  int
+}
+
+// SuggestResult is the reply of /api/suggest.
+type SuggestResult struct {
+	Suggestions []Suggestion
+}
+
+// serveSuggest completes the last term of the q parameter with field
+// keywords, and the names of repositories, file path prefixes and symbols
+// found with the searcher. The searches run concurrently through the
+// searcher, and so through its scheduler, within suggestBudget. Whatever they
+// find in time is ranked and returned.
+func (s *Server) serveSuggest(w http.ResponseWriter, r *http.Request) {
+	if !s.checkAuth(w, r) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+
+	qvals := r.URL.Query()
+	num, err := strconv.Atoi(qvals.Get("num"))
+	if err != nil || num <= 0 {
+		num = defaultNumSuggestions
+	}
+	num = min(num, maxNumSuggestions)
+
+	ctx, cancel := context.WithTimeout(r.Context(), suggestBudget)
+	defer cancel()
+
+	res := SuggestResult{Suggestions: s.suggest(ctx, qvals.Get("q"), num)}
+	if res.Suggestions == nil {
+		res.Suggestions = []Suggestion{}
+	}
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// suggest returns up to num ranked completions of the last term of
+// queryStr.
+func (s *Server) suggest(ctx context.Context, queryStr string, num int) []Suggestion {
+	i := strings.LastIndexByte(queryStr, ' ') + 1
+	before, term := queryStr[:i], queryStr[i:]
+	if strings.HasPrefix(term, "-") {
+		before, term = before+"-", term[1:]
+	}
+
+	// The field of the term decides what to complete. A term without a field
+	// can be completed to anything.
+	field := ""
+	for _, f := range query.Fields() {
+		if strings.HasPrefix(term, f) && len(f) > len(field) {
+			field = f
+		}
+	}
+	value := strings.TrimPrefix(term, field)
+
+	var (
+		mu          sync.Mutex
+		suggestions []Suggestion
+		wg          sync.WaitGroup
+	)
+	add := func(kind, text, atom string, prefix bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		for i := range suggestions {
+			if suggestions[i].Kind == kind && suggestions[i].Text == text {
+				suggestions[i].count++
+				return
+			}
+		}
+		suggestions = append(suggestions, Suggestion{
+			Kind:   kind,
+			Text:   text,
+			Query:  before + atom,
+			prefix: prefix,
+			count:  1,
+		})
+	}
+	run := func(f func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f()
+		}()
+	}
+
+	if field == "" && value != "" {
+		for _, f := range query.Fields() {
+			if strings.HasPrefix(f, value) && f != value {
+				add(SuggestField, f, f, true)
+			}
+		}
+	}
+
+	// The other terms restrict the files and symbols, if they are a valid
+	// query.
+	var restrict query.Q = &query.Const{Value: true}
+	if strings.TrimSpace(strings.TrimSuffix(before, "-")) != "" {
+		if q, err := query.Parse(strings.TrimSuffix(before, "-")); err == nil {
+			restrict = q
+		}
+	}
+
+	if len(value) >= minSuggestSearchLength {
+		if field == "" || field == "r:" || field == "repo:" {
+			run(func() { s.suggestRepos(ctx, value, add) })
+		}
+		if field == "" || field == "f:" || field == "file:" {
+			run(func() { s.suggestFiles(ctx, restrict, value, add) })
+		}
+		if field == "" || field == "sym:" {
+			run(func() { s.suggestSymbols(ctx, restrict, value, add) })
+		}
+	}
+	wg.Wait()
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.prefix != b.prefix {
+			return a.prefix
+		}
+		if a.Kind != b.Kind {
+			return suggestKindOrder[a.Kind] < suggestKindOrder[b.Kind]
+		}
+		if a.count != b.count {
+			return a.count > b.count
+		}
+		if len(a.Text) != len(b.Text) {
+			return len(a.Text) < len(b.Text)
+		}
+		return a.Text < b.Text
+	})
+	if len(suggestions) > num {
+		suggestions = suggestions[:num]
+	}
+	return suggestions
+}
+
+// suggestSearchOptions returns the options of the searches for suggestions,
+// which stop a bit before the budget of ctx runs out to return what they
+// found.
+func suggestSearchOptions(ctx context.Context) *zoekt.SearchOptions {
+	opts := &zoekt.SearchOptions{
+		ShardMaxMatchCount: 100,
+		TotalMaxMatchCount: 1000,
+		MaxDocDisplayCount: 100,
+		MaxWallTime:        suggestBudget * 3 / 4,
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		opts.MaxWallTime = min(opts.MaxWallTime, time.Until(deadline)*3/4)
+	}
+	return opts
+}
+
+func (s *Server) suggestRepos(ctx context.Context, value string, add func(kind, text, atom string, prefix bool)) {
+	re, err := regexp.Compile("(?i)" + regexp.QuoteMeta(value))
+	if err != nil {
+		return
+	}
+	repos, err := s.Searcher.List(ctx, &query.Repo{Regexp: re}, nil)
+	if err != nil {
+		return
+	}
+	lower := strings.ToLower(value)
+	for _, r := range repos.Repos {
+		name := strings.ToLower(r.Repository.Name)
+		prefix := strings.HasPrefix(name, lower) || strings.HasPrefix(name[strings.LastIndexByte(name, '/')+1:], lower)
+		add(SuggestRepo, r.Repository.Name, "r:"+quoteAtom("^"+regexp.QuoteMeta(r.Repository.Name)+"$"), prefix)
+	}
+}
+
+// suggestFiles completes value to the path prefixes up to the end of the
+// path component containing value.
+func (s *Server) suggestFiles(ctx context.Context, restrict query.Q, value string, add func(kind, text, atom string, prefix bool)) {
+	q := query.NewAnd(restrict, &query.Substring{Pattern: value, FileName: true})
+	res, err := s.Searcher.Search(ctx, q, suggestSearchOptions(ctx))
+	if err != nil {
+		return
+	}
+	lower := strings.ToLower(value)
+	for _, f := range res.Files {
+		name := f.FileName
+		i := strings.Index(strings.ToLower(name), lower)
+		if i < 0 || i+len(value) > len(name) {
+			continue
+		}
+		path := name
+		if j := strings.IndexByte(name[i+len(value):], '/'); j >= 0 {
+			path = name[:i+len(value)+j+1]
+		}
+		prefix := i == 0 || name[i-1] == '/'
+		add(SuggestFile, path, "f:"+quoteAtom("^"+regexp.QuoteMeta(path)), prefix)
+	}
+}
+
+// suggestSymbols completes value to the names of the symbols containing it.
+func (s *Server) suggestSymbols(ctx context.Context, restrict query.Q, value string, add func(kind, text, atom string, prefix bool)) {
+	lower := strings.ToLower(value)
+	re, err := syntax.Parse(`\w*`+regexp.QuoteMeta(lower)+`\w*`, syntax.Perl)
+	if err != nil {
+		return
+	}
+	q := query.NewAnd(restrict, &query.Symbol{Expr: &query.Regexp{Regexp: re, Content: true}})
+	res, err := s.Searcher.Search(ctx, q, suggestSearchOptions(ctx))
+	if err != nil {
+		return
+	}
+	for _, f := range res.Files {
+		for _, l := range f.LineMatches {
+			for _, m := range l.LineFragments {
+				if m.LineOffset < 0 || m.LineOffset+m.MatchLength > len(l.Line) {
+					continue
+				}
+				sym := string(l.Line[m.LineOffset : m.LineOffset+m.MatchLength])
+				add(SuggestSymbol, sym, "sym:"+quoteAtom(regexp.QuoteMeta(sym)), strings.HasPrefix(strings.ToLower(sym), lower))
+			}
+		}
+	}
+}
@@ -85,13 +85,42 @@ var TemplateText = map[string]string{
               {{if .Query}}
               value={{.Query}}
               {{end}}
-              id="searchbox" type="text" name="q">
+              id="searchbox" type="text" name="q" list="suggestions" autocomplete="off">
       <div class="input-group-btn">
         <button class="btn btn-primary">Search</button>
       </div>
     </div>
   </div>
 </form>
+{{template "suggest"}}
+`,
+
+	// completes the query of the search boxes with /api/suggest.
+	"suggest": `
+<datalist id="suggestions"></datalist>
+<script>
+(function() {
+  var timer, list = document.getElementById("suggestions");
+  document.querySelectorAll("input[list=suggestions]").forEach(function(box) {
+    box.addEventListener("input", function() {
+      clearTimeout(timer);
+      timer = setTimeout(function() {
+        fetch("api/suggest?q=" + encodeURIComponent(box.value)).then(function(resp) {
+          return resp.ok ? resp.json() : {Suggestions: []};
+        }).then(function(res) {
+          list.innerHTML = "";
+          res.Suggestions.forEach(function(s) {
+            var opt = document.createElement("option");
+            opt.value = s.Query;
+            opt.label = s.Kind + ": " + s.Text;
+            list.appendChild(opt);
+          });
+        });
+      }, 100);
+    });
+  });
+})();
+</script>
 `,
 
 	"navbar": `
@@ -112,6 +141,7 @@ var TemplateText = map[string]string{
           <input class="form-control"
                 placeholder="Search for some code..." role="search"
                 id="navsearchbox" type="text" name="q" autofocus
+                list="suggestions" autocomplete="off"
                 {{if .Query}}
                 value={{.Query}}
                 {{end}}>
@@ -123,6 +153,15 @@ var TemplateText = map[string]string{
             <div class="input-group-addon">Context Lines</div>
             <input class="form-control" id="context" name="ctx" type="number" value="{{.Ctx}}">
           </div>
+          <div class="input-group">
+            <div class="input-group-addon">Sort</div>
+            <select class="form-control" id="sort" name="sort">
+              <option value="score"{{if eq .Sort "score"}} selected{{end}}>score</option>
+              <option value="path"{{if eq .Sort "path"}} selected{{end}}>path</option>
+              <option value="repo"{{if eq .Sort "repo"}} selected{{end}}>repo</option>
+              <option value="commitdate"{{if eq .Sort "commitdate"}} selected{{end}}>commit date</option>
+            </select>
+          </div>
           <button class="btn btn-primary">Search</button>
           <!--Hack: we use a hidden form field to keep track of the debug flag across searches-->
           {{if .Debug}}<input id="debug" name="debug" type="hidden" value="{{.Debug}}">{{end}}
@@ -143,6 +182,7 @@ document.onkeydown=function(e){
   }
 };
 </script>
+{{template "suggest"}}
 `,
 	// search box for the entry page.
 	"search": `
@@ -175,7 +215,9 @@ document.onkeydown=function(e){
           <dt><a href="search?q=foo.*bar">foo.*bar</a></dt><dd>search for the regular expression "foo.*bar"</dd>
           <dt><a href="search?q=-%28Path File%29 Stream">-(Path File) Stream</a></dt><dd>search "Stream", but exclude files containing both "Path" and "File"</dd>
           <dt><a href="search?q=-Path%5c+file+Stream">-Path\ file Stream</a></dt><dd>search "Stream", but exclude files containing "Path File"</dd>
+          <dt><a href="search?q=near%3A3%28lock+unlock%29">near:3(lock unlock)</a></dt><dd>search for files where "lock" and "unlock" are at most 3 lines apart</dd>
           <dt><a href="search?q=sym:data">sym:data</a></span></dt><dd>search for symbol definitions containing "data"</dd>
+          <dt><a href="search?q=insym%3AClient+retry">insym:Client retry</a></dt><dd>search for "retry" inside the definitions of symbols matching "Client"</dd>
           <dt><a href="search?q=phone+r:droid">phone r:droid</a></dt><dd>search for "phone" in repositories whose name contains "droid"</dd>
           <dt><a href="search?q=phone+archived:no">phone archived:no</a></dt><dd>search for "phone" in repositories that are not archived</dd>
           <dt><a href="search?q=phone+fork:no">phone fork:no</a></dt><dd>search for "phone" in repositories that are not forks</dd>
@@ -223,11 +265,59 @@ document.onkeydown=function(e){
     <h5>
       {{if .Stats.Crashes}}<br><b>{{.Stats.Crashes}} shards crashed</b><br>{{end}}
       {{ $fileCount := len .FileMatches }}
-      Found {{.Stats.MatchCount}} results in {{.Stats.FileCount}} files{{if or (lt $fileCount .Stats.FileCount) (or (gt .Stats.ShardsSkipped 0) (gt .Stats.FilesSkipped 0)) }},
+      Found {{.Stats.MatchCount}} results in {{.Stats.FileCount}} files{{if .Commits}} and {{len .Commits}} commits{{end}}{{if .Paginated}}{{if .NextCursor}},
+        showing {{ $fileCount }} files (<a rel="nofollow"
+           href="search?q={{.Last.Query}}&num={{.Last.Num}}&sort={{.Last.Sort}}&cursor={{.NextCursor}}">next page</a>).
+      {{else}}.{{end}}
+      {{else if or (lt $fileCount .Stats.FileCount) (or (gt .Stats.ShardsSkipped 0) (gt .Stats.FilesSkipped 0)) }},
         showing top {{ $fileCount }} files (<a rel="nofollow"
-           href="search?q={{.Last.Query}}&num={{More .Last.Num}}">show more</a>).
+           href="search?q={{.Last.Query}}&num={{More .Last.Num}}&sort={{.Last.Sort}}">show more</a>, <a rel="nofollow"
+           href="search?q={{.Last.Query}}&num={{.Last.Num}}&sort={{.Last.Sort}}&cursor=">page through all</a>).
       {{else}}.{{end}}
     </h5>
+    {{if .DidYouMean}}
+    <h5>Did you mean <a rel="nofollow" href="search?q={{.DidYouMean}}&num={{.Last.Num}}&sort={{.Last.Sort}}">{{.DidYouMean}}</a>?</h5>
+    {{end}}
+    {{if .Explain}}
+    <details class="explain">
+      <summary><small>Query plan: {{len .Explain}} shards searched in {{.Stats.Duration}}</small></summary>
+      <ul>
+        {{range .Explain}}
+        <li><small><code>{{.Shard}}</code>: {{.MatchTreeConstruction}} construction, {{.MatchTreeSearch}} search,
+          {{.FileCount}} of {{.FilesConsidered}} files matched{{if .BruteForce}}, <b>brute force</b>{{end}}</small>
+          <ul>
+            <li><small>query <code>{{.Query}}</code></small></li>
+            {{if .MatchTree}}<li><small>match tree <code>{{.MatchTree}}</code></small></li>{{end}}
+            {{if .Ngrams}}
+            <li><small>ngrams</small>
+              <ul>
+                {{range .Ngrams}}{{$freqs := .Frequencies}}
+                <li><small>{{if .FileName}}file {{end}}<code>{{.Pattern}}</code>:{{range $i, $ng := .Ngrams}} <code>{{$ng}}</code> &times;{{index $freqs $i}}{{end}}</small></li>
+                {{end}}
+              </ul>
+            </li>
+            {{end}}
+          </ul>
+        </li>
+        {{end}}
+      </ul>
+    </details>
+    {{end}}
+    <div class="row">
+    {{if .Facets}}
+    <div class="col-md-2 facets">
+      {{range .Facets}}
+      <h6>{{.Title}}</h6>
+      <ul class="list-unstyled">
+        {{range .Values}}
+        <li><small>{{if .Atom}}<a href="#" title="restrict search to {{.Value}}" onclick="zoektAddQ('{{.Atom}}'); return false;">{{.Value}}</a>{{else}}{{.Value}}{{end}}
+          <span class="badge">{{.Count}}</span></small></li>
+        {{end}}
+      </ul>
+      {{end}}
+    </div>
+    {{end}}
+    <div class="{{if .Facets}}col-md-10{{else}}col-md-12{{end}}">
     {{range .FileMatches}}
     <table class="table table-hover table-condensed">
       <thead>
@@ -241,6 +331,7 @@ document.onkeydown=function(e){
                    title="restrict search to files written in {{.Language}}"
                    onclick="zoektAddQ('lang:&quot;{{.Language}}&quot;')" class="label label-primary">language {{.Language}}</button></span>{{end}}
               {{if .DuplicateID}}<a class="label label-dup" href="#{{.DuplicateID}}">Duplicate result</a>{{end}}
+              {{if .CommitDate}}<span style="font-weight: normal" title="{{.CommitHash}}">last changed{{if .CommitAuthor}} by {{.CommitAuthor}}{{end}}, {{TimeAgo .CommitDate}}</span>{{end}}
             </small>
           </th>
         </tr>
@@ -268,6 +359,25 @@ document.onkeydown=function(e){
       {{end}}
     </table>
     {{end}}
+    {{if .Commits}}
+    <table class="table table-hover table-condensed">
+      <thead>
+        <tr><th colspan="3"><small>Commits</small></th></tr>
+      </thead>
+      <tbody>
+        {{range .Commits}}
+        <tr>
+          <td style="width: 1%; white-space: nowrap;"><small><tt title="{{.Hash}}">{{.ShortHash}}</tt></small></td>
+          <td><small>{{.Repo}}: <b>{{.Title}}</b>
+            {{range .Paths}}<br><tt>{{.}}</tt>{{end}}</small></td>
+          <td style="width: 1%; white-space: nowrap;"><small>{{.Author}}, {{TimeAgo .Date}}</small></td>
+        </tr>
+        {{end}}
+      </tbody>
+    </table>
+    {{end}}
+    </div>
+    </div>
 
   <nav class="navbar navbar-default navbar-bottom">
     <div class="container">